@@ -0,0 +1,175 @@
+// Package archive keeps every forecast munch fetches, as it was issued, to look back at what was forecast when
+//
+// Providers only ever serve their latest run. The archive records each fetch with its time of issue, so
+// that the forecast for a location can be retrieved as it looked at any earlier time, and two issues can
+// be compared to see how the forecast for a given hour evolved as it drew nearer.
+//
+// Forecasts are kept in a store.ForecastStore; wrap a provider with NewProvider to archive its fetches:
+//
+//	s, err := store.OpenBolt("forecasts.db")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	a := archive.New(s)
+//	p, _ := providers.New("open-meteo", cfg)
+//	archived := archive.NewProvider("open-meteo", providers.ConfiguredModel("open-meteo", cfg), p, a)
+//	// Fetch through archived, later:
+//	then, err := a.AsIssued(ctx, "open-meteo", "best_match", coords, time.Now().Add(-24*time.Hour))
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tinkershack/meteomunch/plumber"
+	"github.com/tinkershack/meteomunch/store"
+)
+
+// ErrNoIssue is returned when no forecast had been issued for the location by the requested time
+var ErrNoIssue = errors.New("no forecast issued")
+
+// DefaultTolerance is how far, in degrees, an archived forecast may be from the requested location;
+// half a cell of the 0.25 degree grids most models use
+const DefaultTolerance = 0.125
+
+// Archive records and retrieves forecasts as issued
+//
+// An Archive is safe for concurrent use if its store is.
+type Archive struct {
+	store     store.ForecastStore
+	tolerance float64
+}
+
+// New returns an Archive keeping forecasts in s
+func New(s store.ForecastStore) *Archive {
+	return &Archive{store: s, tolerance: DefaultTolerance}
+}
+
+// Record archives a forecast of provider's model as issued at the given time
+func (a *Archive) Record(ctx context.Context, provider, model string, issuedAt time.Time, data *plumber.BaseData) error {
+	return a.store.Put(ctx, store.NewRecord(provider, model, issuedAt, data))
+}
+
+// AsIssued returns the latest forecast for coords issued at or before t
+//
+// An empty model matches forecasts of any model. The error wraps ErrNoIssue if there's none.
+func (a *Archive) AsIssued(ctx context.Context, provider, model string, coords *plumber.Coordinates, t time.Time) (*store.Record, error) {
+	records, err := a.store.Query(ctx, store.Query{
+		Provider:      provider,
+		Model:         model,
+		Location:      coords,
+		Tolerance:     a.tolerance,
+		FetchedBefore: t.Add(time.Millisecond), // Fetch times are kept to the millisecond, make t inclusive
+		Limit:         1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: %s at %.4f,%.4f by %s", ErrNoIssue, provider, coords.Latitude, coords.Longitude, t.UTC().Format(time.RFC3339))
+	}
+	return records[0], nil
+}
+
+// Issues lists the forecasts for coords issued within [from, to), most recent first, at most limit of them
+//
+// Zero times leave that end of the range open, a zero limit lists them all.
+func (a *Archive) Issues(ctx context.Context, provider, model string, coords *plumber.Coordinates, from, to time.Time, limit int) ([]store.Key, error) {
+	records, err := a.store.Query(ctx, store.Query{
+		Provider:      provider,
+		Model:         model,
+		Location:      coords,
+		Tolerance:     a.tolerance,
+		FetchedAfter:  from,
+		FetchedBefore: to,
+		Limit:         limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]store.Key, len(records))
+	for i, r := range records {
+		keys[i] = r.Key
+	}
+	return keys, nil
+}
+
+// Diff compares what the forecasts issued by the times older and newer said about the target hour
+func (a *Archive) Diff(ctx context.Context, provider, model string, coords *plumber.Coordinates, older, newer, target time.Time) (*Diff, error) {
+	o, err := a.AsIssued(ctx, provider, model, coords, older)
+	if err != nil {
+		return nil, err
+	}
+	n, err := a.AsIssued(ctx, provider, model, coords, newer)
+	if err != nil {
+		return nil, err
+	}
+	return Compare(o, n, target)
+}
+
+// Issue describes one side of a Diff
+type Issue struct {
+	Provider string    `json:"provider"`
+	Model    string    `json:"model"`
+	IssuedAt time.Time `json:"issued_at"`
+	LeadTime float64   `json:"lead_time"` // Hours from the issue to the target hour
+}
+
+// Change is how the forecast value of a field evolved between two issues
+type Change struct {
+	Older float64 `json:"older"`
+	Newer float64 `json:"newer"`
+	Delta float64 `json:"delta"` // Newer - Older
+}
+
+// Diff is how the forecast for a target hour changed from one issue to another
+type Diff struct {
+	Target  time.Time         `json:"target"`
+	Older   Issue             `json:"older"`
+	Newer   Issue             `json:"newer"`
+	Changes map[string]Change `json:"changes"` // Keyed by the JSON name of the hourly field, for fields both issues forecast
+	Changed []string          `json:"changed"` // Names of the fields whose value differs, sorted
+}
+
+// Compare diffs the hourly forecasts of two archived issues for the target hour
+func Compare(older, newer *store.Record, target time.Time) (*Diff, error) {
+	t := target.Unix()
+	oi, ni := older.Data.Hourly.Index(t), newer.Data.Hourly.Index(t)
+	if oi < 0 || ni < 0 {
+		return nil, fmt.Errorf("%w: %s isn't covered by both issues", ErrNoIssue, target.UTC().Format(time.RFC3339))
+	}
+
+	d := &Diff{
+		Target:  target.UTC(),
+		Older:   issue(older, target),
+		Newer:   issue(newer, target),
+		Changes: make(map[string]Change),
+		Changed: []string{},
+	}
+	ov, nv := older.Data.Hourly.Values(oi), newer.Data.Hourly.Values(ni)
+	for name, o := range ov {
+		n, ok := nv[name]
+		if !ok {
+			continue
+		}
+		d.Changes[name] = Change{Older: o, Newer: n, Delta: n - o}
+		if n != o {
+			d.Changed = append(d.Changed, name)
+		}
+	}
+	sort.Strings(d.Changed)
+	return d, nil
+}
+
+func issue(r *store.Record, target time.Time) Issue {
+	return Issue{
+		Provider: r.Provider,
+		Model:    r.Model,
+		IssuedAt: r.FetchedAt,
+		LeadTime: target.Sub(r.FetchedAt).Hours(),
+	}
+}
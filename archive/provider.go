@@ -0,0 +1,71 @@
+package archive
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	e "github.com/tinkershack/meteomunch/errors"
+	"github.com/tinkershack/meteomunch/logger"
+	"github.com/tinkershack/meteomunch/plumber"
+	"github.com/tinkershack/meteomunch/providers"
+)
+
+// recordTimeout bounds writing a forecast to the archive, which outlives the fetch's context
+const recordTimeout = 5 * time.Second
+
+// Provider is a providers.Provider that archives every forecast it fetches
+//
+// Put it directly in front of the upstream provider, behind any cache, so that only fresh issues are archived.
+// Archive failures are logged and don't fail the fetch.
+type Provider struct {
+	name    string
+	model   string
+	next    providers.Provider
+	archive *Archive
+	logger  *slog.Logger
+}
+
+// NewProvider returns next archiving its forecasts in a under the provider name and the model it's configured for,
+// see providers.ConfiguredModel
+func NewProvider(name, model string, next providers.Provider, a *Archive) *Provider {
+	return &Provider{
+		name:    name,
+		model:   model,
+		next:    next,
+		archive: a,
+		logger:  logger.NewTag("archive"),
+	}
+}
+
+// FetchData fetches from upstream and archives the forecast as issued now
+//
+// Forecasts fetched for a selection of Fields in the Request aren't archived, being incomplete, and neither
+// are ranges of days chosen in its Options, which aren't forecasts. Those of a model chosen in its Options
+// are archived under that model.
+func (p *Provider) FetchData(ctx context.Context, coords *plumber.Coordinates, r providers.Request) (*plumber.BaseData, error) {
+	data, err := p.next.FetchData(ctx, coords, r)
+	if err != nil {
+		return nil, err
+	}
+	if r.Fields != nil || r.Options.StartDate != "" {
+		return data, nil
+	}
+
+	// The forecast has been paid for; archive it even if the caller has given up on it meanwhile
+	rctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), recordTimeout)
+	defer cancel()
+	model := p.model
+	if m := r.Options.Model; m != "" {
+		model = m
+	}
+	if err := p.archive.Record(rctx, p.name, model, time.Now(), data); err != nil {
+		p.logger.Error(e.FAIL, "err", err, "description", "Couldn't archive forecast", "provider", p.name)
+	}
+	return data, nil
+}
+
+// QueryParams returns the upstream query parameters for coords
+func (p *Provider) QueryParams(coords *plumber.Coordinates) map[string]string {
+	return p.next.QueryParams(coords)
+}
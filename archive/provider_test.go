@@ -0,0 +1,61 @@
+package archive
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tinkershack/meteomunch/plumber"
+	"github.com/tinkershack/meteomunch/providers"
+	"github.com/tinkershack/meteomunch/store"
+)
+
+// stubProvider returns a forecast with an hourly time axis for the coordinates it's asked for
+type stubProvider struct{}
+
+func (stubProvider) FetchData(_ context.Context, coords *plumber.Coordinates, _ providers.Request) (*plumber.BaseData, error) {
+	bd := &plumber.BaseData{Latitude: coords.Latitude, Longitude: coords.Longitude}
+	bd.Hourly.Time = []int64{1700000000, 1700003600}
+	return bd, nil
+}
+
+func (stubProvider) QueryParams(*plumber.Coordinates) map[string]string {
+	return nil
+}
+
+func TestProviderRecordsModel(t *testing.T) {
+	tests := []struct {
+		name  string
+		req   providers.Request
+		model string // Model of the record, empty if nothing is archived
+	}{
+		{"configured", providers.Request{}, "best_match"},
+		{"chosen", providers.Request{Options: providers.Options{Model: "icon_seamless"}}, "icon_seamless"},
+		{"partial", providers.Request{Fields: plumber.Fields{plumber.BlockHourly: {"cape"}}}, ""},
+		{"range", providers.Request{Options: providers.Options{StartDate: "2024-01-01", EndDate: "2024-01-02"}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := store.NewMemory()
+			p := NewProvider("open-meteo", "best_match", stubProvider{}, New(s))
+			if _, err := p.FetchData(context.Background(), plumber.NewCoordinates(46.68, 7.86), tt.req); err != nil {
+				t.Fatal(err)
+			}
+			records, err := s.Query(context.Background(), store.Query{Provider: "open-meteo"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.model == "" {
+				if len(records) != 0 {
+					t.Fatalf("archived %d records, want none", len(records))
+				}
+				return
+			}
+			if len(records) != 1 {
+				t.Fatalf("archived %d records, want 1", len(records))
+			}
+			if records[0].Model != tt.model {
+				t.Errorf("model = %q, want %q", records[0].Model, tt.model)
+			}
+		})
+	}
+}
@@ -0,0 +1,345 @@
+// Package aviation parses the METAR and TAF reports of aviation weather stations and maps them onto plumber types.
+//
+// A METAR is an observation: it becomes a plumber.CurrentData, or a verify.Observation to score forecasts
+// against. A TAF is a terminal aerodrome forecast: its change groups are resolved into an hourly Timeline
+// and plumber.HourlyData. Stations maps the ICAO codes of the reporting stations onto plumber.Location.
+//
+// Values are converted into plumber.CommonUnits; heights of cloud bases are in m above ground.
+package aviation
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidReport is returned when a report is missing the groups every METAR or TAF must have
+	ErrInvalidReport = errors.New("invalid report")
+	// ErrNoReport is returned for NIL reports, which stations issue when they have nothing to report
+	ErrNoReport = errors.New("station filed no report")
+	// ErrUnknownStation is returned when a station isn't in the station list
+	ErrUnknownStation = errors.New("unknown station")
+)
+
+const (
+	kmhPerKnot       = 1.852
+	kmhPerMPS        = 3.6
+	metresPerMile    = 1609.344 // Statute mile
+	metresPerFoot    = 0.3048
+	hPaPerInHg       = 33.8639
+	unlimitedVisible = 10000 // Visibility in m reported as 9999, P6SM or CAVOK
+)
+
+// Wind is the surface wind of a report
+type Wind struct {
+	Direction int     `json:"direction"` // Where the wind blows from in degrees, 0 if Variable or calm
+	Variable  bool    `json:"variable"`  // VRB: the direction is too variable to report
+	Speed     float64 `json:"speed"`     // Mean speed in km/h
+	Gusts     float64 `json:"gusts"`     // Gust speed in km/h, 0 without gusts
+	// Extremes of the direction when it varies by 60° or more, e.g. 280V350
+	VariableFrom *int `json:"variable_from,omitempty"`
+	VariableTo   *int `json:"variable_to,omitempty"`
+}
+
+// Cloud is a cloud layer of a report
+type Cloud struct {
+	Cover string   `json:"cover"`          // FEW, SCT, BKN, OVC or VV, the vertical visibility into an obscured sky
+	Base  *float64 `json:"base"`           // Height of the base above ground in m, nil if not reported
+	Type  string   `json:"type,omitempty"` // CB for cumulonimbus, TCU for towering cumulus
+}
+
+// Conditions are the weather conditions of a METAR or of a TAF period
+//
+// TAF change groups only carry the elements that change, so every element is optional.
+type Conditions struct {
+	Wind       *Wind    `json:"wind,omitempty"`
+	Visibility *float64 `json:"visibility,omitempty"` // Prevailing visibility in m
+	CAVOK      bool     `json:"cavok,omitempty"`      // Ceiling and visibility OK: 10 km or more, no cloud below 1500 m, no weather
+	Weather    []string `json:"weather,omitempty"`    // Present weather groups, e.g. -SHRA
+	NSW        bool     `json:"nsw,omitempty"`        // No significant weather, ending the weather of a previous TAF period
+	Clouds     []Cloud  `json:"clouds,omitempty"`
+	SkyClear   bool     `json:"sky_clear,omitempty"` // SKC, CLR, NSC or NCD
+}
+
+// VisibilityMetres returns the prevailing visibility in m; ok is false if the conditions don't tell
+func (c *Conditions) VisibilityMetres() (float64, bool) {
+	switch {
+	case c.CAVOK:
+		return unlimitedVisible, true
+	case c.Visibility != nil:
+		return *c.Visibility, true
+	}
+	return 0, false
+}
+
+// CloudCover estimates the total cloud cover in % from the most extensive layer
+func (c *Conditions) CloudCover() int {
+	cover := 0
+	for _, cl := range c.Clouds {
+		// Middle of the octa range of each cover: FEW 1-2, SCT 3-4, BKN 5-7, OVC 8
+		switch cl.Cover {
+		case "FEW":
+			cover = max(cover, 20)
+		case "SCT":
+			cover = max(cover, 45)
+		case "BKN":
+			cover = max(cover, 75)
+		case "OVC", "VV":
+			cover = 100
+		}
+	}
+	return cover
+}
+
+// WeatherCode maps the present weather and clouds onto the WMO weather codes used by plumber
+//
+// The most severe weather wins; without any, the code reflects the cloud cover.
+func (c *Conditions) WeatherCode() int {
+	code := 0
+	switch cover := c.CloudCover(); {
+	case cover >= 75:
+		code = 3 // Overcast
+	case cover >= 45:
+		code = 2 // Partly cloudy
+	case cover > 0:
+		code = 1 // Mainly clear
+	}
+	for _, w := range c.Weather {
+		code = max(code, weatherGroupCode(w))
+	}
+	return code
+}
+
+// weatherGroupCode maps a single present weather group, e.g. +TSRA, onto a WMO weather code, 0 if insignificant
+func weatherGroupCode(w string) int {
+	if strings.HasPrefix(w, "VC") {
+		return 0 // In the vicinity, not at the station
+	}
+	intensity := 1
+	switch {
+	case strings.HasPrefix(w, "-"):
+		intensity, w = 0, w[1:]
+	case strings.HasPrefix(w, "+"):
+		intensity, w = 2, w[1:]
+	}
+	graded := func(light, moderate, heavy int) int {
+		return []int{light, moderate, heavy}[intensity]
+	}
+	has := func(s string) bool { return strings.Contains(w, s) }
+
+	switch {
+	case has("TS") && (has("GR") || has("GS")):
+		return graded(96, 96, 99) // Thunderstorm with hail
+	case has("TS"):
+		return 95 // Thunderstorm
+	case has("FZDZ"):
+		return graded(56, 56, 57) // Freezing drizzle
+	case has("FZRA"):
+		return graded(66, 66, 67) // Freezing rain
+	case has("SH") && has("SN"):
+		return graded(85, 85, 86) // Snow showers
+	case has("SH"):
+		return graded(80, 81, 82) // Rain showers, hail showers count as violent ones
+	case has("SN"):
+		return graded(71, 73, 75) // Snow fall
+	case has("RA"):
+		return graded(61, 63, 65) // Rain
+	case has("DZ"):
+		return graded(51, 53, 55) // Drizzle
+	case has("SG"), has("PL"), has("IC"):
+		return 77 // Snow grains
+	case has("FZFG"):
+		return 48 // Depositing rime fog
+	case has("FG") && !has("MIFG") && !has("BCFG") && !has("PRFG"):
+		return 45 // Fog; shallow, patchy and partial fog leave the prevailing visibility alone
+	}
+	return 0
+}
+
+var (
+	windGroup       = regexp.MustCompile(`^(\d{3}|VRB|///)(\d{2,3}|//)(?:G(\d{2,3}))?(KT|MPS|KMH)$`)
+	windVariation   = regexp.MustCompile(`^(\d{3})V(\d{3})$`)
+	visibilityGroup = regexp.MustCompile(`^(\d{4})(NDV)?$`)
+	directionalVis  = regexp.MustCompile(`^\d{4}(N|NE|E|SE|S|SW|W|NW)$`)
+	visibilitySM    = regexp.MustCompile(`^([PM])?(?:(\d{1,2})|(\d{1,2})/(\d{1,2}))SM$`)
+	wholeMiles      = regexp.MustCompile(`^\d$`)
+	fractionSM      = regexp.MustCompile(`^(\d{1,2})/(\d{1,2})SM$`)
+	rvrGroup        = regexp.MustCompile(`^R\d{2}[LCR]?/`)
+	weatherGroup    = regexp.MustCompile(`^(?:[-+]|VC)?(?:MI|PR|BC|DR|BL|SH|TS|FZ)?(?:DZ|RA|SN|SG|IC|PL|GR|GS|UP|BR|FG|FU|VA|DU|SA|HZ|PY|PO|SQ|FC|SS|DS)*$`)
+	recentWeather   = regexp.MustCompile(`^RE[A-Z]{2,}$`)
+	cloudGroup      = regexp.MustCompile(`^(FEW|SCT|BKN|OVC|VV)(\d{3}|///)(CB|TCU|///)?$`)
+)
+
+// parseGroup parses the condition group at tokens[i] into c
+//
+// It returns the number of tokens consumed, 0 if tokens[i] isn't a condition group.
+func (c *Conditions) parseGroup(tokens []string, i int) int {
+	t := tokens[i]
+	switch t {
+	case "CAVOK":
+		c.CAVOK = true
+		return 1
+	case "NSW":
+		c.NSW = true
+		return 1
+	case "SKC", "CLR", "NSC", "NCD":
+		c.SkyClear = true
+		return 1
+	}
+
+	if m := windGroup.FindStringSubmatch(t); m != nil {
+		if m[1] == "///" || m[2] == "//" {
+			return 1 // Not measured
+		}
+		scale := unitScale(m[4])
+		w := &Wind{Variable: m[1] == "VRB", Speed: round1(atof(m[2]) * scale)}
+		if !w.Variable {
+			w.Direction = atoi(m[1])
+		}
+		if m[3] != "" {
+			w.Gusts = round1(atof(m[3]) * scale)
+		}
+		c.Wind = w
+		return 1
+	}
+	if m := windVariation.FindStringSubmatch(t); m != nil && c.Wind != nil {
+		from, to := atoi(m[1]), atoi(m[2])
+		c.Wind.VariableFrom, c.Wind.VariableTo = &from, &to
+		return 1
+	}
+
+	if m := visibilityGroup.FindStringSubmatch(t); m != nil {
+		v := atof(m[1])
+		if v == 9999 {
+			v = unlimitedVisible
+		}
+		c.Visibility = &v
+		return 1
+	}
+	if directionalVis.MatchString(t) || rvrGroup.MatchString(t) || recentWeather.MatchString(t) {
+		return 1 // Minimum visibility by direction, runway visual range and recent weather don't describe the prevailing conditions
+	}
+	// US style visibility in statute miles: 10SM, 1/2SM, M1/4SM, P6SM or 1 1/2SM
+	if wholeMiles.MatchString(t) && i+1 < len(tokens) {
+		if m := fractionSM.FindStringSubmatch(tokens[i+1]); m != nil {
+			v := math.Round((atof(t) + atof(m[1])/atof(m[2])) * metresPerMile)
+			c.Visibility = &v
+			return 2
+		}
+	}
+	if m := visibilitySM.FindStringSubmatch(t); m != nil {
+		var miles float64
+		if m[2] != "" {
+			miles = atof(m[2])
+		} else {
+			miles = atof(m[3]) / atof(m[4])
+		}
+		v := math.Min(math.Round(miles*metresPerMile), unlimitedVisible)
+		if m[1] == "P" {
+			v = unlimitedVisible
+		}
+		c.Visibility = &v
+		return 1
+	}
+
+	if m := cloudGroup.FindStringSubmatch(t); m != nil {
+		cl := Cloud{Cover: m[1]}
+		if m[2] != "///" {
+			base := math.Round(atof(m[2]) * 100 * metresPerFoot)
+			cl.Base = &base
+		}
+		if m[3] != "///" {
+			cl.Type = m[3]
+		}
+		c.Clouds = append(c.Clouds, cl)
+		return 1
+	}
+
+	if t != "" && t != "-" && t != "+" && t != "VC" && weatherGroup.MatchString(t) {
+		c.Weather = append(c.Weather, t)
+		return 1
+	}
+	return 0
+}
+
+// merge returns c with the elements that o carries replacing those of c, as a TAF change group does
+func (c Conditions) merge(o Conditions) Conditions {
+	if o.Wind != nil {
+		c.Wind = o.Wind
+	}
+	if o.CAVOK {
+		return Conditions{Wind: c.Wind, CAVOK: true}
+	}
+	if o.Visibility != nil {
+		c.Visibility, c.CAVOK = o.Visibility, false
+	}
+	if len(o.Weather) > 0 || o.NSW {
+		c.Weather, c.NSW, c.CAVOK = o.Weather, o.NSW, false
+	}
+	if len(o.Clouds) > 0 || o.SkyClear {
+		c.Clouds, c.SkyClear, c.CAVOK = o.Clouds, o.SkyClear, false
+	}
+	return c
+}
+
+// unitScale returns the factor converting wind speeds in the unit of a wind group to km/h
+func unitScale(unit string) float64 {
+	switch unit {
+	case "MPS":
+		return kmhPerMPS
+	case "KMH":
+		return 1
+	default:
+		return kmhPerKnot
+	}
+}
+
+// round1 rounds converted speeds to 0.1 km/h, hiding the noise of the conversion
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
+
+// atoi and atof parse digits already matched by a pattern, so they can't fail
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+func atof(s string) float64 {
+	f, _ := strconv.ParseFloat(s, 64)
+	return f
+}
+
+// resolveTime completes a report's day of month and UTC time of day with the month and year of ref,
+// picking the candidate closest to ref so that reports issued around the turn of a month resolve correctly
+//
+// hour may be 24, the end of the day, as TAF validity periods use it.
+func resolveTime(day, hour, minute int, ref time.Time) (time.Time, error) {
+	if day < 1 || day > 31 || hour > 24 || minute > 59 {
+		return time.Time{}, fmt.Errorf("%w: no such time: day %d %02d:%02d", ErrInvalidReport, day, hour, minute)
+	}
+	ref = ref.UTC()
+	var best time.Time
+	for _, months := range []int{-1, 0, 1} {
+		first := time.Date(ref.Year(), ref.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+		t := first.AddDate(0, 0, day-1)
+		if t.Month() != first.Month() {
+			continue // The month has no such day
+		}
+		t = t.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+		if best.IsZero() || t.Sub(ref).Abs() < best.Sub(ref).Abs() {
+			best = t
+		}
+	}
+	return best, nil
+}
+
+// tokenize splits a report into its groups, dropping the = that terminates it
+func tokenize(raw string) []string {
+	return strings.Fields(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(raw), "=")))
+}
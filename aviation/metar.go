@@ -0,0 +1,222 @@
+package aviation
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/tinkershack/meteomunch/plumber"
+	"github.com/tinkershack/meteomunch/verify"
+)
+
+// METAR is a routine (or, for SPECI, special) weather observation of an aviation station
+type METAR struct {
+	Raw        string    `json:"raw"`
+	Station    string    `json:"station"` // ICAO code
+	Time       time.Time `json:"time"`
+	Special    bool      `json:"special,omitempty"`    // SPECI, issued on a significant change between routine reports
+	Auto       bool      `json:"auto,omitempty"`       // Fully automated observation
+	Correction bool      `json:"correction,omitempty"` // COR, corrects an earlier report
+	Conditions
+	Temperature *float64 `json:"temperature,omitempty"` // in °C
+	DewPoint    *float64 `json:"dew_point,omitempty"`   // in °C
+	QNH         *float64 `json:"qnh,omitempty"`         // Altimeter setting, the pressure reduced to mean sea level, in hPa
+	Trend       string   `json:"trend,omitempty"`       // Trend forecast appended to the report, e.g. NOSIG
+	Remarks     string   `json:"remarks,omitempty"`     // Everything after RMK, unparsed
+}
+
+var (
+	stationGroup     = regexp.MustCompile(`^[A-Z][A-Z0-9]{3}$`)
+	timeGroup        = regexp.MustCompile(`^(\d{2})(\d{2})(\d{2})Z$`)
+	temperatureGroup = regexp.MustCompile(`^(M?\d{2}|//)/(M?\d{2}|//)?$`)
+	qnhGroup         = regexp.MustCompile(`^Q(\d{4})$`)
+	altimeterGroup   = regexp.MustCompile(`^A(\d{4})$`)
+)
+
+// ParseMETAR parses a raw METAR or SPECI report
+//
+// Reports only carry the day of month and time of day of the observation; ref, typically the time the
+// report was received, supplies the month and year. Groups that don't describe the conditions at the
+// station, like runway states or wind shear warnings, are skipped.
+func ParseMETAR(raw string, ref time.Time) (*METAR, error) {
+	tokens := tokenize(raw)
+	m := &METAR{Raw: strings.Join(tokens, " ")}
+
+	i := 0
+	if i < len(tokens) && (tokens[i] == "METAR" || tokens[i] == "SPECI") {
+		m.Special = tokens[i] == "SPECI"
+		i++
+	}
+	if i < len(tokens) && tokens[i] == "COR" {
+		m.Correction = true
+		i++
+	}
+	if i >= len(tokens) || !stationGroup.MatchString(tokens[i]) {
+		return nil, fmt.Errorf("%w: no station: %q", ErrInvalidReport, m.Raw)
+	}
+	m.Station = tokens[i]
+	i++
+
+	if i >= len(tokens) {
+		return nil, fmt.Errorf("%w: no observation time: %q", ErrInvalidReport, m.Raw)
+	}
+	tm := timeGroup.FindStringSubmatch(tokens[i])
+	if tm == nil {
+		return nil, fmt.Errorf("%w: no observation time: %q", ErrInvalidReport, m.Raw)
+	}
+	t, err := resolveTime(atoi(tm[1]), atoi(tm[2]), atoi(tm[3]), ref)
+	if err != nil {
+		return nil, err
+	}
+	m.Time = t
+	i++
+
+modifiers:
+	for ; i < len(tokens); i++ {
+		switch tokens[i] {
+		case "NIL":
+			return nil, fmt.Errorf("%w: %s", ErrNoReport, m.Station)
+		case "AUTO":
+			m.Auto = true
+		case "COR":
+			m.Correction = true
+		default:
+			break modifiers
+		}
+	}
+
+	for i < len(tokens) {
+		t := tokens[i]
+		switch {
+		case t == "RMK":
+			m.Remarks = strings.Join(tokens[i+1:], " ")
+			return m, nil
+		case t == "NOSIG" || t == "BECMG" || t == "TEMPO":
+			// The trend runs up to the remarks
+			end := i
+			for end < len(tokens) && tokens[end] != "RMK" {
+				end++
+			}
+			m.Trend = strings.Join(tokens[i:end], " ")
+			i = end
+			continue
+		}
+
+		if tg := temperatureGroup.FindStringSubmatch(t); tg != nil {
+			m.Temperature = signedTemperature(tg[1])
+			m.DewPoint = signedTemperature(tg[2])
+			i++
+			continue
+		}
+		if q := qnhGroup.FindStringSubmatch(t); q != nil {
+			v := atof(q[1])
+			m.QNH = &v
+			i++
+			continue
+		}
+		if a := altimeterGroup.FindStringSubmatch(t); a != nil {
+			v := math.Round(atof(a[1])/100*hPaPerInHg*10) / 10
+			m.QNH = &v
+			i++
+			continue
+		}
+
+		if n := m.Conditions.parseGroup(tokens, i); n > 0 {
+			i += n
+			continue
+		}
+		i++
+	}
+	return m, nil
+}
+
+// signedTemperature parses a METAR temperature, M prefixing negative ones; nil if not reported
+func signedTemperature(s string) *float64 {
+	if s == "" || s == "//" {
+		return nil
+	}
+	v := atof(strings.TrimPrefix(s, "M"))
+	if strings.HasPrefix(s, "M") {
+		v = -v
+	}
+	return &v
+}
+
+// RelativeHumidity returns the relative humidity in % derived from the temperature and dew point
+func (m *METAR) RelativeHumidity() (float64, bool) {
+	if m.Temperature == nil || m.DewPoint == nil {
+		return 0, false
+	}
+	return plumber.RelativeHumidity(*m.Temperature, *m.DewPoint), true
+}
+
+// CurrentData maps the observation onto plumber.CurrentData
+//
+// METARs don't report precipitation amounts, snow depth, surface pressure or daylight, so those stay zero.
+func (m *METAR) CurrentData() plumber.CurrentData {
+	cd := plumber.CurrentData{
+		Time:        m.Time.Unix(),
+		WeatherCode: m.WeatherCode(),
+		CloudCover:  m.CloudCover(),
+	}
+	if m.Wind != nil {
+		cd.WindSpeed10M = m.Wind.Speed
+		cd.WindDirection10M = m.Wind.Direction
+		cd.WindGusts10M = math.Max(m.Wind.Gusts, m.Wind.Speed)
+	}
+	if m.Temperature != nil {
+		cd.Temperature2M = *m.Temperature
+		cd.ApparentTemperature = *m.Temperature
+	}
+	if rh, ok := m.RelativeHumidity(); ok {
+		cd.RelativeHumidity2M = int(math.Round(rh))
+		cd.ApparentTemperature = math.Round(plumber.ApparentTemperature(*m.Temperature, rh, cd.WindSpeed10M)*10) / 10
+	}
+	if m.QNH != nil {
+		cd.PressureMSL = *m.QNH
+	}
+	return cd
+}
+
+// Observation maps the report onto a verify.Observation made at the station's location
+//
+// Only the values the report carries are set; the wind direction is left out when it's variable.
+func (m *METAR) Observation(station plumber.Location) verify.Observation {
+	o := verify.Observation{
+		Station:   m.Station,
+		Latitude:  station.Coordinates.Latitude,
+		Longitude: station.Coordinates.Longitude,
+		Time:      m.Time,
+		Values:    make(map[string]float64),
+	}
+	if m.Temperature != nil {
+		o.Values["temperature_2m"] = *m.Temperature
+	}
+	if m.DewPoint != nil {
+		o.Values["dew_point_2m"] = *m.DewPoint
+	}
+	if rh, ok := m.RelativeHumidity(); ok {
+		o.Values["relative_humidity_2m"] = math.Round(rh)
+	}
+	if m.QNH != nil {
+		o.Values["pressure_msl"] = *m.QNH
+	}
+	if m.Wind != nil {
+		o.Values["wind_speed_10m"] = m.Wind.Speed
+		if m.Wind.Gusts > 0 {
+			o.Values["wind_gusts_10m"] = m.Wind.Gusts
+		}
+		if !m.Wind.Variable && m.Wind.Speed > 0 {
+			o.Values["wind_direction_10m"] = float64(m.Wind.Direction)
+		}
+	}
+	if v, ok := m.VisibilityMetres(); ok {
+		o.Values["visibility"] = v
+	}
+	if len(m.Clouds) > 0 || m.SkyClear || m.CAVOK {
+		o.Values["cloud_cover"] = float64(m.CloudCover())
+	}
+	return o
+}
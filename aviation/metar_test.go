@@ -0,0 +1,185 @@
+package aviation
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseMETAR(t *testing.T) {
+	ref := time.Date(2024, 1, 15, 12, 58, 0, 0, time.UTC)
+	type want struct {
+		station     string
+		time        time.Time
+		temperature *float64
+		dewPoint    *float64
+		qnh         *float64
+		wind        *Wind
+		visibility  float64
+		cavok, auto bool
+		cloudCover  int
+		weatherCode int
+		trend       string
+		remarks     string
+	}
+	tests := []struct {
+		name string
+		raw  string
+		want want
+	}{
+		{
+			name: "negative temperatures and QNH",
+			raw:  "METAR LSZH 151250Z 24012G25KT 200V280 9999 FEW030 SCT050 M02/M05 Q1023 NOSIG=",
+			want: want{
+				station: "LSZH", time: time.Date(2024, 1, 15, 12, 50, 0, 0, time.UTC),
+				temperature: ptr(-2), dewPoint: ptr(-5), qnh: ptr(1023),
+				wind:       &Wind{Direction: 240, Speed: 22.2, Gusts: 46.3, VariableFrom: iptr(200), VariableTo: iptr(280)},
+				visibility: 10000, cloudCover: 45, weatherCode: 2, trend: "NOSIG",
+			},
+		},
+		{
+			name: "altimeter in inches of mercury",
+			raw:  "KJFK 151251Z 31015KT 10SM BKN250 22/M01 A2992 RMK AO2 SLP132",
+			want: want{
+				station: "KJFK", time: time.Date(2024, 1, 15, 12, 51, 0, 0, time.UTC),
+				temperature: ptr(22), dewPoint: ptr(-1), qnh: ptr(1013.2),
+				wind:       &Wind{Direction: 310, Speed: 27.8},
+				visibility: 10000, cloudCover: 75, weatherCode: 3, remarks: "AO2 SLP132",
+			},
+		},
+		{
+			name: "CAVOK",
+			raw:  "METAR EGLL 151220Z AUTO 27008KT CAVOK 18/12 Q1015",
+			want: want{
+				station: "EGLL", time: time.Date(2024, 1, 15, 12, 20, 0, 0, time.UTC),
+				temperature: ptr(18), dewPoint: ptr(12), qnh: ptr(1015),
+				wind:       &Wind{Direction: 270, Speed: 14.8},
+				visibility: 10000, cavok: true, auto: true,
+			},
+		},
+		{
+			name: "fog without a dew point",
+			raw:  "SPECI EDDF 151220Z VRB02MPS 0400 R25L/0550N FG VV001 M00/ Q1030 TEMPO 0800",
+			want: want{
+				station: "EDDF", time: time.Date(2024, 1, 15, 12, 20, 0, 0, time.UTC),
+				temperature: ptr(0), qnh: ptr(1030),
+				wind:       &Wind{Variable: true, Speed: 7.2},
+				visibility: 400, cloudCover: 100, weatherCode: 45, trend: "TEMPO 0800",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := ParseMETAR(tt.raw, ref)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w := tt.want
+			if m.Station != w.station || !m.Time.Equal(w.time) {
+				t.Errorf("station %s at %v, want %s at %v", m.Station, m.Time, w.station, w.time)
+			}
+			expectFloat(t, "temperature", m.Temperature, w.temperature)
+			expectFloat(t, "dew point", m.DewPoint, w.dewPoint)
+			expectFloat(t, "QNH", m.QNH, w.qnh)
+			expectWind(t, m.Wind, w.wind)
+			if v, _ := m.VisibilityMetres(); v != w.visibility {
+				t.Errorf("visibility = %v, want %v", v, w.visibility)
+			}
+			if m.CAVOK != w.cavok || m.Auto != w.auto {
+				t.Errorf("CAVOK %v, AUTO %v, want %v, %v", m.CAVOK, m.Auto, w.cavok, w.auto)
+			}
+			if cc, wc := m.CloudCover(), m.WeatherCode(); cc != w.cloudCover || wc != w.weatherCode {
+				t.Errorf("cloud cover %d, weather code %d, want %d, %d", cc, wc, w.cloudCover, w.weatherCode)
+			}
+			if m.Trend != w.trend || m.Remarks != w.remarks {
+				t.Errorf("trend %q, remarks %q, want %q, %q", m.Trend, m.Remarks, w.trend, w.remarks)
+			}
+		})
+	}
+}
+
+func TestParseMETARInvalid(t *testing.T) {
+	ref := time.Date(2024, 1, 15, 12, 58, 0, 0, time.UTC)
+	tests := []struct {
+		raw  string
+		want error
+	}{
+		{"METAR LSZH 151250Z NIL=", ErrNoReport},
+		{"METAR 151250Z 24012KT 9999", ErrInvalidReport},
+		{"METAR LSZH 24012KT 9999", ErrInvalidReport},
+		{"METAR LSZH 321250Z 24012KT 9999", ErrInvalidReport},
+		{"", ErrInvalidReport},
+	}
+	for _, tt := range tests {
+		if _, err := ParseMETAR(tt.raw, ref); !errors.Is(err, tt.want) {
+			t.Errorf("ParseMETAR(%q) = %v, want %v", tt.raw, err, tt.want)
+		}
+	}
+}
+
+func TestResolveTime(t *testing.T) {
+	tests := []struct {
+		name              string
+		day, hour, minute int
+		ref               time.Time
+		want              time.Time
+	}{
+		{"same day", 15, 12, 50, time.Date(2024, 1, 15, 12, 58, 0, 0, time.UTC), time.Date(2024, 1, 15, 12, 50, 0, 0, time.UTC)},
+		{"into the next month", 1, 0, 20, time.Date(2024, 1, 31, 23, 50, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 20, 0, 0, time.UTC)},
+		{"from the previous month", 31, 23, 50, time.Date(2024, 2, 1, 0, 5, 0, 0, time.UTC), time.Date(2024, 1, 31, 23, 50, 0, 0, time.UTC)},
+		{"into the next year", 1, 0, 30, time.Date(2024, 12, 31, 23, 55, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 30, 0, 0, time.UTC)},
+		{"from the previous year", 31, 23, 50, time.Date(2025, 1, 1, 0, 5, 0, 0, time.UTC), time.Date(2024, 12, 31, 23, 50, 0, 0, time.UTC)},
+		{"leap day", 29, 23, 0, time.Date(2024, 3, 1, 1, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 23, 0, 0, 0, time.UTC)},
+		{"month without the day", 30, 6, 0, time.Date(2023, 3, 1, 1, 0, 0, 0, time.UTC), time.Date(2023, 3, 30, 6, 0, 0, 0, time.UTC)},
+		{"hour 24", 15, 24, 0, time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC), time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)},
+		{"reference in another zone", 15, 23, 0, time.Date(2024, 1, 16, 1, 0, 0, 0, time.FixedZone("CET", 3600)), time.Date(2024, 1, 15, 23, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveTime(tt.day, tt.hour, tt.minute, tt.ref)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("resolveTime(%d, %d, %d, %v) = %v, want %v", tt.day, tt.hour, tt.minute, tt.ref, got, tt.want)
+			}
+		})
+	}
+
+	for _, invalid := range [][3]int{{0, 12, 0}, {32, 12, 0}, {15, 25, 0}, {15, 12, 60}} {
+		if _, err := resolveTime(invalid[0], invalid[1], invalid[2], time.Now()); !errors.Is(err, ErrInvalidReport) {
+			t.Errorf("resolveTime(%v) = %v, want ErrInvalidReport", invalid, err)
+		}
+	}
+}
+
+func ptr(v float64) *float64 { return &v }
+
+func iptr(v int) *int { return &v }
+
+func expectFloat(t *testing.T, name string, got, want *float64) {
+	t.Helper()
+	switch {
+	case got == nil && want == nil:
+	case got == nil || want == nil:
+		t.Errorf("%s = %v, want %v", name, got, want)
+	case *got != *want:
+		t.Errorf("%s = %v, want %v", name, *got, *want)
+	}
+}
+
+func expectWind(t *testing.T, got, want *Wind) {
+	t.Helper()
+	if got == nil || want == nil {
+		if got != want {
+			t.Errorf("wind = %+v, want %+v", got, want)
+		}
+		return
+	}
+	if got.Direction != want.Direction || got.Variable != want.Variable || got.Speed != want.Speed || got.Gusts != want.Gusts {
+		t.Errorf("wind = %+v, want %+v", *got, *want)
+	}
+	if (got.VariableFrom == nil) != (want.VariableFrom == nil) || (got.VariableFrom != nil && (*got.VariableFrom != *want.VariableFrom || *got.VariableTo != *want.VariableTo)) {
+		t.Errorf("wind variation = %v-%v, want %v-%v", got.VariableFrom, got.VariableTo, want.VariableFrom, want.VariableTo)
+	}
+}
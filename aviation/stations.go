@@ -0,0 +1,220 @@
+package aviation
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+// airportFeatureCode is the GeoNames feature code of airports
+const airportFeatureCode = "AIRP"
+
+// Stations maps the ICAO codes of aviation weather stations onto their locations
+//
+// Stations is safe for concurrent use.
+type Stations struct {
+	mu       sync.RWMutex
+	byICAO   map[string]plumber.Location
+	ordering []string // ICAO codes in the order added, so that Nearest breaks ties deterministically
+}
+
+// NewStations returns a station list holding the given stations, keyed by ICAO code
+func NewStations(stations map[string]plumber.Location) *Stations {
+	s := &Stations{byICAO: make(map[string]plumber.Location, len(stations))}
+	for _, icao := range slices.Sorted(maps.Keys(stations)) {
+		s.Add(icao, stations[icao])
+	}
+	return s
+}
+
+// DefaultStations returns a station list preloaded with a selection of well known aerodromes
+//
+// Use LoadCSV or LoadFile to extend it, e.g. with OurAirports' airports.csv.
+func DefaultStations() *Stations {
+	return NewStations(knownStations)
+}
+
+// Add adds or replaces a station
+func (s *Stations) Add(icao string, loc plumber.Location) {
+	icao = strings.ToUpper(icao)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.byICAO[icao]; !ok {
+		s.ordering = append(s.ordering, icao)
+	}
+	s.byICAO[icao] = loc
+}
+
+// Lookup returns the location of a station
+func (s *Stations) Lookup(icao string) (plumber.Location, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	loc, ok := s.byICAO[strings.ToUpper(icao)]
+	if !ok {
+		return plumber.Location{}, fmt.Errorf("%w: %s", ErrUnknownStation, icao)
+	}
+	return loc, nil
+}
+
+// Len returns the number of stations in the list
+func (s *Stations) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.byICAO)
+}
+
+// Nearest returns the ICAO code and location of the station closest to coords, and its distance in km
+//
+// Stations further away than maxDistance km are ignored; the error wraps ErrUnknownStation if none is left.
+func (s *Stations) Nearest(coords *plumber.Coordinates, maxDistance float64) (string, plumber.Location, float64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	best, bestDistance := "", math.Inf(1)
+	for _, icao := range s.ordering {
+		loc := s.byICAO[icao]
+		d := distance(coords, &loc.Coordinates)
+		if d < bestDistance {
+			best, bestDistance = icao, d
+		}
+	}
+	if best == "" || bestDistance > maxDistance {
+		return "", plumber.Location{}, 0, fmt.Errorf("%w: none within %g km of %f,%f", ErrUnknownStation, maxDistance, coords.Latitude, coords.Longitude)
+	}
+	return best, s.byICAO[best], bestDistance, nil
+}
+
+// LoadFile adds the stations of a CSV file, see LoadCSV
+func (s *Stations) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return s.LoadCSV(f)
+}
+
+// LoadCSV adds the stations of a CSV in the format of OurAirports' airports.csv
+//
+// Columns are found by their header: ident, or icao_code or gps_code when set, name, latitude_deg,
+// longitude_deg and the optional elevation_ft and iso_country. Rows without a four letter code are skipped.
+func (s *Stations) LoadCSV(r io.Reader) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return fmt.Errorf("couldn't read station header: %w", err)
+	}
+	col := make(map[string]int, len(header))
+	for i, name := range header {
+		col[strings.TrimSpace(name)] = i
+	}
+	for _, required := range []string{"ident", "name", "latitude_deg", "longitude_deg"} {
+		if _, ok := col[required]; !ok {
+			return fmt.Errorf("station CSV has no %s column", required)
+		}
+	}
+	field := func(row []string, name string) string {
+		if i, ok := col[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	for line := 2; ; line++ {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("station CSV line %d: %w", line, err)
+		}
+
+		icao := field(row, "icao_code")
+		if icao == "" {
+			icao = field(row, "gps_code")
+		}
+		if !stationGroup.MatchString(icao) {
+			icao = field(row, "ident")
+		}
+		if !stationGroup.MatchString(icao) {
+			continue
+		}
+
+		lat, err := strconv.ParseFloat(field(row, "latitude_deg"), 64)
+		if err != nil {
+			return fmt.Errorf("station CSV line %d: latitude: %w", line, err)
+		}
+		lon, err := strconv.ParseFloat(field(row, "longitude_deg"), 64)
+		if err != nil {
+			return fmt.Errorf("station CSV line %d: longitude: %w", line, err)
+		}
+		loc := plumber.Location{
+			Name:        field(row, "name"),
+			Coordinates: *plumber.NewCoordinates(lat, lon),
+			FeatureCode: airportFeatureCode,
+			CountryCode: field(row, "iso_country"),
+		}
+		if ft, err := strconv.ParseFloat(field(row, "elevation_ft"), 64); err == nil {
+			loc.Elevation = math.Round(ft * metresPerFoot)
+		}
+		s.Add(icao, loc)
+	}
+}
+
+// distance returns the great circle distance between a and b in km
+func distance(a, b *plumber.Coordinates) float64 {
+	const earthRadius = 6371.0
+	rad := math.Pi / 180
+	dLat := (b.Latitude - a.Latitude) * rad
+	dLon := (b.Longitude - a.Longitude) * rad
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(a.Latitude*rad)*math.Cos(b.Latitude*rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// station builds the location of a built-in station, elevation in ft as published in the AIP
+func station(name string, lat, lon, elevationFt float64, timezone, countryCode, country string) plumber.Location {
+	return plumber.Location{
+		Name:        name,
+		Coordinates: *plumber.NewCoordinates(lat, lon),
+		Elevation:   math.Round(elevationFt * metresPerFoot),
+		Timezone:    timezone,
+		FeatureCode: airportFeatureCode,
+		CountryCode: countryCode,
+		Country:     country,
+	}
+}
+
+// knownStations are the aerodromes DefaultStations starts out with
+var knownStations = map[string]plumber.Location{
+	"VOCB": station("Coimbatore International Airport", 11.0300, 77.0434, 1324, "Asia/Kolkata", "IN", "India"),
+	"VOBL": station("Kempegowda International Airport", 13.1979, 77.7063, 3000, "Asia/Kolkata", "IN", "India"),
+	"VOMM": station("Chennai International Airport", 12.9900, 80.1693, 52, "Asia/Kolkata", "IN", "India"),
+	"VOCI": station("Cochin International Airport", 10.1520, 76.4019, 30, "Asia/Kolkata", "IN", "India"),
+	"VABB": station("Chhatrapati Shivaji Maharaj International Airport", 19.0887, 72.8679, 39, "Asia/Kolkata", "IN", "India"),
+	"VIDP": station("Indira Gandhi International Airport", 28.5665, 77.1031, 777, "Asia/Kolkata", "IN", "India"),
+	"EGLL": station("London Heathrow Airport", 51.4706, -0.4619, 83, "Europe/London", "GB", "United Kingdom"),
+	"LSZH": station("Zurich Airport", 47.4647, 8.5492, 1416, "Europe/Zurich", "CH", "Switzerland"),
+	"LSGS": station("Sion Airport", 46.2196, 7.3268, 1585, "Europe/Zurich", "CH", "Switzerland"),
+	"LOWI": station("Innsbruck Airport", 47.2602, 11.3440, 1907, "Europe/Vienna", "AT", "Austria"),
+	"LFLB": station("Chambéry Savoie Mont Blanc Airport", 45.6381, 5.8802, 779, "Europe/Paris", "FR", "France"),
+	"LFLS": station("Grenoble Alpes-Isère Airport", 45.3629, 5.3294, 1302, "Europe/Paris", "FR", "France"),
+	"EDDM": station("Munich Airport", 48.3538, 11.7861, 1487, "Europe/Berlin", "DE", "Germany"),
+	"LIMC": station("Milan Malpensa Airport", 45.6306, 8.7281, 768, "Europe/Rome", "IT", "Italy"),
+	"LEBL": station("Josep Tarradellas Barcelona-El Prat Airport", 41.2971, 2.0785, 12, "Europe/Madrid", "ES", "Spain"),
+	"KJFK": station("John F. Kennedy International Airport", 40.6398, -73.7789, 13, "America/New_York", "US", "United States"),
+	"KSFO": station("San Francisco International Airport", 37.6190, -122.3750, 13, "America/Los_Angeles", "US", "United States"),
+	"KDEN": station("Denver International Airport", 39.8617, -104.6730, 5434, "America/Denver", "US", "United States"),
+	"YSSY": station("Sydney Kingsford Smith Airport", -33.9461, 151.1770, 21, "Australia/Sydney", "AU", "Australia"),
+	"NZQN": station("Queenstown Airport", -45.0211, 168.7390, 1171, "Pacific/Auckland", "NZ", "New Zealand"),
+}
@@ -0,0 +1,326 @@
+package aviation
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+// ChangeType is the kind of a TAF change group
+type ChangeType string
+
+const (
+	From        ChangeType = "FM"    // Conditions change completely and rapidly at From
+	Becoming    ChangeType = "BECMG" // Conditions change gradually, at some point between From and To
+	Temporary   ChangeType = "TEMPO" // Conditions fluctuate for less than half of the time between From and To
+	Probability ChangeType = "PROB"  // Conditions may occur between From and To
+)
+
+// Change is a change group of a TAF
+type Change struct {
+	Type        ChangeType `json:"type"`
+	Probability int        `json:"probability,omitempty"` // Percent chance of PROB30 and PROB40 groups, also of PROB TEMPO
+	From        time.Time  `json:"from"`
+	To          time.Time  `json:"to"` // The next FM group or the end of the validity for FM groups
+	Conditions
+}
+
+// TAF is a terminal aerodrome forecast
+type TAF struct {
+	Raw        string    `json:"raw"`
+	Station    string    `json:"station"` // ICAO code
+	Issued     time.Time `json:"issued"`
+	ValidFrom  time.Time `json:"valid_from"`
+	ValidTo    time.Time `json:"valid_to"`
+	Amendment  bool      `json:"amendment,omitempty"`  // AMD, replaces an earlier forecast
+	Correction bool      `json:"correction,omitempty"` // COR, corrects an earlier forecast
+	// Conditions forecast for the whole validity, unless changed by the change groups
+	Base    Conditions `json:"base"`
+	Changes []Change   `json:"changes,omitempty"`
+}
+
+var (
+	periodGroup = regexp.MustCompile(`^(\d{2})(\d{2})/(\d{2})(\d{2})$`)
+	fromGroup   = regexp.MustCompile(`^FM(\d{2})(\d{2})(\d{2})$`)
+	probGroup   = regexp.MustCompile(`^PROB(\d{2})$`)
+	extremeTemp = regexp.MustCompile(`^T[XN]M?\d{2}/\d{4}Z$`)
+)
+
+// ParseTAF parses a raw TAF
+//
+// Like ParseMETAR, ref supplies the month and year of the issue and validity times.
+func ParseTAF(raw string, ref time.Time) (*TAF, error) {
+	tokens := tokenize(raw)
+	t := &TAF{Raw: strings.Join(tokens, " ")}
+
+	i := 0
+	if i < len(tokens) && tokens[i] == "TAF" {
+		i++
+	}
+	for ; i < len(tokens) && (tokens[i] == "AMD" || tokens[i] == "COR"); i++ {
+		t.Amendment = t.Amendment || tokens[i] == "AMD"
+		t.Correction = t.Correction || tokens[i] == "COR"
+	}
+	if i >= len(tokens) || !stationGroup.MatchString(tokens[i]) {
+		return nil, fmt.Errorf("%w: no station: %q", ErrInvalidReport, t.Raw)
+	}
+	t.Station = tokens[i]
+	i++
+
+	// The issue time is optional in some national formats, the validity isn't
+	if i < len(tokens) {
+		if tm := timeGroup.FindStringSubmatch(tokens[i]); tm != nil {
+			issued, err := resolveTime(atoi(tm[1]), atoi(tm[2]), atoi(tm[3]), ref)
+			if err != nil {
+				return nil, err
+			}
+			t.Issued = issued
+			i++
+		}
+	}
+	if i < len(tokens) && tokens[i] == "NIL" {
+		return nil, fmt.Errorf("%w: %s", ErrNoReport, t.Station)
+	}
+	if i >= len(tokens) || !periodGroup.MatchString(tokens[i]) {
+		return nil, fmt.Errorf("%w: no validity period: %q", ErrInvalidReport, t.Raw)
+	}
+	if t.Issued.IsZero() {
+		t.Issued = ref.UTC()
+	}
+	from, to, err := parsePeriod(tokens[i], t.Issued)
+	if err != nil {
+		return nil, err
+	}
+	t.ValidFrom, t.ValidTo = from, to
+	i++
+
+	// Conditions go to the base forecast until the first change group, then to the latest change group
+	conditions := &t.Base
+	for i < len(tokens) {
+		tok := tokens[i]
+		switch {
+		case tok == "RMK":
+			i = len(tokens)
+			continue
+		case tok == "CNL":
+			return nil, fmt.Errorf("%w: %s cancelled its forecast", ErrNoReport, t.Station)
+		case extremeTemp.MatchString(tok):
+			// Forecast maximum and minimum temperatures aren't mapped onto the hourly data
+			i++
+			continue
+		}
+
+		change, n, err := t.parseChange(tokens, i)
+		if err != nil {
+			return nil, err
+		}
+		if n > 0 {
+			t.Changes = append(t.Changes, change)
+			conditions = &t.Changes[len(t.Changes)-1].Conditions
+			i += n
+			continue
+		}
+
+		if n := conditions.parseGroup(tokens, i); n > 0 {
+			i += n
+			continue
+		}
+		i++
+	}
+	if _, ok := t.Base.VisibilityMetres(); t.Base.Wind == nil || !ok {
+		return nil, fmt.Errorf("%w: base forecast lacks wind or visibility: %q", ErrInvalidReport, t.Raw)
+	}
+
+	// FM groups last until the next one or the end of the validity
+	for j := range t.Changes {
+		if t.Changes[j].Type != From {
+			continue
+		}
+		t.Changes[j].To = t.ValidTo
+		for k := j + 1; k < len(t.Changes); k++ {
+			if t.Changes[k].Type == From {
+				t.Changes[j].To = t.Changes[k].From
+				break
+			}
+		}
+	}
+	return t, nil
+}
+
+// parseChange parses the header of the change group starting at tokens[i]: FMddhhmm, BECMG, TEMPO or PROBnn,
+// each but FM followed by its period
+//
+// It returns the number of tokens consumed, 0 if tokens[i] doesn't start a change group.
+func (t *TAF) parseChange(tokens []string, i int) (Change, int, error) {
+	tok := tokens[i]
+	if m := fromGroup.FindStringSubmatch(tok); m != nil {
+		from, err := resolveTime(atoi(m[1]), atoi(m[2]), atoi(m[3]), t.ValidFrom)
+		if err != nil {
+			return Change{}, 0, err
+		}
+		return Change{Type: From, From: from}, 1, nil
+	}
+
+	var c Change
+	n := 0
+	if m := probGroup.FindStringSubmatch(tok); m != nil {
+		c.Type, c.Probability = Probability, atoi(m[1])
+		n++
+		if i+n < len(tokens) && tokens[i+n] == "TEMPO" {
+			c.Type = Temporary
+			n++
+		}
+	} else if tok == "BECMG" || tok == "TEMPO" {
+		c.Type = ChangeType(tok)
+		n++
+	} else {
+		return Change{}, 0, nil
+	}
+
+	if i+n >= len(tokens) || !periodGroup.MatchString(tokens[i+n]) {
+		return Change{}, 0, fmt.Errorf("%w: %s group without period: %q", ErrInvalidReport, c.Type, t.Raw)
+	}
+	from, to, err := parsePeriod(tokens[i+n], t.ValidFrom)
+	if err != nil {
+		return Change{}, 0, err
+	}
+	c.From, c.To = from, to
+	return c, n + 1, nil
+}
+
+// parsePeriod parses a ddhh/ddhh period resolved against ref
+func parsePeriod(s string, ref time.Time) (time.Time, time.Time, error) {
+	m := periodGroup.FindStringSubmatch(s)
+	from, err := resolveTime(atoi(m[1]), atoi(m[2]), 0, ref)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	to, err := resolveTime(atoi(m[3]), atoi(m[4]), 0, from)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if !to.After(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: period %s ends before it starts", ErrInvalidReport, s)
+	}
+	return from, to, nil
+}
+
+// Period is what a TAF forecasts for a point in time
+type Period struct {
+	Time       time.Time  `json:"time"`
+	Prevailing Conditions `json:"prevailing"`
+	// Conditions that may occur instead: TEMPO and PROB groups and BECMG groups whose change is still underway
+	Possible []Change `json:"possible,omitempty"`
+}
+
+// At resolves the change groups into the conditions forecast for time at
+func (t *TAF) At(at time.Time) Period {
+	p := Period{Time: at, Prevailing: t.Base}
+	for _, c := range t.Changes {
+		switch c.Type {
+		case From:
+			// FM groups describe the conditions completely, but carry over wind and visibility should they lack them
+			if !at.Before(c.From) {
+				previous := p.Prevailing
+				p.Prevailing = c.Conditions
+				if p.Prevailing.Wind == nil {
+					p.Prevailing.Wind = previous.Wind
+				}
+				if _, ok := p.Prevailing.VisibilityMetres(); !ok {
+					p.Prevailing.Visibility, p.Prevailing.CAVOK = previous.Visibility, previous.CAVOK
+				}
+			}
+		case Becoming:
+			switch {
+			case !at.Before(c.To):
+				p.Prevailing = p.Prevailing.merge(c.Conditions)
+			case !at.Before(c.From):
+				p.Possible = append(p.Possible, c)
+			}
+		default:
+			if !at.Before(c.From) && at.Before(c.To) {
+				p.Possible = append(p.Possible, c)
+			}
+		}
+	}
+	return p
+}
+
+// Timeline resolves the forecast at every step, e.g. time.Hour, over its validity
+func (t *TAF) Timeline(step time.Duration) []Period {
+	if step <= 0 {
+		return nil
+	}
+	var periods []Period
+	for at := t.ValidFrom; at.Before(t.ValidTo); at = at.Add(step) {
+		periods = append(periods, t.At(at))
+	}
+	return periods
+}
+
+// Hourly maps the prevailing conditions of every hour of the validity onto plumber.HourlyData
+//
+// TAFs only forecast wind, visibility, weather and clouds, so the other variables are left empty.
+func (t *TAF) Hourly() plumber.HourlyData {
+	periods := t.Timeline(time.Hour)
+	h := plumber.HourlyData{
+		Time:             make([]int64, len(periods)),
+		WeatherCode:      make([]int, len(periods)),
+		CloudCover:       make([]int, len(periods)),
+		Visibility:       make([]float64, len(periods)),
+		WindSpeed10M:     make([]float64, len(periods)),
+		WindDirection10M: make([]int, len(periods)),
+		WindGusts10M:     make([]float64, len(periods)),
+	}
+	for i, p := range periods {
+		c := &p.Prevailing
+		h.Time[i] = p.Time.Unix()
+		h.WeatherCode[i] = c.WeatherCode()
+		h.CloudCover[i] = c.CloudCover()
+		h.Visibility[i], _ = c.VisibilityMetres()
+		if c.Wind != nil {
+			h.WindSpeed10M[i] = c.Wind.Speed
+			h.WindDirection10M[i] = c.Wind.Direction
+			h.WindGusts10M[i] = math.Max(c.Wind.Gusts, c.Wind.Speed)
+		}
+	}
+	return h
+}
+
+// SplitReports splits a bulletin of raw reports, one per line or separated by =, into single reports
+//
+// Lines indented with whitespace continue the report of the previous line, as TAFs are often wrapped that way.
+func SplitReports(text string) []string {
+	var reports []string
+	var current []string
+	flush := func() {
+		if r := strings.TrimSpace(strings.Join(current, " ")); r != "" {
+			reports = append(reports, r)
+		}
+		current = nil
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		if line[0] != ' ' && line[0] != '\t' {
+			flush()
+		}
+		for {
+			before, after, found := strings.Cut(line, "=")
+			current = append(current, strings.TrimSpace(before))
+			if !found {
+				break
+			}
+			flush()
+			line = after
+		}
+	}
+	flush()
+	return reports
+}
@@ -0,0 +1,120 @@
+package aviation
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseTAF(t *testing.T) {
+	raw := `TAF LSZH 151100Z 1512/1618 24010KT 9999 FEW030
+		BECMG 1514/1516 27015G25KT
+		TEMPO 1516/1520 4000 -SHRA BKN015
+		PROB30 1520/1523 TSRA
+		FM160300 VRB03KT CAVOK
+		PROB40 TEMPO 1606/1609 0800 FG
+		FM161200 22008KT 8000 BKN040
+		TX08/1514Z TNM02/1606Z=`
+	taf, err := ParseTAF(raw, time.Date(2024, 1, 15, 10, 58, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	at := func(day, hour int) time.Time { return time.Date(2024, 1, day, hour, 0, 0, 0, time.UTC) }
+	if !taf.Issued.Equal(time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC)) || !taf.ValidFrom.Equal(at(15, 12)) || !taf.ValidTo.Equal(at(16, 18)) {
+		t.Errorf("issued %v, valid %v to %v", taf.Issued, taf.ValidFrom, taf.ValidTo)
+	}
+
+	changes := []struct {
+		typ         ChangeType
+		probability int
+		from, to    time.Time
+	}{
+		{Becoming, 0, at(15, 14), at(15, 16)},
+		{Temporary, 0, at(15, 16), at(15, 20)},
+		{Probability, 30, at(15, 20), at(15, 23)},
+		{From, 0, at(16, 3), at(16, 12)},
+		{Temporary, 40, at(16, 6), at(16, 9)},
+		{From, 0, at(16, 12), at(16, 18)},
+	}
+	if len(taf.Changes) != len(changes) {
+		t.Fatalf("got %d change groups, want %d: %+v", len(taf.Changes), len(changes), taf.Changes)
+	}
+	for i, want := range changes {
+		c := taf.Changes[i]
+		if c.Type != want.typ || c.Probability != want.probability || !c.From.Equal(want.from) || !c.To.Equal(want.to) {
+			t.Errorf("change %d = %s %d %v to %v, want %s %d %v to %v", i, c.Type, c.Probability, c.From, c.To, want.typ, want.probability, want.from, want.to)
+		}
+	}
+
+	periods := []struct {
+		at         time.Time
+		wind       *Wind
+		visibility float64
+		cavok      bool
+		possible   []ChangeType
+	}{
+		{at(15, 12), &Wind{Direction: 240, Speed: 18.5}, 10000, false, nil},
+		// BECMG is underway, then done
+		{at(15, 15), &Wind{Direction: 240, Speed: 18.5}, 10000, false, []ChangeType{Becoming}},
+		{at(15, 17), &Wind{Direction: 270, Speed: 27.8, Gusts: 46.3}, 10000, false, []ChangeType{Temporary}},
+		{at(15, 21), &Wind{Direction: 270, Speed: 27.8, Gusts: 46.3}, 10000, false, []ChangeType{Probability}},
+		{at(16, 7), &Wind{Variable: true, Speed: 5.6}, 10000, true, []ChangeType{Temporary}},
+		{at(16, 13), &Wind{Direction: 220, Speed: 14.8}, 8000, false, nil},
+	}
+	for _, want := range periods {
+		p := taf.At(want.at)
+		expectWind(t, p.Prevailing.Wind, want.wind)
+		if v, _ := p.Prevailing.VisibilityMetres(); v != want.visibility || p.Prevailing.CAVOK != want.cavok {
+			t.Errorf("%v: visibility %v, CAVOK %v, want %v, %v", want.at, v, p.Prevailing.CAVOK, want.visibility, want.cavok)
+		}
+		var possible []ChangeType
+		for _, c := range p.Possible {
+			possible = append(possible, c.Type)
+		}
+		if len(possible) != len(want.possible) || (len(possible) > 0 && possible[0] != want.possible[0]) {
+			t.Errorf("%v: possible %v, want %v", want.at, possible, want.possible)
+		}
+	}
+
+	h := taf.Hourly()
+	if len(h.Time) != 30 {
+		t.Errorf("got %d hours, want 30", len(h.Time))
+	}
+}
+
+func TestParseTAFMonthRollover(t *testing.T) {
+	taf, err := ParseTAF("TAF AMD EGLL 301700Z 3018/0124 27010KT 9999 SCT020 FM010600 30015KT 6000 -RA BKN012", time.Date(2024, 4, 30, 16, 55, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !taf.Amendment {
+		t.Error("AMD not recognised")
+	}
+	if from, to := time.Date(2024, 4, 30, 18, 0, 0, 0, time.UTC), time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC); !taf.ValidFrom.Equal(from) || !taf.ValidTo.Equal(to) {
+		t.Errorf("valid %v to %v, want %v to %v", taf.ValidFrom, taf.ValidTo, from, to)
+	}
+	if fm := time.Date(2024, 5, 1, 6, 0, 0, 0, time.UTC); len(taf.Changes) != 1 || !taf.Changes[0].From.Equal(fm) {
+		t.Errorf("changes %+v, want FM at %v", taf.Changes, fm)
+	}
+}
+
+func TestParseTAFInvalid(t *testing.T) {
+	ref := time.Date(2024, 1, 15, 10, 58, 0, 0, time.UTC)
+	tests := []struct {
+		raw  string
+		want error
+	}{
+		{"TAF LSZH 151100Z NIL=", ErrNoReport},
+		{"TAF LSZH 151100Z 1512/1618 CNL", ErrNoReport},
+		{"TAF LSZH 151100Z 24010KT 9999", ErrInvalidReport},
+		{"TAF LSZH 151100Z 1512/1618 9999 FEW030", ErrInvalidReport},
+		{"TAF LSZH 151100Z 1512/1618 24010KT 9999 TEMPO 4000", ErrInvalidReport},
+		{"TAF LSZH 151100Z 1518/1512 24010KT 9999", ErrInvalidReport},
+	}
+	for _, tt := range tests {
+		if _, err := ParseTAF(tt.raw, ref); !errors.Is(err, tt.want) {
+			t.Errorf("ParseTAF(%q) = %v, want %v", tt.raw, err, tt.want)
+		}
+	}
+}
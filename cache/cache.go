@@ -0,0 +1,127 @@
+// Package cache keeps fetched forecasts around so that repeated requests don't hit the upstream providers
+//
+// Weather models compute their forecasts on a grid, typically of 0.25 degree resolution, and only refresh
+// them once per model run. Forecasts are therefore cached per provider, model and grid cell, and expire when
+// the next model run becomes available. Nearby points that fall into the same cell share one cache entry.
+//
+// The Provider decorator puts a Store in front of any providers.Provider. A MemoryStore keeps
+// forecasts in process; a MongoStore shares them between processes and survives restarts:
+//
+//	p, _ := providers.New("open-meteo", cfg)
+//	cached := cache.NewProvider("open-meteo", p, cache.NewMemoryStore(256), cache.OptionsFor("open-meteo", cfg))
+//	data, err := cached.FetchData(ctx, plumber.NewCoordinates(11.0056, 76.9661), providers.Request{})
+//
+// Decorators stack, e.g. to keep the hottest forecasts in memory in front of mongo:
+//
+//	store, err := cache.NewMongoStore(ctx, cfg.Mongo)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer store.Close(context.Background())
+//
+//	shared := cache.NewProvider("open-meteo", p, store, cache.Options{})
+//	cached = cache.NewProvider("open-meteo", shared, cache.NewMemoryStore(256), cache.Options{})
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"time"
+
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+var (
+	// ErrMiss is returned by Store.Get when there's no unexpired entry for the key
+	ErrMiss = errors.New("cache miss")
+	// ErrFenced is returned by Store.Put when the stored entry was written under a newer fencing token
+	ErrFenced = errors.New("cache entry written under a newer fencing token")
+)
+
+// DefaultResolution is the grid resolution, in degrees, that coordinates are snapped to when none is given
+const DefaultResolution = 0.25
+
+// exactResolution is the precision, in degrees, of the keys of coordinates cached as they're asked for: about 11 m
+const exactResolution = 1e-4
+
+// DefaultModel names the model in cache keys when the provider is queried without picking one
+const DefaultModel = "default"
+
+// Cell is a grid cell of a weather model, identified by its centre
+type Cell struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Snap returns the cell of a grid with the given resolution, in degrees, that contains coords
+func Snap(coords *plumber.Coordinates, resolution float64) Cell {
+	if resolution <= 0 {
+		resolution = DefaultResolution
+	}
+	snap := func(v float64) float64 {
+		// Rounding to a fixed precision keeps floating point noise out of the cache keys
+		v = math.Round(math.Round(v/resolution)*resolution*1e4) / 1e4
+		if v == 0 {
+			return 0 // Not -0
+		}
+		return v
+	}
+	c := Cell{Latitude: snap(coords.Latitude), Longitude: snap(coords.Longitude)}
+	if c.Longitude == 180 {
+		c.Longitude = -180 // The antimeridian is a single cell
+	}
+	return c
+}
+
+// Coordinates returns the centre of the cell
+func (c Cell) Coordinates() *plumber.Coordinates {
+	return plumber.NewCoordinates(c.Latitude, c.Longitude)
+}
+
+// Key identifies a cached forecast
+type Key struct {
+	Provider string
+	Model    string
+	Cell     Cell
+	Options  string // providers.Options other than the model the forecast was fetched with, empty for the configured ones
+	Fields   string // plumber.Fields the forecast was fetched with, empty for a complete forecast
+}
+
+// String returns the key in the form provider/model/latitude,longitude, followed by /options if there are any
+// and /fields-<hash> for a partial forecast
+func (k Key) String() string {
+	s := fmt.Sprintf("%s/%s/%.4f,%.4f", k.Provider, k.Model, k.Cell.Latitude, k.Cell.Longitude)
+	if k.Options != "" {
+		s += "/" + k.Options
+	}
+	if k.Fields != "" {
+		// Selections can name a hundred fields, too long for a key that's also a document ID
+		h := fnv.New64a()
+		h.Write([]byte(k.Fields))
+		s += fmt.Sprintf("/fields-%016x", h.Sum64())
+	}
+	return s
+}
+
+// Entry is a forecast stored in the cache
+type Entry struct {
+	Key       Key
+	Data      *plumber.BaseData
+	FetchedAt time.Time
+	ExpiresAt time.Time
+	Token     uint64 // Fencing token of the fetch lock held while fetching, 0 without a lock
+}
+
+// Store persists cache entries
+//
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Get returns the entry for key, or an error wrapping ErrMiss if there's none or it has expired
+	Get(ctx context.Context, key Key) (*Entry, error)
+	// Put stores the entry, replacing any previous entry for the same key unless that one is unexpired
+	// and carries a greater fencing token, in which case the error wraps ErrFenced
+	Put(ctx context.Context, entry *Entry) error
+}
@@ -0,0 +1,42 @@
+package cache
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+func TestKeyString(t *testing.T) {
+	cell := Snap(plumber.NewCoordinates(46.68, 7.86), DefaultResolution)
+	key := Key{Provider: "open-meteo", Model: "icon_seamless", Cell: cell}
+
+	base := key.String()
+	if want := "open-meteo/icon_seamless/46.7500,7.7500"; base != want {
+		t.Errorf("String() = %q, want %q", base, want)
+	}
+
+	key.Options = "forecast_days=3"
+	if got, want := key.String(), base+"/forecast_days=3"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	fields := func(s string) string {
+		f, err := plumber.ParseFields(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key.Fields = f.String()
+		return key.String()
+	}
+	selected := fields("hourly.temperature_2m,hourly.cape")
+	if !regexp.MustCompile(`^` + regexp.QuoteMeta(base) + `/forecast_days=3/fields-[0-9a-f]{16}$`).MatchString(selected) {
+		t.Errorf("String() = %q, want the options followed by a fields hash", selected)
+	}
+	if again := fields("hourly.cape, hourly.temperature_2m,hourly.cape"); again != selected {
+		t.Errorf("the same selection keys %q and %q", selected, again)
+	}
+	if other := fields("hourly.cape"); other == selected {
+		t.Errorf("different selections share the key %q", other)
+	}
+}
@@ -0,0 +1,42 @@
+package cache
+
+import "time"
+
+// Cycle describes how often a weather model is run and how long its output takes to be published
+type Cycle struct {
+	Interval time.Duration // Time between two model runs
+	Delay    time.Duration // Time from the start of a run until its forecast is available
+}
+
+// DefaultCycle is used for models missing from UpdateCycles; most providers refresh their blended forecasts hourly
+var DefaultCycle = Cycle{Interval: time.Hour}
+
+// UpdateCycles lists the update cycles of well known models, keyed by the model names open-meteo uses
+var UpdateCycles = map[string]Cycle{
+	"ecmwf_ifs025":         {Interval: 6 * time.Hour, Delay: 7 * time.Hour},
+	"gfs_seamless":         {Interval: 6 * time.Hour, Delay: 4 * time.Hour},
+	"icon_seamless":        {Interval: 3 * time.Hour, Delay: 2 * time.Hour},
+	"gem_seamless":         {Interval: 12 * time.Hour, Delay: 5 * time.Hour},
+	"meteofrance_seamless": {Interval: 3 * time.Hour, Delay: 3 * time.Hour},
+	"jma_seamless":         {Interval: 6 * time.Hour, Delay: 4 * time.Hour},
+}
+
+// CycleFor returns the update cycle of the model, falling back to DefaultCycle
+func CycleFor(model string) Cycle {
+	if c, ok := UpdateCycles[model]; ok {
+		return c
+	}
+	return DefaultCycle
+}
+
+// ExpiresAt returns when a forecast fetched at the given time is superseded by the next model run
+//
+// Runs are assumed to start at multiples of Interval since midnight UTC.
+func (c Cycle) ExpiresAt(fetched time.Time) time.Time {
+	if c.Interval <= 0 {
+		return fetched
+	}
+	// The latest run published by the time of the fetch, and the one after it
+	latest := fetched.Add(-c.Delay).Truncate(c.Interval)
+	return latest.Add(c.Interval + c.Delay)
+}
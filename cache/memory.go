@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultMemorySize is the number of forecasts a MemoryStore holds when no size is given
+const DefaultMemorySize = 1024
+
+// MemoryStore is a Store that keeps the most recently used forecasts in process memory
+//
+// Once full, the least recently used entry is evicted to make room. Expired entries are dropped when they're read.
+type MemoryStore struct {
+	mu      sync.Mutex
+	size    int
+	entries map[Key]*list.Element // Values are *Entry
+	lru     *list.List            // Most recently used at the front
+}
+
+// NewMemoryStore returns a MemoryStore holding at most size forecasts, DefaultMemorySize if size isn't positive
+func NewMemoryStore(size int) *MemoryStore {
+	if size <= 0 {
+		size = DefaultMemorySize
+	}
+	return &MemoryStore{
+		size:    size,
+		entries: make(map[Key]*list.Element, size),
+		lru:     list.New(),
+	}
+}
+
+// Get returns the unexpired entry for key
+func (s *MemoryStore) Get(_ context.Context, key Key) (*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	el, ok := s.entries[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMiss, key)
+	}
+	entry := el.Value.(*Entry)
+	if !time.Now().Before(entry.ExpiresAt) {
+		s.remove(el)
+		return nil, fmt.Errorf("%w: %s", ErrMiss, key)
+	}
+	s.lru.MoveToFront(el)
+	return entry, nil
+}
+
+// Put stores the entry, evicting the least recently used one if the store is full
+func (s *MemoryStore) Put(_ context.Context, entry *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.entries[entry.Key]; ok {
+		old := el.Value.(*Entry)
+		if old.Token > entry.Token && time.Now().Before(old.ExpiresAt) {
+			return fmt.Errorf("%w: %s", ErrFenced, entry.Key)
+		}
+		el.Value = entry
+		s.lru.MoveToFront(el)
+		return nil
+	}
+
+	s.entries[entry.Key] = s.lru.PushFront(entry)
+	for s.lru.Len() > s.size {
+		s.remove(s.lru.Back())
+	}
+	return nil
+}
+
+// Len returns the number of entries in the store, including expired ones that haven't been dropped yet
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lru.Len()
+}
+
+func (s *MemoryStore) remove(el *list.Element) {
+	s.lru.Remove(el)
+	delete(s.entries, el.Value.(*Entry).Key)
+}
@@ -0,0 +1,129 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/tinkershack/meteomunch/config"
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+// mongoCollection holds the cached forecasts, one document per key
+const mongoCollection = "forecast_cache"
+
+// MongoStore is a Store backed by a MongoDB collection
+//
+// Expired documents are removed by a TTL index on expires_at. MongoDB only sweeps them once a minute,
+// so Get also filters on the expiry itself.
+type MongoStore struct {
+	client     *mongo.Client
+	collection *mongo.Collection
+}
+
+// mongoEntry is the document an Entry is stored as
+type mongoEntry struct {
+	Key       string           `bson:"_id"`
+	Provider  string           `bson:"provider"`
+	Model     string           `bson:"model"`
+	Latitude  float64          `bson:"latitude"`
+	Longitude float64          `bson:"longitude"`
+	FetchedAt time.Time        `bson:"fetched_at"`
+	ExpiresAt time.Time        `bson:"expires_at"`
+	Token     int64            `bson:"token"`
+	Data      plumber.BaseData `bson:"data"`
+}
+
+// NewMongoStore connects to the database described by ds, usually config.Config.Mongo, and prepares the cache collection
+func NewMongoStore(ctx context.Context, ds config.DataStore) (*MongoStore, error) {
+	if ds.URI == "" || ds.DBName == "" {
+		return nil, errors.New("mongo URI and DBName are required")
+	}
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(ds.URI))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't connect to mongo: %w", err)
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		_ = client.Disconnect(context.Background())
+		return nil, fmt.Errorf("couldn't reach mongo: %w", err)
+	}
+
+	collection := client.Database(ds.DBName).Collection(mongoCollection)
+	_, err = collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	if err != nil {
+		_ = client.Disconnect(context.Background())
+		return nil, fmt.Errorf("couldn't create TTL index: %w", err)
+	}
+
+	return &MongoStore{client: client, collection: collection}, nil
+}
+
+// Get returns the unexpired entry for key
+func (s *MongoStore) Get(ctx context.Context, key Key) (*Entry, error) {
+	var doc mongoEntry
+	filter := bson.D{
+		{Key: "_id", Value: key.String()},
+		{Key: "expires_at", Value: bson.D{{Key: "$gt", Value: time.Now()}}},
+	}
+	err := s.collection.FindOne(ctx, filter).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("%w: %s", ErrMiss, key)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Entry{
+		Key:       key,
+		Data:      &doc.Data,
+		FetchedAt: doc.FetchedAt,
+		ExpiresAt: doc.ExpiresAt,
+		Token:     uint64(doc.Token),
+	}, nil
+}
+
+// Put upserts the entry, unless an unexpired one with a greater fencing token is stored
+func (s *MongoStore) Put(ctx context.Context, entry *Entry) error {
+	if entry.Data == nil {
+		return errors.New("cache entry has no data")
+	}
+	doc := mongoEntry{
+		Key:       entry.Key.String(),
+		Provider:  entry.Key.Provider,
+		Model:     entry.Key.Model,
+		Latitude:  entry.Key.Cell.Latitude,
+		Longitude: entry.Key.Cell.Longitude,
+		FetchedAt: entry.FetchedAt,
+		ExpiresAt: entry.ExpiresAt,
+		Token:     int64(entry.Token),
+		Data:      *entry.Data,
+	}
+
+	// A fenced off write doesn't match the filter, so the upsert tries to insert a second document with the same _id
+	filter := bson.D{
+		{Key: "_id", Value: doc.Key},
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "token", Value: bson.D{{Key: "$lte", Value: doc.Token}}}},
+			bson.D{{Key: "expires_at", Value: bson.D{{Key: "$lte", Value: time.Now()}}}},
+		}},
+	}
+	_, err := s.collection.ReplaceOne(ctx, filter, doc, options.Replace().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("%w: %s", ErrFenced, entry.Key)
+	}
+	return err
+}
+
+// Close disconnects from mongo
+func (s *MongoStore) Close(ctx context.Context) error {
+	return s.client.Disconnect(ctx)
+}
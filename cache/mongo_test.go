@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/tinkershack/meteomunch/config"
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+// testFencing checks that s rejects a write under a stale fencing token with ErrFenced
+func testFencing(t *testing.T, s Store) {
+	ctx := context.Background()
+	key := Key{Provider: "open-meteo", Model: "best_match", Cell: Snap(plumber.NewCoordinates(46.68, 7.86), 0.1)}
+	now := time.Now().Truncate(time.Millisecond)
+	entry := func(token uint64, lat float64, expiresAt time.Time) *Entry {
+		return &Entry{Key: key, Data: &plumber.BaseData{Latitude: lat}, FetchedAt: now, ExpiresAt: expiresAt, Token: token}
+	}
+
+	if err := s.Put(ctx, entry(5, 1, now.Add(time.Hour))); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Put(ctx, entry(4, 2, now.Add(time.Hour))); !errors.Is(err, ErrFenced) {
+		t.Errorf("Put under a stale token = %v, want ErrFenced", err)
+	}
+	got, err := s.Get(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Token != 5 || got.Data.Latitude != 1 {
+		t.Errorf("stored token %d, latitude %v, want the entry under token 5", got.Token, got.Data.Latitude)
+	}
+
+	// The same or a newer token replaces it
+	for _, token := range []uint64{5, 6} {
+		if err := s.Put(ctx, entry(token, float64(token), now.Add(time.Hour))); err != nil {
+			t.Errorf("Put under token %d: %v", token, err)
+		}
+	}
+	if got, err := s.Get(ctx, key); err != nil || got.Token != 6 {
+		t.Errorf("Get = %+v, %v, want the entry under token 6", got, err)
+	}
+}
+
+// testMongoURI is the server the MongoStore tests run against, MUNCH_TEST_MONGO_URI if set
+func testMongoURI() string {
+	if uri := os.Getenv("MUNCH_TEST_MONGO_URI"); uri != "" {
+		return uri
+	}
+	return "mongodb://localhost:27017"
+}
+
+func TestMongoStoreFencing(t *testing.T) {
+	uri := testMongoURI()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	admin, err := mongo.Connect(ctx, options.Client().ApplyURI(uri).SetServerSelectionTimeout(2*time.Second))
+	if err == nil {
+		err = admin.Ping(ctx, nil)
+	}
+	if err != nil {
+		t.Skipf("mongo isn't reachable at %s: %v", uri, err)
+	}
+	defer admin.Disconnect(context.Background())
+
+	db := fmt.Sprintf("munch_test_cache_%d", os.Getpid())
+	defer admin.Database(db).Drop(context.Background())
+	s, err := NewMongoStore(context.Background(), config.DataStore{URI: uri, DBName: db})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close(context.Background())
+	testFencing(t, s)
+}
+
+func TestMemoryStoreFencing(t *testing.T) {
+	testFencing(t, NewMemoryStore(8))
+}
@@ -0,0 +1,280 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/tinkershack/meteomunch/config"
+	"github.com/tinkershack/meteomunch/dlm"
+	e "github.com/tinkershack/meteomunch/errors"
+	"github.com/tinkershack/meteomunch/logger"
+	"github.com/tinkershack/meteomunch/plumber"
+	"github.com/tinkershack/meteomunch/providers"
+)
+
+// Options tune how a Provider caches forecasts; the zero value is usable
+type Options struct {
+	Model      string  // Model the provider is queried for, DefaultModel if empty
+	Resolution float64 // Grid resolution in degrees, DefaultResolution if zero
+	// Exact caches the coordinates as they're asked for, to 4 decimals, rather than the centre of their
+	// grid cell, for providers that aren't providers.Gridded
+	Exact bool
+	Cycle Cycle // Update cycle of the model, CycleFor(Model) if zero
+	// TTL, if set, is a fixed lifetime of cached forecasts that replaces expiry at the next model run,
+	// e.g. for providers that don't document their update cycle
+	TTL time.Duration
+
+	// Locks, if set, makes munch replicas sharing the store take turns on a miss: one fetches from upstream
+	// while the others wait for it and then read its result from the store
+	Locks   *dlm.Manager
+	LockTTL time.Duration // Lease of the fetch lock, DefaultLockTTL if zero
+}
+
+// settledTTL is the lifetime of forecasts that never change, see providers.Settled; they're only ever evicted
+const settledTTL = 100 * 365 * 24 * time.Hour
+
+// DefaultLockTTL is the lease of the fetch lock; it's renewed for as long as the fetch runs
+const DefaultLockTTL = 10 * time.Second
+
+// Provider is a providers.Provider that serves forecasts from a Store and only calls upstream on a miss
+//
+// Forecasts are fetched for the centre of the grid cell containing the requested coordinates,
+// so all points in a cell get the same forecast, unless Options.Exact says otherwise. Concurrent fetches of the same cell share a single
+// lookup and upstream call, which is only cancelled once every caller waiting on it has gone away.
+// Every caller gets its own copy of the forecast to modify as it pleases.
+//
+// Store failures are logged and fall through to upstream: a broken cache slows munch down but doesn't take it down.
+type Provider struct {
+	name   string
+	next   providers.Provider
+	store  Store
+	opts   Options
+	logger *slog.Logger
+
+	mu       sync.Mutex
+	inflight map[Key]*call
+}
+
+// call is a fetch shared by every caller asking for the same key while it runs
+type call struct {
+	done    chan struct{}
+	data    *plumber.BaseData
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// NewProvider returns next cached in store under the provider name
+func NewProvider(name string, next providers.Provider, store Store, opts Options) *Provider {
+	if opts.Model == "" {
+		opts.Model = DefaultModel
+	}
+	if opts.Resolution <= 0 {
+		opts.Resolution = DefaultResolution
+	}
+	if opts.Cycle == (Cycle{}) {
+		opts.Cycle = CycleFor(opts.Model)
+	}
+	if opts.LockTTL <= 0 {
+		opts.LockTTL = DefaultLockTTL
+	}
+	return &Provider{
+		name:     name,
+		next:     next,
+		store:    store,
+		opts:     opts,
+		logger:   logger.NewTag("cache"),
+		inflight: make(map[Key]*call),
+	}
+}
+
+// OptionsFor returns the cache options configured for the named provider in cfg.MeteoProviders
+//
+// Forecasts are keyed by the model the provider is configured for, see providers.ConfiguredModel, and expire
+// with that model's update cycle unless CacheTTL is set. Providers that aren't providers.Gridded are cached Exact.
+func OptionsFor(name string, cfg *config.Config) Options {
+	o := Options{Model: providers.ConfiguredModel(name, cfg), Exact: !providers.Gridded(name)}
+	for _, mp := range cfg.MeteoProviders {
+		if mp.Name == name {
+			o.TTL = mp.CacheTTL
+			break
+		}
+	}
+	return o
+}
+
+// Key returns the cache key of the coordinates
+func (p *Provider) Key(coords *plumber.Coordinates) Key {
+	resolution := p.opts.Resolution
+	if p.opts.Exact {
+		resolution = exactResolution
+	}
+	return Key{Provider: p.name, Model: p.opts.Model, Cell: Snap(coords, resolution)}
+}
+
+// FetchData returns the cached forecast for the grid cell of coords, fetching and storing it on a miss
+//
+// Forecasts fetched for a selection of Fields or with Options in the Request are cached apart from those
+// fetched as configured; a model chosen in the Options replaces the one in the key. Data the Options make
+// providers.Settled, such as a range of past days, is cached for good, a calendar year per entry.
+func (p *Provider) FetchData(ctx context.Context, coords *plumber.Coordinates, r providers.Request) (*plumber.BaseData, error) {
+	if years := providers.SettledYears(p.name, r.Options); years != nil {
+		return p.fetchYears(ctx, coords, r, years)
+	}
+
+	key := p.Key(coords)
+	key.Fields = r.Fields.String()
+	o := r.Options
+	if o.Model != "" {
+		key.Model, o.Model = o.Model, ""
+	}
+	key.Options = o.String()
+
+	p.mu.Lock()
+	c, ok := p.inflight[key]
+	if !ok {
+		// The shared fetch outlives the caller that started it, but keeps its deadline
+		fetchCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		if deadline, ok := ctx.Deadline(); ok {
+			fetchCtx, cancel = context.WithDeadline(context.WithoutCancel(ctx), deadline)
+		}
+		c = &call{done: make(chan struct{}), cancel: cancel}
+		p.inflight[key] = c
+		go p.run(fetchCtx, key, r, c)
+	}
+	c.waiters++
+	p.mu.Unlock()
+
+	select {
+	case <-c.done:
+	case <-ctx.Done():
+		p.mu.Lock()
+		if c.waiters--; c.waiters == 0 {
+			// Callers arriving from now on must start afresh rather than join the cancelled call
+			c.cancel()
+			if p.inflight[key] == c {
+				delete(p.inflight, key)
+			}
+		}
+		p.mu.Unlock()
+		return nil, ctx.Err()
+	}
+
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.data.Clone(), nil
+}
+
+// fetchYears fetches a settled range a year at a time, each cached apart, and joins the years into a single timeline
+//
+// Decades of hourly data would outgrow the size limit of a MongoDB document in a single entry.
+func (p *Provider) fetchYears(ctx context.Context, coords *plumber.Coordinates, r providers.Request, years []providers.Options) (*plumber.BaseData, error) {
+	var data *plumber.BaseData
+	for _, o := range years {
+		r.Options = o
+		year, err := p.FetchData(ctx, coords, r)
+		if err != nil {
+			return nil, fmt.Errorf("%s to %s: %w", o.StartDate, o.EndDate, err)
+		}
+		if data == nil {
+			data = year
+			continue
+		}
+		data.Hourly.Append(&year.Hourly)
+		data.Daily.Append(&year.Daily)
+	}
+	return data, nil
+}
+
+// run performs the fetch shared by the callers of key and removes it from the in-flight calls once done,
+// unless it was abandoned and another call has taken its place
+func (p *Provider) run(ctx context.Context, key Key, r providers.Request, c *call) {
+	c.data, c.err = p.fetch(ctx, key, r)
+	c.cancel()
+
+	p.mu.Lock()
+	if p.inflight[key] == c {
+		delete(p.inflight, key)
+	}
+	p.mu.Unlock()
+	close(c.done)
+}
+
+// fetch reads key from the store, falling back to upstream and storing its forecast
+//
+// The forecast returned is shared and must not be modified.
+func (p *Provider) fetch(ctx context.Context, key Key, r providers.Request) (*plumber.BaseData, error) {
+	entry, err := p.store.Get(ctx, key)
+	switch {
+	case err == nil:
+		p.logger.Debug("Cache hit", "key", key.String())
+		return entry.Data, nil
+	case errors.Is(err, ErrMiss):
+	case ctx.Err() != nil:
+		return nil, ctx.Err()
+	default:
+		p.logger.Error(e.FAIL, "err", err, "description", "Couldn't read from cache", "key", key.String())
+	}
+
+	var token uint64
+	if p.opts.Locks != nil {
+		lock, err := p.opts.Locks.Lock(ctx, key.String(), p.opts.LockTTL)
+		switch {
+		case err == nil:
+			defer func() {
+				if err := lock.Unlock(context.WithoutCancel(ctx)); err != nil {
+					p.logger.Error(e.FAIL, "err", err, "description", "Fetch lock was lost", "key", key.String())
+				}
+			}()
+			token = lock.Token()
+
+			// Whoever held the lock before us has most likely filled the cache already
+			if entry, err := p.store.Get(ctx, key); err == nil {
+				p.logger.Debug("Cache filled while waiting for lock", "key", key.String())
+				return entry.Data, nil
+			}
+		case ctx.Err() != nil:
+			return nil, ctx.Err()
+		default:
+			p.logger.Error(e.FAIL, "err", err, "description", "Couldn't take fetch lock, fetching anyway", "key", key.String())
+		}
+	}
+
+	data, err := p.next.FetchData(ctx, key.Cell.Coordinates(), r)
+	if err != nil {
+		return nil, err
+	}
+
+	cycle := p.opts.Cycle
+	if key.Model != p.opts.Model {
+		cycle = CycleFor(key.Model) // Chosen by the request
+	}
+	now := time.Now()
+	expires := cycle.ExpiresAt(now)
+	if p.opts.TTL > 0 {
+		expires = now.Add(p.opts.TTL)
+	}
+	if providers.Settled(p.name, r.Options) {
+		expires = now.Add(settledTTL)
+	}
+	entry = &Entry{Key: key, Data: data, FetchedAt: now, ExpiresAt: expires, Token: token}
+	switch err := p.store.Put(ctx, entry); {
+	case err == nil:
+	case errors.Is(err, ErrFenced):
+		// A later lock holder has already stored a newer forecast; ours is still good to return
+		p.logger.Debug("Cache write fenced off", "key", key.String(), "token", token)
+	default:
+		p.logger.Error(e.FAIL, "err", err, "description", "Couldn't write to cache", "key", key.String())
+	}
+	return data, nil
+}
+
+// QueryParams returns the upstream query parameters for the grid cell of coords
+func (p *Provider) QueryParams(coords *plumber.Coordinates) map[string]string {
+	return p.next.QueryParams(p.Key(coords).Cell.Coordinates())
+}
@@ -0,0 +1,288 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tinkershack/meteomunch/config"
+	"github.com/tinkershack/meteomunch/plumber"
+	"github.com/tinkershack/meteomunch/providers"
+)
+
+// stubProvider counts its fetches and returns a forecast for the coordinates it's asked for
+type stubProvider struct {
+	fetches atomic.Int32
+	fetch   func(ctx context.Context, n int32) error // Runs on the nth fetch, from 1, if set
+}
+
+func (s *stubProvider) FetchData(ctx context.Context, coords *plumber.Coordinates, _ providers.Request) (*plumber.BaseData, error) {
+	n := s.fetches.Add(1)
+	if s.fetch != nil {
+		if err := s.fetch(ctx, n); err != nil {
+			return nil, err
+		}
+	}
+	return &plumber.BaseData{Latitude: coords.Latitude, Longitude: coords.Longitude}, nil
+}
+
+func (s *stubProvider) QueryParams(*plumber.Coordinates) map[string]string {
+	return nil
+}
+
+func TestOptionsFor(t *testing.T) {
+	cfg := &config.Config{MeteoProviders: []config.MeteoProvider{
+		{Name: "open-meteo", Model: "icon_seamless"},
+		{Name: "open-meteo-historical"},
+		{Name: "meteoblue", CacheTTL: time.Hour},
+	}}
+	tests := []struct {
+		name  string
+		model string
+		cycle Cycle
+	}{
+		{"open-meteo", "icon_seamless", UpdateCycles["icon_seamless"]},
+		{"open-meteo-historical", "best_match", DefaultCycle},
+		{"meteoblue", DefaultModel, DefaultCycle},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewProvider(tt.name, &stubProvider{}, NewMemoryStore(1), OptionsFor(tt.name, cfg))
+			if p.opts.Model != tt.model {
+				t.Errorf("model = %q, want %q", p.opts.Model, tt.model)
+			}
+			if p.opts.Cycle != tt.cycle {
+				t.Errorf("cycle = %+v, want %+v", p.opts.Cycle, tt.cycle)
+			}
+		})
+	}
+	if ttl := OptionsFor("meteoblue", cfg).TTL; ttl != time.Hour {
+		t.Errorf("meteoblue TTL = %v, want 1h", ttl)
+	}
+	for name, exact := range map[string]bool{"open-meteo": false, "meteoblue": false, "metar": true, "open-meteo-marine": true} {
+		if got := OptionsFor(name, cfg).Exact; got != exact {
+			t.Errorf("%s cached exact = %v, want %v", name, got, exact)
+		}
+	}
+}
+
+func TestProviderExact(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  Options
+		cells []Cell // Fetched for the two points, in the same 0.25° cell
+	}{
+		{"gridded", Options{}, []Cell{{46.75, 7.75}}},
+		{"exact", Options{Exact: true}, []Cell{{46.6812, 7.8601}, {46.7, 7.8}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upstream := &stubProvider{}
+			p := NewProvider("metar", upstream, NewMemoryStore(8), tt.opts)
+			var cells []Cell
+			for _, coords := range []*plumber.Coordinates{plumber.NewCoordinates(46.68123, 7.86005), plumber.NewCoordinates(46.7, 7.8)} {
+				data, err := p.FetchData(context.Background(), coords, providers.Request{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if cell := (Cell{data.Latitude, data.Longitude}); !slices.Contains(cells, cell) {
+					cells = append(cells, cell)
+				}
+			}
+			if !slices.Equal(cells, tt.cells) || int(upstream.fetches.Load()) != len(tt.cells) {
+				t.Errorf("fetched %d times for %v, want %v", upstream.fetches.Load(), cells, tt.cells)
+			}
+		})
+	}
+}
+
+// rangeProvider returns a day of data per day of the range it's asked for and records the ranges
+type rangeProvider struct {
+	stubProvider
+	mu     sync.Mutex
+	ranges []string
+}
+
+func (p *rangeProvider) FetchData(ctx context.Context, coords *plumber.Coordinates, r providers.Request) (*plumber.BaseData, error) {
+	p.mu.Lock()
+	p.ranges = append(p.ranges, r.Options.StartDate+".."+r.Options.EndDate)
+	p.mu.Unlock()
+	start, end, _ := r.Options.DateRange()
+	bd := &plumber.BaseData{Latitude: coords.Latitude, Longitude: coords.Longitude}
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		bd.Hourly.Time = append(bd.Hourly.Time, day.Unix())
+		bd.Hourly.Temperature2M = append(bd.Hourly.Temperature2M, float64(day.YearDay()))
+		bd.Daily.Time = append(bd.Daily.Time, day.Unix())
+	}
+	return bd, nil
+}
+
+func TestProviderSettledYears(t *testing.T) {
+	upstream := &rangeProvider{}
+	store := NewMemoryStore(8)
+	p := NewProvider("open-meteo-historical", upstream, store, Options{})
+	coords := plumber.NewCoordinates(46.68, 7.86)
+	fetch := func(start, end string) *plumber.BaseData {
+		t.Helper()
+		data, err := p.FetchData(context.Background(), coords, providers.Request{Options: providers.Options{StartDate: start, EndDate: end}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	data := fetch("2019-12-30", "2021-01-02")
+	if want := []string{"2019-12-30..2019-12-31", "2020-01-01..2020-12-31", "2021-01-01..2021-01-02"}; !slices.Equal(upstream.ranges, want) {
+		t.Errorf("fetched %v, want %v", upstream.ranges, want)
+	}
+	if n := len(data.Daily.Time); n != 370 || len(data.Hourly.Temperature2M) != 370 {
+		t.Errorf("joined %d days and %d temperatures, want 370", n, len(data.Hourly.Temperature2M))
+	}
+	for i := 1; i < len(data.Daily.Time); i++ {
+		if data.Daily.Time[i]-data.Daily.Time[i-1] != 86400 {
+			t.Fatalf("days %d and %d aren't consecutive", i-1, i)
+		}
+	}
+	if n := store.Len(); n != 3 {
+		t.Errorf("store holds %d entries, want one per year", n)
+	}
+
+	// The years are shared with the ranges overlapping them
+	upstream.ranges = nil
+	if data := fetch("2020-01-01", "2021-01-02"); len(data.Daily.Time) != 368 || upstream.ranges != nil {
+		t.Errorf("joined %d days and fetched %v, want 368 days from the cache", len(data.Daily.Time), upstream.ranges)
+	}
+	fetch("2020-03-01", "2020-03-31")
+	if want := []string{"2020-03-01..2020-03-31"}; !slices.Equal(upstream.ranges, want) {
+		t.Errorf("fetched %v, want %v", upstream.ranges, want)
+	}
+}
+
+func TestProviderKeysConfiguredModel(t *testing.T) {
+	cfg := &config.Config{MeteoProviders: []config.MeteoProvider{{Name: "open-meteo", Model: "icon_seamless"}}}
+	store := NewMemoryStore(8)
+	p := NewProvider("open-meteo", &stubProvider{}, store, OptionsFor("open-meteo", cfg))
+	coords := plumber.NewCoordinates(46.68, 7.86)
+
+	if _, err := p.FetchData(context.Background(), coords, providers.Request{}); err != nil {
+		t.Fatal(err)
+	}
+	key := p.Key(coords)
+	if key.Model != "icon_seamless" {
+		t.Fatalf("key model = %q, want icon_seamless", key.Model)
+	}
+	entry, err := store.Get(context.Background(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := UpdateCycles["icon_seamless"].ExpiresAt(entry.FetchedAt); !entry.ExpiresAt.Equal(want) {
+		t.Errorf("expires at %v, want %v at the next icon_seamless run", entry.ExpiresAt, want)
+	}
+
+	// The configured model chosen again by the request is the same forecast
+	o := providers.Request{Options: providers.Options{Model: "icon_seamless"}}
+	if _, err := p.FetchData(context.Background(), coords, o); err != nil {
+		t.Fatal(err)
+	}
+	if n := store.Len(); n != 1 {
+		t.Errorf("store holds %d entries, want 1", n)
+	}
+}
+
+func TestProviderAbandonedCallIsNotJoined(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	stub := &stubProvider{fetch: func(ctx context.Context, n int32) error {
+		if n > 1 {
+			return nil
+		}
+		// The first fetch is abandoned, and lingers until released after noticing
+		close(started)
+		<-ctx.Done()
+		<-release
+		return ctx.Err()
+	}}
+	p := NewProvider("open-meteo", stub, NewMemoryStore(8), Options{})
+	coords := plumber.NewCoordinates(46.68, 7.86)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := p.FetchData(ctx, coords, providers.Request{})
+		first <- err
+	}()
+	<-started
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Fatalf("abandoned fetch returned %v, want context.Canceled", err)
+	}
+
+	second := make(chan error, 1)
+	go func() {
+		_, err := p.FetchData(context.Background(), coords, providers.Request{})
+		second <- err
+	}()
+	select {
+	case err := <-second:
+		if err != nil {
+			t.Fatalf("fetch after an abandoned one returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("fetch after an abandoned one joined it")
+	}
+	if n := stub.fetches.Load(); n != 2 {
+		t.Errorf("upstream fetched %d times, want 2", n)
+	}
+}
+
+func TestProviderCoalescesFetches(t *testing.T) {
+	release := make(chan struct{})
+	stub := &stubProvider{fetch: func(context.Context, int32) error {
+		<-release
+		return nil
+	}}
+	p := NewProvider("open-meteo", stub, NewMemoryStore(8), Options{})
+
+	const callers = 16
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Points of the same grid cell
+			_, err := p.FetchData(context.Background(), plumber.NewCoordinates(46.68+float64(i)*0.001, 7.86), providers.Request{})
+			errs <- err
+		}()
+	}
+	for p.waiters() < callers {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := stub.fetches.Load(); n != 1 {
+		t.Errorf("upstream fetched %d times, want 1", n)
+	}
+}
+
+// waiters returns the number of callers waiting on in-flight calls
+func (p *Provider) waiters() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	n := 0
+	for _, c := range p.inflight {
+		n += c.waiters
+	}
+	return n
+}
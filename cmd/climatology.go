@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tinkershack/meteomunch/config"
+	"github.com/tinkershack/meteomunch/plumber"
+	"github.com/tinkershack/meteomunch/providers"
+)
+
+var climatologyFlags struct {
+	lat, lon float64
+	provider string
+	years    int
+	fields   []string
+	options  providers.Options
+}
+
+// climatologyCmd computes the climate of a site from historical data and prints it
+var climatologyCmd = &cobra.Command{
+	Use:   "climatology",
+	Short: "climatology computes the climate of a site from past weather",
+	Long: `climatology computes the climate of a site from past weather and prints it as JSON
+
+The hourly data of the last --years complete years, or of the range given with
+--start-date and --end-date, is fetched from a historical provider and
+summarised: means and percentile bands per month, means per hour of the day,
+the share of flyable days per month, wind roses and typical thermal tops.
+Flyability is judged against conservative paragliding limits. Months and hours
+are local to the site unless --timezone says otherwise. Fetching decades of
+data takes a while.`,
+	Example: `  munch climatology --lat 46.68 --lon 7.86 --years 20`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		coords := plumber.NewCoordinates(climatologyFlags.lat, climatologyFlags.lon)
+		if err := coords.Validate(); err != nil {
+			return err
+		}
+		o := climatologyFlags.options
+		if o.StartDate == "" {
+			if climatologyFlags.years < 1 {
+				return errors.New("years must be at least 1")
+			}
+			past := providers.PastYears(climatologyFlags.years)
+			o.StartDate, o.EndDate = past.StartDate, past.EndDate
+		}
+
+		cfg, err := config.Get()
+		if err != nil {
+			return err
+		}
+		limits, err := providers.LimitsOf(climatologyFlags.provider)
+		if err != nil {
+			return err
+		}
+		if err := o.Validate(limits); err != nil {
+			return err
+		}
+		p, err := providers.New(climatologyFlags.provider, cfg)
+		if err != nil {
+			return err
+		}
+
+		bd, err := p.FetchData(context.Background(), coords, providers.Request{Options: o})
+		if err != nil {
+			return err
+		}
+		c, err := bd.Climatology(climatologyFlags.fields, plumber.ParaglidingLimits)
+		if err != nil {
+			return err
+		}
+
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(c)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(climatologyCmd)
+
+	f := climatologyCmd.Flags()
+	f.Float64Var(&climatologyFlags.lat, "lat", 0, "latitude of the site")
+	f.Float64Var(&climatologyFlags.lon, "lon", 0, "longitude of the site")
+	f.StringVar(&climatologyFlags.provider, "provider", "open-meteo-historical", "historical provider to fetch the past weather from")
+	f.IntVar(&climatologyFlags.years, "years", 10, "complete past years to cover")
+	f.StringSliceVar(&climatologyFlags.fields, "fields", nil, "hourly fields to average (default temperature, humidity, rain, clouds, sunshine, wind and boundary layer)")
+	f.StringVar(&climatologyFlags.options.Model, "model", "", "reanalysis model, e.g. era5 or era5_land")
+	f.StringVar(&climatologyFlags.options.CellSelection, "cell-selection", "", "grid cell to pick: land, sea or nearest")
+	f.StringVar(&climatologyFlags.options.Timezone, "timezone", "auto", "time zone the months and hours are local to")
+	f.StringVar(&climatologyFlags.options.StartDate, "start-date", "", "first day of the range instead of --years, e.g. 2000-01-01")
+	f.StringVar(&climatologyFlags.options.EndDate, "end-date", "", "last day of the range, inclusive")
+	climatologyCmd.MarkFlagsMutuallyExclusive("years", "start-date")
+	_ = climatologyCmd.MarkFlagRequired("lat")
+	_ = climatologyCmd.MarkFlagRequired("lon")
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tinkershack/meteomunch/config"
+	"github.com/tinkershack/meteomunch/plumber"
+	"github.com/tinkershack/meteomunch/providers"
+	"github.com/tinkershack/meteomunch/units"
+)
+
+var forecastFlags struct {
+	lat, lon float64
+	provider string
+	units    string
+	fields   string
+	air      bool
+	options  providers.Options
+}
+
+// forecastCmd fetches a forecast from a provider and prints it
+var forecastCmd = &cobra.Command{
+	Use:   "forecast",
+	Short: "forecast fetches the forecast for a location",
+	Long: `forecast fetches the forecast for a location from a provider and prints it as JSON
+
+Values are converted into the unit system given with --units: metric, the
+default, imperial, si or aviation. The units used are listed in the output.
+--fields limits the forecast to the named fields, e.g. hourly.temperature_2m,
+daily.sunrise, and only requests those from the provider where it can.
+--model, --days, --hours, --past-days, --cell-selection and --timezone
+override the options configured for the provider; --start-date and
+--end-date fetch a range of days instead, e.g. from open-meteo-historical.
+--air-quality adds the air quality of the same hours from
+open-meteo-air-quality, as does selecting air_quality fields.`,
+	Example: `  munch forecast --lat 11.0168 --lon 76.9558 --units aviation`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		system, err := units.Lookup(forecastFlags.units)
+		if err != nil {
+			return err
+		}
+
+		fields, err := plumber.ParseFields(forecastFlags.fields)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Get()
+		if err != nil {
+			return err
+		}
+		limits, err := providers.LimitsOf(forecastFlags.provider)
+		if err != nil {
+			return err
+		}
+		if err := forecastFlags.options.Validate(limits); err != nil {
+			return err
+		}
+		p, err := providers.New(forecastFlags.provider, cfg)
+		if err != nil {
+			return err
+		}
+
+		coords := plumber.NewCoordinates(forecastFlags.lat, forecastFlags.lon)
+		if err := coords.Validate(); err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		if timeout := cfg.Munch.Server.FetchTimeout; timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		req, air, withAirQuality := providers.AirQualityRequest(providers.Request{Options: forecastFlags.options, Fields: fields}, forecastFlags.air)
+		bd, err := p.FetchData(ctx, coords, req)
+		if err != nil {
+			return err
+		}
+		if withAirQuality {
+			aq, err := providers.New("open-meteo-air-quality", cfg)
+			if err != nil {
+				return err
+			}
+			data, err := aq.FetchData(ctx, coords, air)
+			if err != nil {
+				return err
+			}
+			bd.SetAirQuality(data.AirQuality)
+		}
+		system.Convert(bd)
+
+		var out any = bd
+		if req.Fields != nil {
+			if out, err = req.Fields.Project(bd); err != nil {
+				return err
+			}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(forecastCmd)
+
+	f := forecastCmd.Flags()
+	f.Float64Var(&forecastFlags.lat, "lat", 0, "latitude of the location")
+	f.Float64Var(&forecastFlags.lon, "lon", 0, "longitude of the location")
+	f.StringVar(&forecastFlags.provider, "provider", "open-meteo", "provider to fetch the forecast from")
+	f.StringVar(&forecastFlags.units, "units", "metric", "unit system: metric, imperial, si or aviation")
+	f.BoolVar(&forecastFlags.air, "air-quality", false, "add the air quality of the same hours")
+	f.StringVar(&forecastFlags.fields, "fields", "", "comma separated fields to keep, e.g. current,hourly.cape (default all)")
+	f.StringVar(&forecastFlags.options.Model, "model", "", "weather model, e.g. icon_seamless")
+	f.IntVar(&forecastFlags.options.ForecastDays, "days", 0, "days of forecast")
+	f.IntVar(&forecastFlags.options.ForecastHours, "hours", 0, "hours of hourly forecast from the current hour")
+	f.IntVar(&forecastFlags.options.PastDays, "past-days", 0, "days before today to include")
+	f.StringVar(&forecastFlags.options.CellSelection, "cell-selection", "", "grid cell to pick: land, sea or nearest")
+	f.StringVar(&forecastFlags.options.Timezone, "timezone", "", "time zone the days are aligned to, or auto")
+	f.StringVar(&forecastFlags.options.StartDate, "start-date", "", "first day of a range to fetch instead, e.g. 2020-01-01")
+	f.StringVar(&forecastFlags.options.EndDate, "end-date", "", "last day of the range, inclusive")
+	_ = forecastCmd.MarkFlagRequired("lat")
+	_ = forecastCmd.MarkFlagRequired("lon")
+}
@@ -10,8 +10,11 @@ import (
 // serverCmd operates the server command
 var serverCmd = &cobra.Command{
 	Use:   "server",
-	Short: "server serves meteo data for grpc clients",
-	Long:  `server serves meteo data for grpc clients`,
+	Short: "server serves meteo data for http and grpc clients",
+	Long: `server serves meteo data for http and grpc clients
+
+The HTTP API listens on Munch.Server.Port and the gRPC Forecast service
+on Munch.Server.GRPCPort, both bound to Munch.Server.Hostname.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		log.Debug("server called")
 		server.Serve(context.Background(), args)
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tinkershack/meteomunch/config"
+	"github.com/tinkershack/meteomunch/plumber"
+	"github.com/tinkershack/meteomunch/store"
+	"github.com/tinkershack/meteomunch/verify"
+)
+
+var verifyFlags struct {
+	observations []string
+	lat, lon     float64
+	provider     string
+	model        string
+	from, to     string
+	fields       []string
+	leadBucket   time.Duration
+}
+
+// verifyCmd scores the archived forecasts against observations
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "verify scores archived forecasts against observations",
+	Long: `verify scores archived forecasts against observations
+
+Forecasts are read from the archive configured by Munch.ForecastStore and
+observations from the CSV or JSON files given with --observations. The report,
+printed as JSON, has MAE, RMSE, bias and hit/miss rates per provider, model,
+field and lead time, along with suggested provider weights per field. --lat and
+--lon narrow it down to the forecasts for a single location.`,
+	Example: `  munch verify --observations obs.csv --from 2024-10-01T00:00:00Z --fields temperature_2m,precipitation`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		q := store.Query{Provider: verifyFlags.provider, Model: verifyFlags.model}
+		if cmd.Flags().Changed("lat") {
+			q.Location = plumber.NewCoordinates(verifyFlags.lat, verifyFlags.lon)
+			if err := q.Location.Validate(); err != nil {
+				return err
+			}
+		}
+		var err error
+		if q.From, err = flagTime("from", verifyFlags.from); err != nil {
+			return err
+		}
+		if q.To, err = flagTime("to", verifyFlags.to); err != nil {
+			return err
+		}
+
+		src, err := verify.NewObservations()
+		if err != nil {
+			return err
+		}
+		for _, path := range verifyFlags.observations {
+			if err := src.ImportFile(path); err != nil {
+				return err
+			}
+		}
+
+		cfg, err := config.Get()
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+		fs, err := store.FromConfig(ctx, cfg)
+		if errors.Is(err, store.ErrNotConfigured) {
+			return errors.New("set Munch.ForecastStore to verify the forecasts archived in it")
+		}
+		if err != nil {
+			return err
+		}
+		defer fs.Close()
+
+		opts := verify.Options{Fields: verifyFlags.fields, LeadBucket: verifyFlags.leadBucket}
+		report, err := verify.Run(ctx, fs, src, q, opts)
+		if err != nil {
+			return err
+		}
+
+		fields := opts.Fields
+		if len(fields) == 0 {
+			fields = verify.DefaultFields
+		}
+		weights := make(map[string]map[string]float64, len(fields))
+		for _, field := range fields {
+			weights[field] = report.Weights(field)
+		}
+
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			*verify.Report
+			Weights map[string]map[string]float64 `json:"weights"`
+		}{report, weights})
+	},
+}
+
+// flagTime parses an optional RFC 3339 time flag
+func flagTime(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("--%s must be an RFC 3339 time: %w", name, err)
+	}
+	return t, nil
+}
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+
+	f := verifyCmd.Flags()
+	f.StringSliceVar(&verifyFlags.observations, "observations", nil, "CSV or JSON files of observations, may be repeated")
+	f.Float64Var(&verifyFlags.lat, "lat", 0, "only verify the forecasts for this latitude, along with --lon")
+	f.Float64Var(&verifyFlags.lon, "lon", 0, "only verify the forecasts for this longitude, along with --lat")
+	f.StringVar(&verifyFlags.provider, "provider", "", "only verify this provider")
+	f.StringVar(&verifyFlags.model, "model", "", "only verify this model")
+	f.StringVar(&verifyFlags.from, "from", "", "only verify forecast hours from this RFC 3339 time")
+	f.StringVar(&verifyFlags.to, "to", "", "only verify forecast hours up to this RFC 3339 time")
+	f.StringSliceVar(&verifyFlags.fields, "fields", nil, "hourly fields to verify (default temperature_2m,wind_speed_10m,precipitation)")
+	f.DurationVar(&verifyFlags.leadBucket, "lead-bucket", verify.DefaultLeadBucket, "width of the lead time ranges")
+	_ = verifyCmd.MarkFlagRequired("observations")
+	verifyCmd.MarkFlagsRequiredTogether("lat", "lon")
+}
@@ -12,6 +12,7 @@ package config
 import (
 	"log/slog" // avoiding logger package to prevent cyclic imports
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -27,8 +28,10 @@ func init() {
 type Config struct {
 	Munch          Munch     // Parameters of munch app, excluding external dependencies
 	Mongo          DataStore // Gets picked if DocumentStore is "mongo"
-	DLMRedis       DataStore
+	DLMRedis       DataStore // Gets picked if LockManager is "redis"
 	MeteoProviders []MeteoProvider
+	// Personal weather stations allowed to upload readings to the server's ingest endpoints
+	WeatherStations []WeatherStation
 }
 
 func (c *Config) GetMunch() Munch {
@@ -47,22 +50,74 @@ func (c *Config) GetMeteoProviders() []MeteoProvider {
 	return c.MeteoProviders
 }
 
+func (c *Config) GetWeatherStations() []WeatherStation {
+	return c.WeatherStations
+}
+
 // TODO: Validate URL string
 type MeteoProvider struct {
 	Name    string
 	APIKey  string
-	APIPath string // Path to the provider's API, excluding the base URI
-	BaseURI string // URI of the provider's API, fully qualified with protocol
+	APIPath string  // Path to the provider's API, excluding the base URI
+	BaseURI string  // URI of the provider's API, fully qualified with protocol
+	Weight  float64 // Relative weight of the provider in a weighted consensus, defaults to 1
+	// Lifetime of cached forecasts, e.g. "30m". Zero keeps them until the model's next run is published
+	CacheTTL time.Duration
+
+	// Defaults of what's fetched, which requests may override within the provider's limits (providers.LimitsOf).
+	// Empty or zero values leave them to the provider: open-meteo fetches 24 hours of its best_match model
+	// for the nearest grid cell, in GMT
+	Model         string // Weather model, e.g. icon_seamless
+	ForecastDays  int    // Days of forecast, up to 16 for open-meteo
+	ForecastHours int    // Hours of hourly forecast from the current hour, within ForecastDays
+	PastDays      int    // Days before today to include, up to 92 for open-meteo
+	CellSelection string // land, sea or nearest grid cell to the coordinates
+	Timezone      string // Time zone database name the days are aligned to, or auto for the location's own
+	// Variables requested per block, by their names in the plumber package. Empty requests all that the provider
+	// maps, currently only configurable for the open-meteo providers: only Hourly for open-meteo-ensemble,
+	// no Current for open-meteo-historical and the air quality variables, e.g. pm10, as Hourly for open-meteo-air-quality,
+	// likewise the marine variables, e.g. wave_height, for open-meteo-marine
+	Current []string
+	Hourly  []string
+	Daily   []string
+}
+
+// WeatherStation is a personal weather station uploading with the Weather Underground or Ecowitt protocol
+type WeatherStation struct {
+	ID        string  // Station ID, sent as ID by Weather Underground protocol uploads
+	Key       string  // Secret the station authenticates with, the WU PASSWORD or the Ecowitt PASSKEY; required
+	Name      string  // Name of the site, e.g. the launch the station is set up at
+	Latitude  float64 // in degrees
+	Longitude float64 // in degrees
+	Elevation float64 // Above mean sea level in m
+	Timezone  string  // Time zone database name of the site, e.g. Europe/Zurich
 }
 
 type Munch struct {
-	Server   MunchServer
-	LogLevel string // Log level for the application
+	Server        MunchServer
+	LogLevel      string // Log level for the application
+	DocumentStore string // Caches fetched forecasts in the named store, only "mongo" for now. Empty disables caching
+	// Lets replicas sharing the DocumentStore take turns fetching the same forecast through the named lock manager,
+	// only "redis" (DLMRedis) for now. Empty disables locking
+	LockManager string
+	// Number of forecasts cached in process memory, in front of the DocumentStore, per provider, model and grid cell
+	// like the DocumentStore. 0, the default, disables it
+	MemoryCache int
+	// Archives every forecast fetched from upstream in the named store, "bolt" (ForecastStorePath) or "mongo" (Mongo).
+	// Empty disables the archive
+	ForecastStore     string
+	ForecastStorePath string // File of the "bolt" ForecastStore
+	// CSV of aviation stations in the format of OurAirports' airports.csv, added to the stations the "metar"
+	// provider knows out of the box
+	AviationStations string
 }
 
 type MunchServer struct {
 	Hostname string
-	Port     string
+	Port     string // Port for the HTTP transport
+	GRPCPort string // Port for the gRPC transport, served alongside HTTP on the same Hostname
+	// Deadline for fetching a forecast from the upstream providers, e.g. "10s". Zero disables it
+	FetchTimeout time.Duration
 }
 
 type DataStore struct {
@@ -78,10 +133,13 @@ var currentConfigErrors error
 var defaultConfig = &Config{
 	Munch: Munch{
 		Server: MunchServer{
-			Hostname: "localhost",
-			Port:     "50050",
+			Hostname:     "localhost",
+			Port:         "50050",
+			GRPCPort:     "50051",
+			FetchTimeout: 10 * time.Second,
 		},
-		LogLevel: "info",
+		LogLevel:          "info",
+		ForecastStorePath: "munch.db",
 	},
 	Mongo: DataStore{
 		Name:     "mongo",
@@ -0,0 +1,362 @@
+// Package dlm is a distributed lock manager that lets munch replicas agree on who fetches a forecast
+//
+// It implements the Redlock algorithm: a lock is held when it's been set on a majority of independent
+// redis instances within its validity time. A single instance works too, at the cost of the lock being
+// lost if that instance restarts without persistence.
+//
+// Locks are leases. They're renewed in the background until unlocked, and Lock.Context is cancelled as
+// soon as a renewal fails to reach a majority. Every acquisition also hands out a fencing token that
+// grows with each holder of the same key, so that a store can reject writes from a holder whose lease
+// silently expired, e.g. during a long GC pause.
+//
+// Example usage:
+//
+//	m, err := dlm.NewFromConfig(ctx, cfg.DLMRedis)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer m.Close()
+//
+//	lock, err := m.Lock(ctx, "open-meteo/default/11.0000,77.0000", 10*time.Second)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer lock.Unlock(context.Background())
+//	// Write with lock.Token(), abort when lock.Context() is done
+package dlm
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	mrand "math/rand/v2"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/tinkershack/meteomunch/config"
+	e "github.com/tinkershack/meteomunch/errors"
+	"github.com/tinkershack/meteomunch/logger"
+)
+
+var (
+	// ErrNotAcquired is returned by TryLock when the lock is held by someone else or a majority couldn't be reached
+	ErrNotAcquired = errors.New("lock not acquired")
+	// ErrLockLost is the cause of a lock's context being cancelled because its lease couldn't be renewed
+	ErrLockLost = errors.New("lock lost")
+	// ErrUnlocked is the cause of a lock's context being cancelled by Unlock
+	ErrUnlocked = errors.New("lock released")
+)
+
+const (
+	lockPrefix  = "munch:lock:"
+	fencePrefix = "munch:fence:"
+	// fenceTTL keeps fencing counters of keys that aren't locked anymore from piling up
+	fenceTTL = 7 * 24 * time.Hour
+	// clockDrift is the share of the TTL allowed for clock drift between instances, as the Redlock paper suggests
+	clockDrift = 0.01
+)
+
+var (
+	// acquireScript sets the lock if it's free and returns the next fencing token, 0 otherwise
+	acquireScript = redis.NewScript(`
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	local token = redis.call("INCR", KEYS[2])
+	redis.call("PEXPIRE", KEYS[2], ARGV[3])
+	return token
+end
+return 0`)
+
+	// fenceScript raises the fencing counter to at least the token handed out, so that all instances agree on it
+	fenceScript = redis.NewScript(`
+local current = tonumber(redis.call("GET", KEYS[1]) or "0")
+if current < tonumber(ARGV[1]) then
+	redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+end
+return 1`)
+
+	// extendScript renews the lease if the lock is still ours
+	extendScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+	// releaseScript deletes the lock if it's still ours
+	releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+)
+
+// Manager hands out locks backed by one or more independent redis instances
+//
+// A Manager is safe for concurrent use.
+type Manager struct {
+	clients    []*redis.Client
+	quorum     int
+	retryDelay time.Duration // Base delay between attempts of Lock, jittered to keep contenders apart
+	logger     *slog.Logger
+}
+
+// New returns a Manager over the given independent redis instances
+func New(clients ...*redis.Client) (*Manager, error) {
+	if len(clients) == 0 {
+		return nil, errors.New("at least one redis client is required")
+	}
+	return &Manager{
+		clients:    clients,
+		quorum:     len(clients)/2 + 1,
+		retryDelay: 100 * time.Millisecond,
+		logger:     logger.NewTag("dlm"),
+	}, nil
+}
+
+// NewFromConfig connects to the redis instances described by ds, usually config.Config.DLMRedis
+//
+// ds.URI may list several comma separated instances for Redlock; ds.DBNumber selects the database
+// of those that don't name one in their URI.
+func NewFromConfig(ctx context.Context, ds config.DataStore) (*Manager, error) {
+	var clients []*redis.Client
+	closeAll := func() {
+		for _, c := range clients {
+			_ = c.Close()
+		}
+	}
+
+	for _, uri := range strings.Split(ds.URI, ",") {
+		uri = strings.TrimSpace(uri)
+		if uri == "" {
+			continue
+		}
+		opts, err := redis.ParseURL(uri)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("invalid redis URI %q: %w", uri, err)
+		}
+		if opts.DB == 0 {
+			opts.DB = ds.DBNumber
+		}
+		c := redis.NewClient(opts)
+		clients = append(clients, c)
+		if err := c.Ping(ctx).Err(); err != nil {
+			closeAll()
+			return nil, fmt.Errorf("couldn't reach redis at %s: %w", opts.Addr, err)
+		}
+	}
+
+	m, err := New(clients...)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Close closes the connections to every redis instance
+func (m *Manager) Close() error {
+	var errs []error
+	for _, c := range m.clients {
+		errs = append(errs, c.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// Lock blocks until it acquires the lock for key with a lease of ttl, or ctx is done
+func (m *Manager) Lock(ctx context.Context, key string, ttl time.Duration) (*Lock, error) {
+	for {
+		lock, err := m.TryLock(ctx, key, ttl)
+		if !errors.Is(err, ErrNotAcquired) {
+			return lock, err
+		}
+
+		delay := m.retryDelay/2 + mrand.N(m.retryDelay)
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %s: %w", ErrNotAcquired, key, ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
+
+// TryLock makes a single attempt at acquiring the lock for key with a lease of ttl
+//
+// The error wraps ErrNotAcquired if the lock is held by someone else or a majority of the instances couldn't be reached.
+func (m *Manager) TryLock(ctx context.Context, key string, ttl time.Duration) (*Lock, error) {
+	if ttl <= 0 {
+		return nil, errors.New("lock ttl must be positive")
+	}
+	value, err := randomValue()
+	if err != nil {
+		return nil, err
+	}
+
+	lockKey, fenceKey := lockPrefix+key, fencePrefix+key
+	start := time.Now()
+
+	tokens := make([]int64, len(m.clients))
+	m.each(ctx, ttl, func(ctx context.Context, i int, c *redis.Client) error {
+		t, err := acquireScript.Run(ctx, c, []string{lockKey, fenceKey}, value, ttl.Milliseconds(), fenceTTL.Milliseconds()).Int64()
+		tokens[i] = t
+		return err
+	})
+
+	var acquired int
+	var token int64
+	for _, t := range tokens {
+		if t > 0 {
+			acquired++
+			token = max(token, t)
+		}
+	}
+
+	drift := time.Duration(float64(ttl)*clockDrift) + 2*time.Millisecond
+	validity := ttl - time.Since(start) - drift
+	if acquired < m.quorum || validity <= 0 {
+		// Undo partial acquisitions even if ctx is done, rather than leave them until they expire
+		m.release(context.WithoutCancel(ctx), lockKey, value, ttl)
+		return nil, fmt.Errorf("%w: %s", ErrNotAcquired, key)
+	}
+
+	// Best effort: instances that miss the bump only hand out lower tokens, and every majority overlaps this one
+	m.each(ctx, ttl, func(ctx context.Context, i int, c *redis.Client) error {
+		if tokens[i] == 0 {
+			return nil
+		}
+		return fenceScript.Run(ctx, c, []string{fenceKey}, token, fenceTTL.Milliseconds()).Err()
+	})
+
+	lockCtx, cancel := context.WithCancelCause(context.Background())
+	l := &Lock{
+		m:      m,
+		key:    lockKey,
+		value:  value,
+		ttl:    ttl,
+		token:  uint64(token),
+		ctx:    lockCtx,
+		cancel: cancel,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go l.renew(start.Add(validity))
+	return l, nil
+}
+
+// each runs fn against every instance concurrently, each call bounded by a share of ttl so that a dead instance can't eat the lease
+//
+// Errors are logged and otherwise ignored; callers judge the outcome by the quorum.
+func (m *Manager) each(ctx context.Context, ttl time.Duration, fn func(ctx context.Context, i int, c *redis.Client) error) {
+	var wg sync.WaitGroup
+	for i, c := range m.clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, max(ttl/10, 50*time.Millisecond))
+			defer cancel()
+			if err := fn(ctx, i, c); err != nil && !errors.Is(err, redis.Nil) {
+				m.logger.Debug("Redis instance failed", "err", err, "addr", c.Options().Addr)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// release deletes the lock from every instance that still holds it with our value
+func (m *Manager) release(ctx context.Context, lockKey, value string, ttl time.Duration) {
+	m.each(ctx, ttl, func(ctx context.Context, _ int, c *redis.Client) error {
+		return releaseScript.Run(ctx, c, []string{lockKey}, value).Err()
+	})
+}
+
+// Lock is a held lock, renewed in the background until Unlock is called or its lease is lost
+type Lock struct {
+	m      *Manager
+	key    string
+	value  string
+	ttl    time.Duration
+	token  uint64
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	stop   chan struct{}
+	done   chan struct{}
+	once   sync.Once
+}
+
+// Token returns the fencing token of the lock, greater than that of every previous holder of the same key
+func (l *Lock) Token() uint64 {
+	return l.token
+}
+
+// Context is cancelled when the lock is released or lost; context.Cause tells ErrUnlocked and ErrLockLost apart
+func (l *Lock) Context() context.Context {
+	return l.ctx
+}
+
+// Unlock stops renewing the lease and releases the lock
+//
+// The error wraps ErrLockLost if the lease had been lost before. Unlocking more than once is a no-op.
+func (l *Lock) Unlock(ctx context.Context) error {
+	var err error
+	l.once.Do(func() {
+		close(l.stop)
+		<-l.done
+		if cause := context.Cause(l.ctx); errors.Is(cause, ErrLockLost) {
+			err = fmt.Errorf("%w: %s", cause, l.key)
+		}
+		l.cancel(ErrUnlocked)
+		l.m.release(ctx, l.key, l.value, l.ttl)
+	})
+	return err
+}
+
+// renew extends the lease at a third of its TTL until the lock is unlocked or a renewal misses the quorum
+func (l *Lock) renew(validUntil time.Time) {
+	defer close(l.done)
+
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+		}
+
+		start := time.Now()
+		var mu sync.Mutex
+		var extended int
+		l.m.each(context.Background(), l.ttl, func(ctx context.Context, _ int, c *redis.Client) error {
+			n, err := extendScript.Run(ctx, c, []string{l.key}, l.value, l.ttl.Milliseconds()).Int64()
+			if n > 0 {
+				mu.Lock()
+				extended++
+				mu.Unlock()
+			}
+			return err
+		})
+
+		if extended >= l.m.quorum && time.Now().Before(validUntil) {
+			drift := time.Duration(float64(l.ttl)*clockDrift) + 2*time.Millisecond
+			validUntil = start.Add(l.ttl - drift)
+			continue
+		}
+
+		l.m.logger.Error(e.FAIL, "err", ErrLockLost, "description", "Couldn't renew lock lease", "key", l.key, "extended", extended)
+		l.cancel(ErrLockLost)
+		return
+	}
+}
+
+// randomValue returns a value unique to a single acquisition, so that only its holder can extend or release the lock
+func randomValue() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("couldn't generate lock value: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,231 @@
+package dlm
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// newTestManager returns a Manager over n fresh in-memory redis instances
+func newTestManager(t *testing.T, n int) (*Manager, []*miniredis.Miniredis) {
+	t.Helper()
+	servers := make([]*miniredis.Miniredis, n)
+	clients := make([]*redis.Client, n)
+	for i := range servers {
+		servers[i] = miniredis.RunT(t)
+		clients[i] = redis.NewClient(&redis.Options{Addr: servers[i].Addr(), MaxRetries: -1})
+	}
+	m, err := New(clients...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.retryDelay = 10 * time.Millisecond
+	t.Cleanup(func() { m.Close() })
+	return m, servers
+}
+
+func TestLockAcquire(t *testing.T) {
+	m, servers := newTestManager(t, 3)
+	ctx := context.Background()
+
+	lock, err := m.TryLock(ctx, "open-meteo/default/11.0000,77.0000", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lock.Token() != 1 {
+		t.Errorf("token = %d, want 1", lock.Token())
+	}
+	for i, s := range servers {
+		if !s.Exists(lockPrefix + "open-meteo/default/11.0000,77.0000") {
+			t.Errorf("instance %d doesn't hold the lock", i)
+		}
+	}
+	if err := lock.Unlock(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if cause := context.Cause(lock.Context()); !errors.Is(cause, ErrUnlocked) {
+		t.Errorf("context cause after Unlock = %v, want ErrUnlocked", cause)
+	}
+	for i, s := range servers {
+		if s.Exists(lockPrefix + "open-meteo/default/11.0000,77.0000") {
+			t.Errorf("instance %d still holds the lock after Unlock", i)
+		}
+	}
+	if err := lock.Unlock(ctx); err != nil {
+		t.Errorf("second Unlock = %v, want a no-op", err)
+	}
+}
+
+func TestLockQuorum(t *testing.T) {
+	m, servers := newTestManager(t, 3)
+	ctx := context.Background()
+
+	// A minority down doesn't stop the lock
+	servers[0].Close()
+	lock, err := m.TryLock(ctx, "key", time.Second)
+	if err != nil {
+		t.Fatalf("with 2 of 3 instances: %v", err)
+	}
+	lock.Unlock(ctx)
+
+	// A majority down does, and leaves nothing behind on the instance that's up
+	servers[1].Close()
+	if _, err := m.TryLock(ctx, "key", time.Second); !errors.Is(err, ErrNotAcquired) {
+		t.Fatalf("with 1 of 3 instances: %v, want ErrNotAcquired", err)
+	}
+	if servers[2].Exists(lockPrefix + "key") {
+		t.Error("partial acquisition wasn't undone")
+	}
+}
+
+func TestLockContention(t *testing.T) {
+	m, _ := newTestManager(t, 3)
+	ctx := context.Background()
+
+	held, err := m.TryLock(ctx, "key", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.TryLock(ctx, "key", time.Second); !errors.Is(err, ErrNotAcquired) {
+		t.Errorf("TryLock of a held lock = %v, want ErrNotAcquired", err)
+	}
+	if _, err := m.TryLock(ctx, "other", time.Second); err != nil {
+		t.Errorf("TryLock of another key = %v", err)
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := m.Lock(waitCtx, "key", time.Second); !errors.Is(err, ErrNotAcquired) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Lock of a held lock = %v, want ErrNotAcquired and context.DeadlineExceeded", err)
+	}
+	held.Unlock(ctx)
+
+	// Contenders take turns, each under a greater token than the one before
+	var (
+		wg      sync.WaitGroup
+		holders atomic.Int32
+		mu      sync.Mutex
+		tokens  []uint64
+	)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lock, err := m.Lock(ctx, "key", time.Second)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if n := holders.Add(1); n != 1 {
+				t.Errorf("%d holders at once", n)
+			}
+			mu.Lock()
+			tokens = append(tokens, lock.Token())
+			mu.Unlock()
+			time.Sleep(5 * time.Millisecond)
+			holders.Add(-1)
+			lock.Unlock(ctx)
+		}()
+	}
+	wg.Wait()
+	for i := 1; i < len(tokens); i++ {
+		if tokens[i] <= tokens[i-1] {
+			t.Errorf("tokens %v don't grow with every holder", tokens)
+			break
+		}
+	}
+}
+
+func TestLockExpiry(t *testing.T) {
+	m, servers := newTestManager(t, 3)
+	ctx := context.Background()
+
+	lock, err := m.TryLock(ctx, "key", 300*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The lease runs out on every instance before it's renewed, e.g. while the holder is paused
+	for _, s := range servers {
+		s.FastForward(time.Second)
+	}
+	select {
+	case <-lock.Context().Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("lock context wasn't cancelled after the lease expired")
+	}
+	if cause := context.Cause(lock.Context()); !errors.Is(cause, ErrLockLost) {
+		t.Errorf("context cause = %v, want ErrLockLost", cause)
+	}
+
+	next, err := m.TryLock(ctx, "key", time.Second)
+	if err != nil {
+		t.Fatalf("TryLock after expiry: %v", err)
+	}
+	defer next.Unlock(ctx)
+	if next.Token() <= lock.Token() {
+		t.Errorf("token after expiry = %d, want more than %d", next.Token(), lock.Token())
+	}
+	if err := lock.Unlock(ctx); !errors.Is(err, ErrLockLost) {
+		t.Errorf("Unlock of a lost lock = %v, want ErrLockLost", err)
+	}
+	// The late Unlock mustn't release the new holder's lock
+	if _, err := m.TryLock(ctx, "key", time.Second); !errors.Is(err, ErrNotAcquired) {
+		t.Errorf("TryLock after a lost lock's Unlock = %v, want ErrNotAcquired", err)
+	}
+}
+
+func TestLockRenewal(t *testing.T) {
+	m, _ := newTestManager(t, 1)
+	ctx := context.Background()
+
+	lock, err := m.TryLock(ctx, "key", 150*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lock.Unlock(ctx)
+	time.Sleep(500 * time.Millisecond)
+	if err := lock.Context().Err(); err != nil {
+		t.Fatalf("lock lost while renewed: %v", context.Cause(lock.Context()))
+	}
+	if _, err := m.TryLock(ctx, "key", time.Second); !errors.Is(err, ErrNotAcquired) {
+		t.Errorf("TryLock of a renewed lock = %v, want ErrNotAcquired", err)
+	}
+}
+
+func TestLockFencing(t *testing.T) {
+	m, servers := newTestManager(t, 3)
+	ctx := context.Background()
+
+	// An instance that missed earlier bumps hands out lower tokens; the greatest of the majority wins
+	servers[0].Set(fencePrefix+"key", "10")
+	servers[1].Set(fencePrefix+"key", "4")
+	lock, err := m.TryLock(ctx, "key", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lock.Unlock(ctx)
+	if lock.Token() != 11 {
+		t.Errorf("token = %d, want 11", lock.Token())
+	}
+	for i, s := range servers {
+		if v, _ := s.Get(fencePrefix + "key"); v != "11" {
+			t.Errorf("instance %d fencing counter = %s, want 11", i, v)
+		}
+	}
+
+	// Even with the instance that ran ahead gone, the next token is still greater
+	servers[0].Close()
+	next, err := m.TryLock(ctx, "key", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer next.Unlock(ctx)
+	if next.Token() <= lock.Token() {
+		t.Errorf("token = %d, want more than %d", next.Token(), lock.Token())
+	}
+}
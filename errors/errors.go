@@ -9,7 +9,7 @@ const (
 //
 // Definied for use when it becomes necessary.
 type Munch struct {
-	Error   error
-	Message string
-	Code    int
+	Error   error  `json:"-"`
+	Message string `json:"message"`
+	Code    int    `json:"code"`
 }
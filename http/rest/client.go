@@ -4,16 +4,22 @@
 // This involves defining an interface for the HTTP client and a wrapper for the Resty client that implements this interface.
 // This way, Resty can be easily swapped out for another HTTP client in the future without affecting the calling functions.
 //
+// A client is configured once and then shared; every call builds its own Request so that concurrent callers
+// never see each other's query params, headers or path params.
+//
 // Example usage:
 //     var client rest.HTTPClient = rest.NewClient().
+//         SetDefaults().
+//         SetBaseURL("https://api.example.com").
+//         SetOutputDirectory("/path/to/output").
+//         EnableTrace()
+
+//     resp, err := client.NewRequest().
 //         SetAuthToken("dummy-auth-token").
 //         SetQueryParams(map[string]string{"key": "value"}).
 //         AcceptJSON().
-//         SetOutputDirectory("/path/to/output").
 //         SetPathParams(map[string]string{"userId": "123", "accountId": "456"}).
-//         EnableTrace()
-
-//     resp, err := client.Get("/v1/users/{userId}/{accountId}/details")
+//         Get(ctx, "/v1/users/{userId}/{accountId}/details")
 //     if err != nil {
 //     		log.Fatalf("Error: %v", err)
 //     }
@@ -26,9 +32,8 @@
 
 package rest
 
-// TODO: Query params passed through SetQueryParams() and SetQueryString() doesn't seem to work. Passing it via Get() works however.
-
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -36,20 +41,29 @@ import (
 )
 
 // HTTPClient interface defines the methods that an HTTP client should implement.
+//
+// The setters configure the client for every request it makes; call them before sharing the client between goroutines.
+// NewRequest is safe for concurrent use.
 type HTTPClient interface {
-	Get(url string) (*Response, error)
-	SetQueryParams(params map[string]string) HTTPClient
-	AcceptJSON() HTTPClient
-	SetQueryString(query string) HTTPClient
-	SetAuthToken(token string) HTTPClient
-	SetOutput(filename string) HTTPClient
+	NewRequest() Request
 	SetOutputDirectory(dir string) HTTPClient
-	SetPathParams(params map[string]string) HTTPClient
 	EnableTrace() HTTPClient
 	SetDefaults() HTTPClient
 	SetDebug() HTTPClient
 	SetBaseURL(url string) HTTPClient
-	NewRequest() HTTPClient
+}
+
+// Request interface defines the methods of a single HTTP request.
+//
+// A Request must not be used by more than one goroutine, nor reused once it has been sent.
+type Request interface {
+	Get(ctx context.Context, url string) (*Response, error)
+	SetQueryParams(params map[string]string) Request
+	AcceptJSON() Request
+	SetQueryString(query string) Request
+	SetAuthToken(token string) Request
+	SetOutput(filename string) Request
+	SetPathParams(params map[string]string) Request
 }
 
 // Response wraps the Resty response
@@ -76,8 +90,7 @@ func (r *Response) Body() []byte {
 //
 // RestyClient is a wrapper around Resty client and provides the necessary methods.
 type RestyClient struct {
-	restyClient  *resty.Client
-	restyRequest *resty.Request
+	restyClient *resty.Client
 }
 
 // NewClient creates a new RestyClient
@@ -87,42 +100,9 @@ func NewClient() *RestyClient {
 	}
 }
 
-func (c *RestyClient) Get(url string) (*Response, error) {
-	resp, err := c.restyRequest.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make GET request: %w", err)
-	}
-
-	if resp.IsError() {
-		return nil, fmt.Errorf("received error response: %s", resp.Status())
-	}
-
-	return &Response{restyResponse: resp}, nil
-}
-
-func (c *RestyClient) SetQueryParams(params map[string]string) HTTPClient {
-	c.restyRequest.SetQueryParams(params)
-	return c
-}
-
-func (c *RestyClient) AcceptJSON() HTTPClient {
-	c.restyRequest.SetHeader("Accept", "application/json")
-	return c
-}
-
-func (c *RestyClient) SetQueryString(query string) HTTPClient {
-	c.restyRequest.SetQueryString(query)
-	return c
-}
-
-func (c *RestyClient) SetAuthToken(token string) HTTPClient {
-	c.restyRequest.SetAuthToken(token)
-	return c
-}
-
-func (c *RestyClient) SetOutput(filename string) HTTPClient {
-	c.restyRequest.SetOutput(filename)
-	return c
+// NewRequest returns a fresh request that carries the client's configuration
+func (c *RestyClient) NewRequest() Request {
+	return &RestyRequest{restyRequest: c.restyClient.R()}
 }
 
 func (c *RestyClient) SetOutputDirectory(dir string) HTTPClient {
@@ -130,11 +110,6 @@ func (c *RestyClient) SetOutputDirectory(dir string) HTTPClient {
 	return c
 }
 
-func (c *RestyClient) SetPathParams(params map[string]string) HTTPClient {
-	c.restyRequest.SetPathParams(params)
-	return c
-}
-
 func (c *RestyClient) EnableTrace() HTTPClient {
 	c.restyClient.EnableTrace()
 	return c
@@ -158,7 +133,53 @@ func (c *RestyClient) SetBaseURL(url string) HTTPClient {
 	return c
 }
 
-func (c *RestyClient) NewRequest() HTTPClient {
-	c.restyRequest = c.restyClient.R()
-	return c
+// RestyRequest struct implements Request interface
+//
+// RestyRequest is a wrapper around a single Resty request.
+type RestyRequest struct {
+	restyRequest *resty.Request
+}
+
+// Get performs a GET request bound to ctx. Cancelling ctx or letting its deadline pass aborts the request, including pending retries.
+func (r *RestyRequest) Get(ctx context.Context, url string) (*Response, error) {
+	resp, err := r.restyRequest.SetContext(ctx).Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make GET request: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("received error response: %s", resp.Status())
+	}
+
+	return &Response{restyResponse: resp}, nil
+}
+
+func (r *RestyRequest) SetQueryParams(params map[string]string) Request {
+	r.restyRequest.SetQueryParams(params)
+	return r
+}
+
+func (r *RestyRequest) AcceptJSON() Request {
+	r.restyRequest.SetHeader("Accept", "application/json")
+	return r
+}
+
+func (r *RestyRequest) SetQueryString(query string) Request {
+	r.restyRequest.SetQueryString(query)
+	return r
+}
+
+func (r *RestyRequest) SetAuthToken(token string) Request {
+	r.restyRequest.SetAuthToken(token)
+	return r
+}
+
+func (r *RestyRequest) SetOutput(filename string) Request {
+	r.restyRequest.SetOutput(filename)
+	return r
+}
+
+func (r *RestyRequest) SetPathParams(params map[string]string) Request {
+	r.restyRequest.SetPathParams(params)
+	return r
 }
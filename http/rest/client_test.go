@@ -0,0 +1,89 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClientConcurrentRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s %s", r.URL.Path, r.URL.Query().Get("n"))
+	}))
+	defer srv.Close()
+	client := NewClient().SetDefaults().SetBaseURL(srv.URL)
+
+	const callers = 32
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n := fmt.Sprint(i)
+			resp, err := client.NewRequest().
+				SetQueryParams(map[string]string{"n": n}).
+				SetPathParams(map[string]string{"id": n}).
+				Get(context.Background(), "/v1/{id}")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			// Every caller sees its own params, none of another's
+			if want := "/v1/" + n + " " + n; string(resp.Body()) != want {
+				t.Errorf("body = %q, want %q", resp.Body(), want)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestClientCancelledRequests(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+	client := NewClient().SetDefaults().SetBaseURL(srv.URL)
+
+	// Cancelled before it's sent
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.NewRequest().Get(ctx, "/"); !errors.Is(err, context.Canceled) {
+		t.Errorf("request with a cancelled context returned %v, want context.Canceled", err)
+	}
+
+	// Cancelled while in flight, alongside others on the same client; retries are abandoned too
+	ctx, cancel = context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.NewRequest().Get(ctx, "/")
+			errs <- err
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	start := time.Now()
+	cancel()
+	wg.Wait()
+	close(errs)
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("cancelled requests took %v to return", d)
+	}
+	for err := range errs {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("cancelled request returned %v, want context.Canceled", err)
+		}
+	}
+}
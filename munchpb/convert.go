@@ -0,0 +1,234 @@
+package munchpb
+
+import "github.com/tinkershack/meteomunch/plumber"
+
+// FromBaseData converts plumber.BaseData into its protobuf counterpart
+func FromBaseData(d *plumber.BaseData) *BaseData {
+	if d == nil {
+		return nil
+	}
+	return &BaseData{
+		Latitude:             d.Latitude,
+		Longitude:            d.Longitude,
+		UtcOffsetSeconds:     int32(d.UTCOffsetSeconds),
+		Timezone:             d.Timezone,
+		TimezoneAbbreviation: d.TimezoneAbbreviation,
+		Elevation:            d.Elevation,
+		Current:              FromCurrentData(&d.Current),
+		Hourly:               FromHourlyData(&d.Hourly),
+		Daily:                FromDailyData(&d.Daily),
+		AirQuality:           FromAirQualityData(d.AirQuality),
+		Marine:               FromMarineData(d.Marine),
+		Units:                d.Units,
+	}
+}
+
+// FromCurrentData converts plumber.CurrentData into its protobuf counterpart
+func FromCurrentData(d *plumber.CurrentData) *CurrentData {
+	return &CurrentData{
+		Time:                d.Time,
+		Interval:            int32(d.Interval),
+		Temperature_2M:      d.Temperature2M,
+		RelativeHumidity_2M: int32(d.RelativeHumidity2M),
+		ApparentTemperature: d.ApparentTemperature,
+		IsDay:               int32(d.IsDay),
+		Precipitation:       d.Precipitation,
+		Rain:                d.Rain,
+		Showers:             d.Showers,
+		Snowfall:            d.Snowfall,
+		WeatherCode:         int32(d.WeatherCode),
+		CloudCover:          int32(d.CloudCover),
+		PressureMsl:         d.PressureMSL,
+		SurfacePressure:     d.SurfacePressure,
+		WindSpeed_10M:       d.WindSpeed10M,
+		WindDirection_10M:   int32(d.WindDirection10M),
+		WindGusts_10M:       d.WindGusts10M,
+	}
+}
+
+// FromHourlyData converts plumber.HourlyData into its protobuf counterpart
+func FromHourlyData(d *plumber.HourlyData) *HourlyData {
+	return &HourlyData{
+		Time:                             d.Time,
+		Temperature_2M:                   d.Temperature2M,
+		RelativeHumidity_2M:              int32s(d.RelativeHumidity2M),
+		DewPoint_2M:                      d.DewPoint2M,
+		ApparentTemperature:              d.ApparentTemperature,
+		PrecipitationProbability:         int32s(d.PrecipitationProbability),
+		Precipitation:                    d.Precipitation,
+		WeatherCode:                      int32s(d.WeatherCode),
+		PressureMsl:                      d.PressureMSL,
+		SurfacePressure:                  d.SurfacePressure,
+		CloudCover:                       int32s(d.CloudCover),
+		CloudCoverLow:                    int32s(d.CloudCoverLow),
+		CloudCoverMid:                    int32s(d.CloudCoverMid),
+		CloudCoverHigh:                   int32s(d.CloudCoverHigh),
+		Visibility:                       d.Visibility,
+		Evapotranspiration:               d.Evapotranspiration,
+		Et0FaoEvapotranspiration:         d.ET0FAOEvapotranspiration,
+		VapourPressureDeficit:            d.VapourPressureDeficit,
+		WindSpeed_10M:                    d.WindSpeed10M,
+		WindSpeed_80M:                    d.WindSpeed80M,
+		WindSpeed_120M:                   d.WindSpeed120M,
+		WindSpeed_180M:                   d.WindSpeed180M,
+		WindDirection_10M:                int32s(d.WindDirection10M),
+		WindDirection_80M:                int32s(d.WindDirection80M),
+		WindDirection_120M:               int32s(d.WindDirection120M),
+		WindDirection_180M:               int32s(d.WindDirection180M),
+		WindGusts_10M:                    d.WindGusts10M,
+		Temperature_80M:                  d.Temperature80M,
+		Temperature_120M:                 d.Temperature120M,
+		Temperature_180M:                 d.Temperature180M,
+		UvIndex:                          d.UVIndex,
+		UvIndexClearSky:                  d.UVIndexClearSky,
+		IsDay:                            int32s(d.IsDay),
+		SunshineDuration:                 d.SunshineDuration,
+		TotalColumnIntegratedWaterVapour: d.TotalColumnIntegratedWaterVapour,
+		Cape:                             d.Cape,
+		LiftedIndex:                      d.LiftedIndex,
+		ConvectiveInhibition:             d.ConvectiveInhibition,
+		FreezingLevelHeight:              d.FreezingLevelHeight,
+		BoundaryLayerHeight:              d.BoundaryLayerHeight,
+		Temperature_1000Hpa:              d.Temperature1000hPa,
+		Temperature_975Hpa:               d.Temperature975hPa,
+		Temperature_950Hpa:               d.Temperature950hPa,
+		Temperature_925Hpa:               d.Temperature925hPa,
+		Temperature_900Hpa:               d.Temperature900hPa,
+		Temperature_850Hpa:               d.Temperature850hPa,
+		Temperature_800Hpa:               d.Temperature800hPa,
+		Temperature_700Hpa:               d.Temperature700hPa,
+		Temperature_600Hpa:               d.Temperature600hPa,
+		Temperature_500Hpa:               d.Temperature500hPa,
+		Temperature_400Hpa:               d.Temperature400hPa,
+		RelativeHumidity_1000Hpa:         int32s(d.RelativeHumidity1000hPa),
+		RelativeHumidity_975Hpa:          int32s(d.RelativeHumidity975hPa),
+		RelativeHumidity_950Hpa:          int32s(d.RelativeHumidity950hPa),
+		RelativeHumidity_925Hpa:          int32s(d.RelativeHumidity925hPa),
+		RelativeHumidity_900Hpa:          int32s(d.RelativeHumidity900hPa),
+		RelativeHumidity_850Hpa:          int32s(d.RelativeHumidity850hPa),
+		RelativeHumidity_800Hpa:          int32s(d.RelativeHumidity800hPa),
+		RelativeHumidity_700Hpa:          int32s(d.RelativeHumidity700hPa),
+		RelativeHumidity_600Hpa:          int32s(d.RelativeHumidity600hPa),
+		RelativeHumidity_500Hpa:          int32s(d.RelativeHumidity500hPa),
+		RelativeHumidity_400Hpa:          int32s(d.RelativeHumidity400hPa),
+		CloudCover_1000Hpa:               int32s(d.CloudCover1000hPa),
+		CloudCover_975Hpa:                int32s(d.CloudCover975hPa),
+		CloudCover_950Hpa:                int32s(d.CloudCover950hPa),
+		CloudCover_925Hpa:                int32s(d.CloudCover925hPa),
+		CloudCover_900Hpa:                int32s(d.CloudCover900hPa),
+		CloudCover_850Hpa:                int32s(d.CloudCover850hPa),
+		CloudCover_800Hpa:                int32s(d.CloudCover800hPa),
+		CloudCover_700Hpa:                int32s(d.CloudCover700hPa),
+		CloudCover_600Hpa:                int32s(d.CloudCover600hPa),
+		CloudCover_500Hpa:                int32s(d.CloudCover500hPa),
+		CloudCover_400Hpa:                int32s(d.CloudCover400hPa),
+		WindSpeed_1000Hpa:                d.WindSpeed1000hPa,
+		WindSpeed_975Hpa:                 d.WindSpeed975hPa,
+		WindSpeed_950Hpa:                 d.WindSpeed950hPa,
+		WindSpeed_925Hpa:                 d.WindSpeed925hPa,
+		WindSpeed_900Hpa:                 d.WindSpeed900hPa,
+		WindSpeed_850Hpa:                 d.WindSpeed850hPa,
+		WindSpeed_800Hpa:                 d.WindSpeed800hPa,
+		WindSpeed_700Hpa:                 d.WindSpeed700hPa,
+		WindSpeed_600Hpa:                 d.WindSpeed600hPa,
+		WindSpeed_500Hpa:                 d.WindSpeed500hPa,
+		WindSpeed_400Hpa:                 d.WindSpeed400hPa,
+		WindDirection_1000Hpa:            int32s(d.WindDirection1000hPa),
+		WindDirection_975Hpa:             int32s(d.WindDirection975hPa),
+		WindDirection_950Hpa:             int32s(d.WindDirection950hPa),
+		WindDirection_925Hpa:             int32s(d.WindDirection925hPa),
+		WindDirection_900Hpa:             int32s(d.WindDirection900hPa),
+		WindDirection_850Hpa:             int32s(d.WindDirection850hPa),
+		WindDirection_800Hpa:             int32s(d.WindDirection800hPa),
+		WindDirection_700Hpa:             int32s(d.WindDirection700hPa),
+		WindDirection_600Hpa:             int32s(d.WindDirection600hPa),
+		WindDirection_500Hpa:             int32s(d.WindDirection500hPa),
+		WindDirection_400Hpa:             int32s(d.WindDirection400hPa),
+		GeopotentialHeight_1000Hpa:       d.GeopotentialHeight1000hPa,
+		GeopotentialHeight_975Hpa:        d.GeopotentialHeight975hPa,
+		GeopotentialHeight_950Hpa:        d.GeopotentialHeight950hPa,
+		GeopotentialHeight_925Hpa:        d.GeopotentialHeight925hPa,
+		GeopotentialHeight_900Hpa:        d.GeopotentialHeight900hPa,
+		GeopotentialHeight_850Hpa:        d.GeopotentialHeight850hPa,
+		GeopotentialHeight_800Hpa:        d.GeopotentialHeight800hPa,
+		GeopotentialHeight_700Hpa:        d.GeopotentialHeight700hPa,
+		GeopotentialHeight_600Hpa:        d.GeopotentialHeight600hPa,
+		GeopotentialHeight_500Hpa:        d.GeopotentialHeight500hPa,
+		GeopotentialHeight_400Hpa:        d.GeopotentialHeight400hPa,
+	}
+}
+
+// FromDailyData converts plumber.DailyData into its protobuf counterpart
+func FromDailyData(d *plumber.DailyData) *DailyData {
+	return &DailyData{
+		Time:                        d.Time,
+		WeatherCode:                 int32s(d.WeatherCode),
+		Temperature_2MMax:           d.Temperature2MMax,
+		Temperature_2MMin:           d.Temperature2MMin,
+		ApparentTemperatureMax:      d.ApparentTemperatureMax,
+		ApparentTemperatureMin:      d.ApparentTemperatureMin,
+		Sunrise:                     d.Sunrise,
+		Sunset:                      d.Sunset,
+		DaylightDuration:            d.DaylightDuration,
+		SunshineDuration:            d.SunshineDuration,
+		UvIndexMax:                  d.UVIndexMax,
+		UvIndexClearSkyMax:          d.UVIndexClearSkyMax,
+		PrecipitationSum:            d.PrecipitationSum,
+		PrecipitationHours:          d.PrecipitationHours,
+		PrecipitationProbabilityMax: int32s(d.PrecipitationProbabilityMax),
+		WindSpeed_10MMax:            d.WindSpeed10MMax,
+		WindGusts_10MMax:            d.WindGusts10MMax,
+		WindDirection_10MDominant:   int32s(d.WindDirection10MDominant),
+		ShortwaveRadiationSum:       d.ShortwaveRadiationSum,
+		Et0FaoEvapotranspiration:    d.ET0FAOEvapotranspiration,
+	}
+}
+
+// FromAirQualityData converts plumber.AirQualityData into its protobuf counterpart
+func FromAirQualityData(d *plumber.AirQualityData) *AirQualityData {
+	if d == nil {
+		return nil
+	}
+	return &AirQualityData{
+		Time:                d.Time,
+		Pm2_5:               d.PM2_5,
+		Pm10:                d.PM10,
+		Dust:                d.Dust,
+		AerosolOpticalDepth: d.AerosolOpticalDepth,
+		Ozone:               d.Ozone,
+		EuropeanAqi:         int32s(d.EuropeanAQI),
+		UsAqi:               int32s(d.USAQI),
+	}
+}
+
+// FromMarineData converts plumber.MarineData into its protobuf counterpart
+func FromMarineData(d *plumber.MarineData) *MarineData {
+	if d == nil {
+		return nil
+	}
+	return &MarineData{
+		Time:                  d.Time,
+		WaveHeight:            d.WaveHeight,
+		WaveDirection:         int32s(d.WaveDirection),
+		WavePeriod:            d.WavePeriod,
+		WindWaveHeight:        d.WindWaveHeight,
+		WindWaveDirection:     int32s(d.WindWaveDirection),
+		WindWavePeriod:        d.WindWavePeriod,
+		SwellWaveHeight:       d.SwellWaveHeight,
+		SwellWaveDirection:    int32s(d.SwellWaveDirection),
+		SwellWavePeriod:       d.SwellWavePeriod,
+		SeaSurfaceTemperature: d.SeaSurfaceTemperature,
+	}
+}
+
+// int32s narrows plumber's int slices to the int32 used on the wire
+func int32s(s []int) []int32 {
+	if s == nil {
+		return nil
+	}
+	out := make([]int32, len(s))
+	for i, v := range s {
+		out[i] = int32(v)
+	}
+	return out
+}
@@ -0,0 +1,35 @@
+package munchpb
+
+import (
+	"testing"
+
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+func TestFromBaseDataBlocks(t *testing.T) {
+	d := &plumber.BaseData{
+		Hourly:     plumber.HourlyData{Time: []int64{0, 3600}},
+		AirQuality: &plumber.AirQualityData{Time: []int64{0, 3600}, PM2_5: []float64{8.1, 9.4}, EuropeanAQI: []int{21, 24}},
+		Marine:     &plumber.MarineData{Time: []int64{0, 3600}, WaveHeight: []float64{1.2, 1.4}, WaveDirection: []int{270, 275}},
+		Units:      map[string]string{"speed": "kn"},
+	}
+	pb := FromBaseData(d)
+	if got := pb.GetAirQuality().GetPm2_5(); len(got) != 2 || got[1] != 9.4 {
+		t.Errorf("air quality pm2_5 = %v", got)
+	}
+	if got := pb.GetAirQuality().GetEuropeanAqi(); len(got) != 2 || got[0] != 21 {
+		t.Errorf("air quality european_aqi = %v", got)
+	}
+	if got := pb.GetMarine().GetWaveDirection(); len(got) != 2 || got[1] != 275 {
+		t.Errorf("marine wave_direction = %v", got)
+	}
+	if got := pb.GetUnits()["speed"]; got != "kn" {
+		t.Errorf("units speed = %q, want kn", got)
+	}
+
+	// Blocks that weren't fetched stay unset
+	pb = FromBaseData(&plumber.BaseData{})
+	if pb.AirQuality != nil || pb.Marine != nil || pb.Units != nil {
+		t.Errorf("unfetched blocks are set: %v %v %v", pb.AirQuality, pb.Marine, pb.Units)
+	}
+}
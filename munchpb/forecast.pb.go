@@ -0,0 +1,2682 @@
+// Protobuf schema for the munch gRPC transport.
+//
+// The data messages mirror the plumber package structures field for field, and
+// the field names match their JSON tags so that both transports read the same.
+// Regenerate the Go code with `go generate ./munchpb` after editing this file.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.1
+// 	protoc        (unknown)
+// source: forecast.proto
+
+package munchpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Coordinates struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Latitude  float64 `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`   // in degrees
+	Longitude float64 `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"` // in degrees
+}
+
+func (x *Coordinates) Reset() {
+	*x = Coordinates{}
+	mi := &file_forecast_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Coordinates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Coordinates) ProtoMessage() {}
+
+func (x *Coordinates) ProtoReflect() protoreflect.Message {
+	mi := &file_forecast_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Coordinates.ProtoReflect.Descriptor instead.
+func (*Coordinates) Descriptor() ([]byte, []int) {
+	return file_forecast_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Coordinates) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Coordinates) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+type ForecastRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Coordinates *Coordinates `protobuf:"bytes,1,opt,name=coordinates,proto3" json:"coordinates,omitempty"`
+	Provider    string       `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"` // Provider name as in config.MeteoProviders, defaults to open-meteo
+	Units       string       `protobuf:"bytes,3,opt,name=units,proto3" json:"units,omitempty"`       // Unit system of the values: metric, the default, imperial, si or aviation
+	// Fields to keep, e.g. hourly.temperature_2m, or whole blocks like marine; everything if empty
+	Fields     []string      `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty"`
+	Options    *FetchOptions `protobuf:"bytes,5,opt,name=options,proto3" json:"options,omitempty"`                          // Override the options configured for the provider
+	AirQuality bool          `protobuf:"varint,6,opt,name=air_quality,json=airQuality,proto3" json:"air_quality,omitempty"` // Adds the air quality of the same hours, as does selecting air_quality fields
+}
+
+func (x *ForecastRequest) Reset() {
+	*x = ForecastRequest{}
+	mi := &file_forecast_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForecastRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForecastRequest) ProtoMessage() {}
+
+func (x *ForecastRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_forecast_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForecastRequest.ProtoReflect.Descriptor instead.
+func (*ForecastRequest) Descriptor() ([]byte, []int) {
+	return file_forecast_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ForecastRequest) GetCoordinates() *Coordinates {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+func (x *ForecastRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *ForecastRequest) GetUnits() string {
+	if x != nil {
+		return x.Units
+	}
+	return ""
+}
+
+func (x *ForecastRequest) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *ForecastRequest) GetOptions() *FetchOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *ForecastRequest) GetAirQuality() bool {
+	if x != nil {
+		return x.AirQuality
+	}
+	return false
+}
+
+type StreamForecastsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Coordinates []*Coordinates `protobuf:"bytes,1,rep,name=coordinates,proto3" json:"coordinates,omitempty"`
+	Provider    string         `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"` // Provider name as in config.MeteoProviders, defaults to open-meteo
+	Units       string         `protobuf:"bytes,3,opt,name=units,proto3" json:"units,omitempty"`       // As in ForecastRequest, for every location
+	Fields      []string       `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty"`
+	Options     *FetchOptions  `protobuf:"bytes,5,opt,name=options,proto3" json:"options,omitempty"`
+	AirQuality  bool           `protobuf:"varint,6,opt,name=air_quality,json=airQuality,proto3" json:"air_quality,omitempty"`
+}
+
+func (x *StreamForecastsRequest) Reset() {
+	*x = StreamForecastsRequest{}
+	mi := &file_forecast_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamForecastsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamForecastsRequest) ProtoMessage() {}
+
+func (x *StreamForecastsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_forecast_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamForecastsRequest.ProtoReflect.Descriptor instead.
+func (*StreamForecastsRequest) Descriptor() ([]byte, []int) {
+	return file_forecast_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StreamForecastsRequest) GetCoordinates() []*Coordinates {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+func (x *StreamForecastsRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *StreamForecastsRequest) GetUnits() string {
+	if x != nil {
+		return x.Units
+	}
+	return ""
+}
+
+func (x *StreamForecastsRequest) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *StreamForecastsRequest) GetOptions() *FetchOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *StreamForecastsRequest) GetAirQuality() bool {
+	if x != nil {
+		return x.AirQuality
+	}
+	return false
+}
+
+// FetchOptions mirror providers.Options; zero values leave the configured option in place
+type FetchOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Model         string `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"` // Weather model, e.g. icon_seamless
+	ForecastDays  int32  `protobuf:"varint,2,opt,name=forecast_days,json=forecastDays,proto3" json:"forecast_days,omitempty"`
+	ForecastHours int32  `protobuf:"varint,3,opt,name=forecast_hours,json=forecastHours,proto3" json:"forecast_hours,omitempty"` // Hours of hourly forecast from the current hour
+	PastDays      int32  `protobuf:"varint,4,opt,name=past_days,json=pastDays,proto3" json:"past_days,omitempty"`                // Days before today to include
+	CellSelection string `protobuf:"bytes,5,opt,name=cell_selection,json=cellSelection,proto3" json:"cell_selection,omitempty"`  // Grid cell to pick: land, sea or nearest
+	Timezone      string `protobuf:"bytes,6,opt,name=timezone,proto3" json:"timezone,omitempty"`                                 // Time zone the days are aligned to, or auto
+	StartDate     string `protobuf:"bytes,7,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`              // First day of a range to fetch instead of the forecast, e.g. 2020-01-01
+	EndDate       string `protobuf:"bytes,8,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`                    // Last day of the range, inclusive
+}
+
+func (x *FetchOptions) Reset() {
+	*x = FetchOptions{}
+	mi := &file_forecast_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchOptions) ProtoMessage() {}
+
+func (x *FetchOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_forecast_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchOptions.ProtoReflect.Descriptor instead.
+func (*FetchOptions) Descriptor() ([]byte, []int) {
+	return file_forecast_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FetchOptions) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *FetchOptions) GetForecastDays() int32 {
+	if x != nil {
+		return x.ForecastDays
+	}
+	return 0
+}
+
+func (x *FetchOptions) GetForecastHours() int32 {
+	if x != nil {
+		return x.ForecastHours
+	}
+	return 0
+}
+
+func (x *FetchOptions) GetPastDays() int32 {
+	if x != nil {
+		return x.PastDays
+	}
+	return 0
+}
+
+func (x *FetchOptions) GetCellSelection() string {
+	if x != nil {
+		return x.CellSelection
+	}
+	return ""
+}
+
+func (x *FetchOptions) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *FetchOptions) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *FetchOptions) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+type ForecastResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Coordinates *Coordinates `protobuf:"bytes,1,opt,name=coordinates,proto3" json:"coordinates,omitempty"` // Coordinates as requested, before the provider snaps them to its grid
+	Provider    string       `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"`
+	Data        *BaseData    `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *ForecastResponse) Reset() {
+	*x = ForecastResponse{}
+	mi := &file_forecast_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForecastResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForecastResponse) ProtoMessage() {}
+
+func (x *ForecastResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_forecast_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForecastResponse.ProtoReflect.Descriptor instead.
+func (*ForecastResponse) Descriptor() ([]byte, []int) {
+	return file_forecast_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ForecastResponse) GetCoordinates() *Coordinates {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+func (x *ForecastResponse) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *ForecastResponse) GetData() *BaseData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type BaseData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Latitude             float64           `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude            float64           `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	UtcOffsetSeconds     int32             `protobuf:"varint,3,opt,name=utc_offset_seconds,json=utcOffsetSeconds,proto3" json:"utc_offset_seconds,omitempty"`
+	Timezone             string            `protobuf:"bytes,4,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	TimezoneAbbreviation string            `protobuf:"bytes,5,opt,name=timezone_abbreviation,json=timezoneAbbreviation,proto3" json:"timezone_abbreviation,omitempty"`
+	Elevation            float64           `protobuf:"fixed64,6,opt,name=elevation,proto3" json:"elevation,omitempty"`
+	Current              *CurrentData      `protobuf:"bytes,7,opt,name=current,proto3" json:"current,omitempty"`
+	Hourly               *HourlyData       `protobuf:"bytes,8,opt,name=hourly,proto3" json:"hourly,omitempty"`
+	Daily                *DailyData        `protobuf:"bytes,9,opt,name=daily,proto3" json:"daily,omitempty"`
+	AirQuality           *AirQualityData   `protobuf:"bytes,10,opt,name=air_quality,json=airQuality,proto3" json:"air_quality,omitempty"`                                                             // Hourly air quality, aligned with the hourly time axis, if asked for
+	Marine               *MarineData       `protobuf:"bytes,11,opt,name=marine,proto3" json:"marine,omitempty"`                                                                                       // Hourly sea state, as fetched by the marine providers
+	Units                map[string]string `protobuf:"bytes,12,rep,name=units,proto3" json:"units,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Units of the values per quantity, e.g. "speed": "kn"; empty means the common units
+}
+
+func (x *BaseData) Reset() {
+	*x = BaseData{}
+	mi := &file_forecast_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BaseData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BaseData) ProtoMessage() {}
+
+func (x *BaseData) ProtoReflect() protoreflect.Message {
+	mi := &file_forecast_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BaseData.ProtoReflect.Descriptor instead.
+func (*BaseData) Descriptor() ([]byte, []int) {
+	return file_forecast_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BaseData) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *BaseData) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *BaseData) GetUtcOffsetSeconds() int32 {
+	if x != nil {
+		return x.UtcOffsetSeconds
+	}
+	return 0
+}
+
+func (x *BaseData) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *BaseData) GetTimezoneAbbreviation() string {
+	if x != nil {
+		return x.TimezoneAbbreviation
+	}
+	return ""
+}
+
+func (x *BaseData) GetElevation() float64 {
+	if x != nil {
+		return x.Elevation
+	}
+	return 0
+}
+
+func (x *BaseData) GetCurrent() *CurrentData {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *BaseData) GetHourly() *HourlyData {
+	if x != nil {
+		return x.Hourly
+	}
+	return nil
+}
+
+func (x *BaseData) GetDaily() *DailyData {
+	if x != nil {
+		return x.Daily
+	}
+	return nil
+}
+
+func (x *BaseData) GetAirQuality() *AirQualityData {
+	if x != nil {
+		return x.AirQuality
+	}
+	return nil
+}
+
+func (x *BaseData) GetMarine() *MarineData {
+	if x != nil {
+		return x.Marine
+	}
+	return nil
+}
+
+func (x *BaseData) GetUnits() map[string]string {
+	if x != nil {
+		return x.Units
+	}
+	return nil
+}
+
+type CurrentData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time                int64   `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`                                                           // Unix timestamp
+	Interval            int32   `protobuf:"varint,2,opt,name=interval,proto3" json:"interval,omitempty"`                                                   // Interval in seconds for the data
+	Temperature_2M      float64 `protobuf:"fixed64,3,opt,name=temperature_2m,json=temperature2m,proto3" json:"temperature_2m,omitempty"`                   // Temperature at 2 meters above ground in °C
+	RelativeHumidity_2M int32   `protobuf:"varint,4,opt,name=relative_humidity_2m,json=relativeHumidity2m,proto3" json:"relative_humidity_2m,omitempty"`   // Relative humidity at 2 meters above ground in %
+	ApparentTemperature float64 `protobuf:"fixed64,5,opt,name=apparent_temperature,json=apparentTemperature,proto3" json:"apparent_temperature,omitempty"` // Apparent temperature in °C
+	IsDay               int32   `protobuf:"varint,6,opt,name=is_day,json=isDay,proto3" json:"is_day,omitempty"`                                            // 1 if it is day, 0 if it is night
+	Precipitation       float64 `protobuf:"fixed64,7,opt,name=precipitation,proto3" json:"precipitation,omitempty"`                                        // Precipitation in mm
+	Rain                float64 `protobuf:"fixed64,8,opt,name=rain,proto3" json:"rain,omitempty"`                                                          // Rain in mm
+	Showers             float64 `protobuf:"fixed64,9,opt,name=showers,proto3" json:"showers,omitempty"`                                                    // Showers in mm
+	Snowfall            float64 `protobuf:"fixed64,10,opt,name=snowfall,proto3" json:"snowfall,omitempty"`                                                 // Snowfall in cm
+	WeatherCode         int32   `protobuf:"varint,11,opt,name=weather_code,json=weatherCode,proto3" json:"weather_code,omitempty"`                         // Weather code according to WMO
+	CloudCover          int32   `protobuf:"varint,12,opt,name=cloud_cover,json=cloudCover,proto3" json:"cloud_cover,omitempty"`                            // Cloud cover in %
+	PressureMsl         float64 `protobuf:"fixed64,13,opt,name=pressure_msl,json=pressureMsl,proto3" json:"pressure_msl,omitempty"`                        // Mean sea level pressure in hPa
+	SurfacePressure     float64 `protobuf:"fixed64,14,opt,name=surface_pressure,json=surfacePressure,proto3" json:"surface_pressure,omitempty"`            // Surface pressure in hPa
+	WindSpeed_10M       float64 `protobuf:"fixed64,15,opt,name=wind_speed_10m,json=windSpeed10m,proto3" json:"wind_speed_10m,omitempty"`                   // Wind speed at 10 meters above ground in km/h
+	WindDirection_10M   int32   `protobuf:"varint,16,opt,name=wind_direction_10m,json=windDirection10m,proto3" json:"wind_direction_10m,omitempty"`        // Wind direction at 10 meters above ground in degrees
+	WindGusts_10M       float64 `protobuf:"fixed64,17,opt,name=wind_gusts_10m,json=windGusts10m,proto3" json:"wind_gusts_10m,omitempty"`                   // Wind gusts at 10 meters above ground in km/h
+}
+
+func (x *CurrentData) Reset() {
+	*x = CurrentData{}
+	mi := &file_forecast_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CurrentData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CurrentData) ProtoMessage() {}
+
+func (x *CurrentData) ProtoReflect() protoreflect.Message {
+	mi := &file_forecast_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CurrentData.ProtoReflect.Descriptor instead.
+func (*CurrentData) Descriptor() ([]byte, []int) {
+	return file_forecast_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CurrentData) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *CurrentData) GetInterval() int32 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+func (x *CurrentData) GetTemperature_2M() float64 {
+	if x != nil {
+		return x.Temperature_2M
+	}
+	return 0
+}
+
+func (x *CurrentData) GetRelativeHumidity_2M() int32 {
+	if x != nil {
+		return x.RelativeHumidity_2M
+	}
+	return 0
+}
+
+func (x *CurrentData) GetApparentTemperature() float64 {
+	if x != nil {
+		return x.ApparentTemperature
+	}
+	return 0
+}
+
+func (x *CurrentData) GetIsDay() int32 {
+	if x != nil {
+		return x.IsDay
+	}
+	return 0
+}
+
+func (x *CurrentData) GetPrecipitation() float64 {
+	if x != nil {
+		return x.Precipitation
+	}
+	return 0
+}
+
+func (x *CurrentData) GetRain() float64 {
+	if x != nil {
+		return x.Rain
+	}
+	return 0
+}
+
+func (x *CurrentData) GetShowers() float64 {
+	if x != nil {
+		return x.Showers
+	}
+	return 0
+}
+
+func (x *CurrentData) GetSnowfall() float64 {
+	if x != nil {
+		return x.Snowfall
+	}
+	return 0
+}
+
+func (x *CurrentData) GetWeatherCode() int32 {
+	if x != nil {
+		return x.WeatherCode
+	}
+	return 0
+}
+
+func (x *CurrentData) GetCloudCover() int32 {
+	if x != nil {
+		return x.CloudCover
+	}
+	return 0
+}
+
+func (x *CurrentData) GetPressureMsl() float64 {
+	if x != nil {
+		return x.PressureMsl
+	}
+	return 0
+}
+
+func (x *CurrentData) GetSurfacePressure() float64 {
+	if x != nil {
+		return x.SurfacePressure
+	}
+	return 0
+}
+
+func (x *CurrentData) GetWindSpeed_10M() float64 {
+	if x != nil {
+		return x.WindSpeed_10M
+	}
+	return 0
+}
+
+func (x *CurrentData) GetWindDirection_10M() int32 {
+	if x != nil {
+		return x.WindDirection_10M
+	}
+	return 0
+}
+
+func (x *CurrentData) GetWindGusts_10M() float64 {
+	if x != nil {
+		return x.WindGusts_10M
+	}
+	return 0
+}
+
+type HourlyData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time                             []int64   `protobuf:"varint,1,rep,packed,name=time,proto3" json:"time,omitempty"` // Time intervals for which rest of the array fields' values are populated
+	Temperature_2M                   []float64 `protobuf:"fixed64,2,rep,packed,name=temperature_2m,json=temperature2m,proto3" json:"temperature_2m,omitempty"`
+	RelativeHumidity_2M              []int32   `protobuf:"varint,3,rep,packed,name=relative_humidity_2m,json=relativeHumidity2m,proto3" json:"relative_humidity_2m,omitempty"`
+	DewPoint_2M                      []float64 `protobuf:"fixed64,4,rep,packed,name=dew_point_2m,json=dewPoint2m,proto3" json:"dew_point_2m,omitempty"`
+	ApparentTemperature              []float64 `protobuf:"fixed64,5,rep,packed,name=apparent_temperature,json=apparentTemperature,proto3" json:"apparent_temperature,omitempty"`
+	PrecipitationProbability         []int32   `protobuf:"varint,6,rep,packed,name=precipitation_probability,json=precipitationProbability,proto3" json:"precipitation_probability,omitempty"`
+	Precipitation                    []float64 `protobuf:"fixed64,7,rep,packed,name=precipitation,proto3" json:"precipitation,omitempty"`
+	WeatherCode                      []int32   `protobuf:"varint,8,rep,packed,name=weather_code,json=weatherCode,proto3" json:"weather_code,omitempty"`
+	PressureMsl                      []float64 `protobuf:"fixed64,9,rep,packed,name=pressure_msl,json=pressureMsl,proto3" json:"pressure_msl,omitempty"`
+	SurfacePressure                  []float64 `protobuf:"fixed64,10,rep,packed,name=surface_pressure,json=surfacePressure,proto3" json:"surface_pressure,omitempty"`
+	CloudCover                       []int32   `protobuf:"varint,11,rep,packed,name=cloud_cover,json=cloudCover,proto3" json:"cloud_cover,omitempty"`
+	CloudCoverLow                    []int32   `protobuf:"varint,12,rep,packed,name=cloud_cover_low,json=cloudCoverLow,proto3" json:"cloud_cover_low,omitempty"`
+	CloudCoverMid                    []int32   `protobuf:"varint,13,rep,packed,name=cloud_cover_mid,json=cloudCoverMid,proto3" json:"cloud_cover_mid,omitempty"`
+	CloudCoverHigh                   []int32   `protobuf:"varint,14,rep,packed,name=cloud_cover_high,json=cloudCoverHigh,proto3" json:"cloud_cover_high,omitempty"`
+	Visibility                       []float64 `protobuf:"fixed64,15,rep,packed,name=visibility,proto3" json:"visibility,omitempty"`
+	Evapotranspiration               []float64 `protobuf:"fixed64,16,rep,packed,name=evapotranspiration,proto3" json:"evapotranspiration,omitempty"`
+	Et0FaoEvapotranspiration         []float64 `protobuf:"fixed64,17,rep,packed,name=et0_fao_evapotranspiration,json=et0FaoEvapotranspiration,proto3" json:"et0_fao_evapotranspiration,omitempty"`
+	VapourPressureDeficit            []float64 `protobuf:"fixed64,18,rep,packed,name=vapour_pressure_deficit,json=vapourPressureDeficit,proto3" json:"vapour_pressure_deficit,omitempty"`
+	WindSpeed_10M                    []float64 `protobuf:"fixed64,19,rep,packed,name=wind_speed_10m,json=windSpeed10m,proto3" json:"wind_speed_10m,omitempty"`
+	WindSpeed_80M                    []float64 `protobuf:"fixed64,20,rep,packed,name=wind_speed_80m,json=windSpeed80m,proto3" json:"wind_speed_80m,omitempty"`
+	WindSpeed_120M                   []float64 `protobuf:"fixed64,21,rep,packed,name=wind_speed_120m,json=windSpeed120m,proto3" json:"wind_speed_120m,omitempty"`
+	WindSpeed_180M                   []float64 `protobuf:"fixed64,22,rep,packed,name=wind_speed_180m,json=windSpeed180m,proto3" json:"wind_speed_180m,omitempty"`
+	WindDirection_10M                []int32   `protobuf:"varint,23,rep,packed,name=wind_direction_10m,json=windDirection10m,proto3" json:"wind_direction_10m,omitempty"`
+	WindDirection_80M                []int32   `protobuf:"varint,24,rep,packed,name=wind_direction_80m,json=windDirection80m,proto3" json:"wind_direction_80m,omitempty"`
+	WindDirection_120M               []int32   `protobuf:"varint,25,rep,packed,name=wind_direction_120m,json=windDirection120m,proto3" json:"wind_direction_120m,omitempty"`
+	WindDirection_180M               []int32   `protobuf:"varint,26,rep,packed,name=wind_direction_180m,json=windDirection180m,proto3" json:"wind_direction_180m,omitempty"`
+	WindGusts_10M                    []float64 `protobuf:"fixed64,27,rep,packed,name=wind_gusts_10m,json=windGusts10m,proto3" json:"wind_gusts_10m,omitempty"`
+	Temperature_80M                  []float64 `protobuf:"fixed64,28,rep,packed,name=temperature_80m,json=temperature80m,proto3" json:"temperature_80m,omitempty"`
+	Temperature_120M                 []float64 `protobuf:"fixed64,29,rep,packed,name=temperature_120m,json=temperature120m,proto3" json:"temperature_120m,omitempty"`
+	Temperature_180M                 []float64 `protobuf:"fixed64,30,rep,packed,name=temperature_180m,json=temperature180m,proto3" json:"temperature_180m,omitempty"`
+	UvIndex                          []float64 `protobuf:"fixed64,31,rep,packed,name=uv_index,json=uvIndex,proto3" json:"uv_index,omitempty"`
+	UvIndexClearSky                  []float64 `protobuf:"fixed64,32,rep,packed,name=uv_index_clear_sky,json=uvIndexClearSky,proto3" json:"uv_index_clear_sky,omitempty"`
+	IsDay                            []int32   `protobuf:"varint,33,rep,packed,name=is_day,json=isDay,proto3" json:"is_day,omitempty"`
+	SunshineDuration                 []float64 `protobuf:"fixed64,34,rep,packed,name=sunshine_duration,json=sunshineDuration,proto3" json:"sunshine_duration,omitempty"`
+	TotalColumnIntegratedWaterVapour []float64 `protobuf:"fixed64,35,rep,packed,name=total_column_integrated_water_vapour,json=totalColumnIntegratedWaterVapour,proto3" json:"total_column_integrated_water_vapour,omitempty"`
+	Cape                             []float64 `protobuf:"fixed64,36,rep,packed,name=cape,proto3" json:"cape,omitempty"`
+	LiftedIndex                      []float64 `protobuf:"fixed64,37,rep,packed,name=lifted_index,json=liftedIndex,proto3" json:"lifted_index,omitempty"`
+	ConvectiveInhibition             []float64 `protobuf:"fixed64,38,rep,packed,name=convective_inhibition,json=convectiveInhibition,proto3" json:"convective_inhibition,omitempty"`
+	FreezingLevelHeight              []float64 `protobuf:"fixed64,39,rep,packed,name=freezing_level_height,json=freezingLevelHeight,proto3" json:"freezing_level_height,omitempty"`
+	BoundaryLayerHeight              []float64 `protobuf:"fixed64,40,rep,packed,name=boundary_layer_height,json=boundaryLayerHeight,proto3" json:"boundary_layer_height,omitempty"`
+	Temperature_1000Hpa              []float64 `protobuf:"fixed64,41,rep,packed,name=temperature_1000hpa,json=temperature1000hpa,proto3" json:"temperature_1000hpa,omitempty"`
+	Temperature_975Hpa               []float64 `protobuf:"fixed64,42,rep,packed,name=temperature_975hpa,json=temperature975hpa,proto3" json:"temperature_975hpa,omitempty"`
+	Temperature_950Hpa               []float64 `protobuf:"fixed64,43,rep,packed,name=temperature_950hpa,json=temperature950hpa,proto3" json:"temperature_950hpa,omitempty"`
+	Temperature_925Hpa               []float64 `protobuf:"fixed64,44,rep,packed,name=temperature_925hpa,json=temperature925hpa,proto3" json:"temperature_925hpa,omitempty"`
+	Temperature_900Hpa               []float64 `protobuf:"fixed64,45,rep,packed,name=temperature_900hpa,json=temperature900hpa,proto3" json:"temperature_900hpa,omitempty"`
+	Temperature_850Hpa               []float64 `protobuf:"fixed64,46,rep,packed,name=temperature_850hpa,json=temperature850hpa,proto3" json:"temperature_850hpa,omitempty"`
+	Temperature_800Hpa               []float64 `protobuf:"fixed64,47,rep,packed,name=temperature_800hpa,json=temperature800hpa,proto3" json:"temperature_800hpa,omitempty"`
+	Temperature_700Hpa               []float64 `protobuf:"fixed64,48,rep,packed,name=temperature_700hpa,json=temperature700hpa,proto3" json:"temperature_700hpa,omitempty"`
+	Temperature_600Hpa               []float64 `protobuf:"fixed64,49,rep,packed,name=temperature_600hpa,json=temperature600hpa,proto3" json:"temperature_600hpa,omitempty"`
+	Temperature_500Hpa               []float64 `protobuf:"fixed64,50,rep,packed,name=temperature_500hpa,json=temperature500hpa,proto3" json:"temperature_500hpa,omitempty"`
+	Temperature_400Hpa               []float64 `protobuf:"fixed64,51,rep,packed,name=temperature_400hpa,json=temperature400hpa,proto3" json:"temperature_400hpa,omitempty"`
+	RelativeHumidity_1000Hpa         []int32   `protobuf:"varint,52,rep,packed,name=relative_humidity_1000hpa,json=relativeHumidity1000hpa,proto3" json:"relative_humidity_1000hpa,omitempty"`
+	RelativeHumidity_975Hpa          []int32   `protobuf:"varint,53,rep,packed,name=relative_humidity_975hpa,json=relativeHumidity975hpa,proto3" json:"relative_humidity_975hpa,omitempty"`
+	RelativeHumidity_950Hpa          []int32   `protobuf:"varint,54,rep,packed,name=relative_humidity_950hpa,json=relativeHumidity950hpa,proto3" json:"relative_humidity_950hpa,omitempty"`
+	RelativeHumidity_925Hpa          []int32   `protobuf:"varint,55,rep,packed,name=relative_humidity_925hpa,json=relativeHumidity925hpa,proto3" json:"relative_humidity_925hpa,omitempty"`
+	RelativeHumidity_900Hpa          []int32   `protobuf:"varint,56,rep,packed,name=relative_humidity_900hpa,json=relativeHumidity900hpa,proto3" json:"relative_humidity_900hpa,omitempty"`
+	RelativeHumidity_850Hpa          []int32   `protobuf:"varint,57,rep,packed,name=relative_humidity_850hpa,json=relativeHumidity850hpa,proto3" json:"relative_humidity_850hpa,omitempty"`
+	RelativeHumidity_800Hpa          []int32   `protobuf:"varint,58,rep,packed,name=relative_humidity_800hpa,json=relativeHumidity800hpa,proto3" json:"relative_humidity_800hpa,omitempty"`
+	RelativeHumidity_700Hpa          []int32   `protobuf:"varint,59,rep,packed,name=relative_humidity_700hpa,json=relativeHumidity700hpa,proto3" json:"relative_humidity_700hpa,omitempty"`
+	RelativeHumidity_600Hpa          []int32   `protobuf:"varint,60,rep,packed,name=relative_humidity_600hpa,json=relativeHumidity600hpa,proto3" json:"relative_humidity_600hpa,omitempty"`
+	RelativeHumidity_500Hpa          []int32   `protobuf:"varint,61,rep,packed,name=relative_humidity_500hpa,json=relativeHumidity500hpa,proto3" json:"relative_humidity_500hpa,omitempty"`
+	RelativeHumidity_400Hpa          []int32   `protobuf:"varint,62,rep,packed,name=relative_humidity_400hpa,json=relativeHumidity400hpa,proto3" json:"relative_humidity_400hpa,omitempty"`
+	CloudCover_1000Hpa               []int32   `protobuf:"varint,63,rep,packed,name=cloud_cover_1000hpa,json=cloudCover1000hpa,proto3" json:"cloud_cover_1000hpa,omitempty"`
+	CloudCover_975Hpa                []int32   `protobuf:"varint,64,rep,packed,name=cloud_cover_975hpa,json=cloudCover975hpa,proto3" json:"cloud_cover_975hpa,omitempty"`
+	CloudCover_950Hpa                []int32   `protobuf:"varint,65,rep,packed,name=cloud_cover_950hpa,json=cloudCover950hpa,proto3" json:"cloud_cover_950hpa,omitempty"`
+	CloudCover_925Hpa                []int32   `protobuf:"varint,66,rep,packed,name=cloud_cover_925hpa,json=cloudCover925hpa,proto3" json:"cloud_cover_925hpa,omitempty"`
+	CloudCover_900Hpa                []int32   `protobuf:"varint,67,rep,packed,name=cloud_cover_900hpa,json=cloudCover900hpa,proto3" json:"cloud_cover_900hpa,omitempty"`
+	CloudCover_850Hpa                []int32   `protobuf:"varint,68,rep,packed,name=cloud_cover_850hpa,json=cloudCover850hpa,proto3" json:"cloud_cover_850hpa,omitempty"`
+	CloudCover_800Hpa                []int32   `protobuf:"varint,69,rep,packed,name=cloud_cover_800hpa,json=cloudCover800hpa,proto3" json:"cloud_cover_800hpa,omitempty"`
+	CloudCover_700Hpa                []int32   `protobuf:"varint,70,rep,packed,name=cloud_cover_700hpa,json=cloudCover700hpa,proto3" json:"cloud_cover_700hpa,omitempty"`
+	CloudCover_600Hpa                []int32   `protobuf:"varint,71,rep,packed,name=cloud_cover_600hpa,json=cloudCover600hpa,proto3" json:"cloud_cover_600hpa,omitempty"`
+	CloudCover_500Hpa                []int32   `protobuf:"varint,72,rep,packed,name=cloud_cover_500hpa,json=cloudCover500hpa,proto3" json:"cloud_cover_500hpa,omitempty"`
+	CloudCover_400Hpa                []int32   `protobuf:"varint,73,rep,packed,name=cloud_cover_400hpa,json=cloudCover400hpa,proto3" json:"cloud_cover_400hpa,omitempty"`
+	WindSpeed_1000Hpa                []float64 `protobuf:"fixed64,74,rep,packed,name=wind_speed_1000hpa,json=windSpeed1000hpa,proto3" json:"wind_speed_1000hpa,omitempty"`
+	WindSpeed_975Hpa                 []float64 `protobuf:"fixed64,75,rep,packed,name=wind_speed_975hpa,json=windSpeed975hpa,proto3" json:"wind_speed_975hpa,omitempty"`
+	WindSpeed_950Hpa                 []float64 `protobuf:"fixed64,76,rep,packed,name=wind_speed_950hpa,json=windSpeed950hpa,proto3" json:"wind_speed_950hpa,omitempty"`
+	WindSpeed_925Hpa                 []float64 `protobuf:"fixed64,77,rep,packed,name=wind_speed_925hpa,json=windSpeed925hpa,proto3" json:"wind_speed_925hpa,omitempty"`
+	WindSpeed_900Hpa                 []float64 `protobuf:"fixed64,78,rep,packed,name=wind_speed_900hpa,json=windSpeed900hpa,proto3" json:"wind_speed_900hpa,omitempty"`
+	WindSpeed_850Hpa                 []float64 `protobuf:"fixed64,79,rep,packed,name=wind_speed_850hpa,json=windSpeed850hpa,proto3" json:"wind_speed_850hpa,omitempty"`
+	WindSpeed_800Hpa                 []float64 `protobuf:"fixed64,80,rep,packed,name=wind_speed_800hpa,json=windSpeed800hpa,proto3" json:"wind_speed_800hpa,omitempty"`
+	WindSpeed_700Hpa                 []float64 `protobuf:"fixed64,81,rep,packed,name=wind_speed_700hpa,json=windSpeed700hpa,proto3" json:"wind_speed_700hpa,omitempty"`
+	WindSpeed_600Hpa                 []float64 `protobuf:"fixed64,82,rep,packed,name=wind_speed_600hpa,json=windSpeed600hpa,proto3" json:"wind_speed_600hpa,omitempty"`
+	WindSpeed_500Hpa                 []float64 `protobuf:"fixed64,83,rep,packed,name=wind_speed_500hpa,json=windSpeed500hpa,proto3" json:"wind_speed_500hpa,omitempty"`
+	WindSpeed_400Hpa                 []float64 `protobuf:"fixed64,84,rep,packed,name=wind_speed_400hpa,json=windSpeed400hpa,proto3" json:"wind_speed_400hpa,omitempty"`
+	WindDirection_1000Hpa            []int32   `protobuf:"varint,85,rep,packed,name=wind_direction_1000hpa,json=windDirection1000hpa,proto3" json:"wind_direction_1000hpa,omitempty"`
+	WindDirection_975Hpa             []int32   `protobuf:"varint,86,rep,packed,name=wind_direction_975hpa,json=windDirection975hpa,proto3" json:"wind_direction_975hpa,omitempty"`
+	WindDirection_950Hpa             []int32   `protobuf:"varint,87,rep,packed,name=wind_direction_950hpa,json=windDirection950hpa,proto3" json:"wind_direction_950hpa,omitempty"`
+	WindDirection_925Hpa             []int32   `protobuf:"varint,88,rep,packed,name=wind_direction_925hpa,json=windDirection925hpa,proto3" json:"wind_direction_925hpa,omitempty"`
+	WindDirection_900Hpa             []int32   `protobuf:"varint,89,rep,packed,name=wind_direction_900hpa,json=windDirection900hpa,proto3" json:"wind_direction_900hpa,omitempty"`
+	WindDirection_850Hpa             []int32   `protobuf:"varint,90,rep,packed,name=wind_direction_850hpa,json=windDirection850hpa,proto3" json:"wind_direction_850hpa,omitempty"`
+	WindDirection_800Hpa             []int32   `protobuf:"varint,91,rep,packed,name=wind_direction_800hpa,json=windDirection800hpa,proto3" json:"wind_direction_800hpa,omitempty"`
+	WindDirection_700Hpa             []int32   `protobuf:"varint,92,rep,packed,name=wind_direction_700hpa,json=windDirection700hpa,proto3" json:"wind_direction_700hpa,omitempty"`
+	WindDirection_600Hpa             []int32   `protobuf:"varint,93,rep,packed,name=wind_direction_600hpa,json=windDirection600hpa,proto3" json:"wind_direction_600hpa,omitempty"`
+	WindDirection_500Hpa             []int32   `protobuf:"varint,94,rep,packed,name=wind_direction_500hpa,json=windDirection500hpa,proto3" json:"wind_direction_500hpa,omitempty"`
+	WindDirection_400Hpa             []int32   `protobuf:"varint,95,rep,packed,name=wind_direction_400hpa,json=windDirection400hpa,proto3" json:"wind_direction_400hpa,omitempty"`
+	GeopotentialHeight_1000Hpa       []float64 `protobuf:"fixed64,96,rep,packed,name=geopotential_height_1000hpa,json=geopotentialHeight1000hpa,proto3" json:"geopotential_height_1000hpa,omitempty"`
+	GeopotentialHeight_975Hpa        []float64 `protobuf:"fixed64,97,rep,packed,name=geopotential_height_975hpa,json=geopotentialHeight975hpa,proto3" json:"geopotential_height_975hpa,omitempty"`
+	GeopotentialHeight_950Hpa        []float64 `protobuf:"fixed64,98,rep,packed,name=geopotential_height_950hpa,json=geopotentialHeight950hpa,proto3" json:"geopotential_height_950hpa,omitempty"`
+	GeopotentialHeight_925Hpa        []float64 `protobuf:"fixed64,99,rep,packed,name=geopotential_height_925hpa,json=geopotentialHeight925hpa,proto3" json:"geopotential_height_925hpa,omitempty"`
+	GeopotentialHeight_900Hpa        []float64 `protobuf:"fixed64,100,rep,packed,name=geopotential_height_900hpa,json=geopotentialHeight900hpa,proto3" json:"geopotential_height_900hpa,omitempty"`
+	GeopotentialHeight_850Hpa        []float64 `protobuf:"fixed64,101,rep,packed,name=geopotential_height_850hpa,json=geopotentialHeight850hpa,proto3" json:"geopotential_height_850hpa,omitempty"`
+	GeopotentialHeight_800Hpa        []float64 `protobuf:"fixed64,102,rep,packed,name=geopotential_height_800hpa,json=geopotentialHeight800hpa,proto3" json:"geopotential_height_800hpa,omitempty"`
+	GeopotentialHeight_700Hpa        []float64 `protobuf:"fixed64,103,rep,packed,name=geopotential_height_700hpa,json=geopotentialHeight700hpa,proto3" json:"geopotential_height_700hpa,omitempty"`
+	GeopotentialHeight_600Hpa        []float64 `protobuf:"fixed64,104,rep,packed,name=geopotential_height_600hpa,json=geopotentialHeight600hpa,proto3" json:"geopotential_height_600hpa,omitempty"`
+	GeopotentialHeight_500Hpa        []float64 `protobuf:"fixed64,105,rep,packed,name=geopotential_height_500hpa,json=geopotentialHeight500hpa,proto3" json:"geopotential_height_500hpa,omitempty"`
+	GeopotentialHeight_400Hpa        []float64 `protobuf:"fixed64,106,rep,packed,name=geopotential_height_400hpa,json=geopotentialHeight400hpa,proto3" json:"geopotential_height_400hpa,omitempty"`
+}
+
+func (x *HourlyData) Reset() {
+	*x = HourlyData{}
+	mi := &file_forecast_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HourlyData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HourlyData) ProtoMessage() {}
+
+func (x *HourlyData) ProtoReflect() protoreflect.Message {
+	mi := &file_forecast_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HourlyData.ProtoReflect.Descriptor instead.
+func (*HourlyData) Descriptor() ([]byte, []int) {
+	return file_forecast_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *HourlyData) GetTime() []int64 {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *HourlyData) GetTemperature_2M() []float64 {
+	if x != nil {
+		return x.Temperature_2M
+	}
+	return nil
+}
+
+func (x *HourlyData) GetRelativeHumidity_2M() []int32 {
+	if x != nil {
+		return x.RelativeHumidity_2M
+	}
+	return nil
+}
+
+func (x *HourlyData) GetDewPoint_2M() []float64 {
+	if x != nil {
+		return x.DewPoint_2M
+	}
+	return nil
+}
+
+func (x *HourlyData) GetApparentTemperature() []float64 {
+	if x != nil {
+		return x.ApparentTemperature
+	}
+	return nil
+}
+
+func (x *HourlyData) GetPrecipitationProbability() []int32 {
+	if x != nil {
+		return x.PrecipitationProbability
+	}
+	return nil
+}
+
+func (x *HourlyData) GetPrecipitation() []float64 {
+	if x != nil {
+		return x.Precipitation
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWeatherCode() []int32 {
+	if x != nil {
+		return x.WeatherCode
+	}
+	return nil
+}
+
+func (x *HourlyData) GetPressureMsl() []float64 {
+	if x != nil {
+		return x.PressureMsl
+	}
+	return nil
+}
+
+func (x *HourlyData) GetSurfacePressure() []float64 {
+	if x != nil {
+		return x.SurfacePressure
+	}
+	return nil
+}
+
+func (x *HourlyData) GetCloudCover() []int32 {
+	if x != nil {
+		return x.CloudCover
+	}
+	return nil
+}
+
+func (x *HourlyData) GetCloudCoverLow() []int32 {
+	if x != nil {
+		return x.CloudCoverLow
+	}
+	return nil
+}
+
+func (x *HourlyData) GetCloudCoverMid() []int32 {
+	if x != nil {
+		return x.CloudCoverMid
+	}
+	return nil
+}
+
+func (x *HourlyData) GetCloudCoverHigh() []int32 {
+	if x != nil {
+		return x.CloudCoverHigh
+	}
+	return nil
+}
+
+func (x *HourlyData) GetVisibility() []float64 {
+	if x != nil {
+		return x.Visibility
+	}
+	return nil
+}
+
+func (x *HourlyData) GetEvapotranspiration() []float64 {
+	if x != nil {
+		return x.Evapotranspiration
+	}
+	return nil
+}
+
+func (x *HourlyData) GetEt0FaoEvapotranspiration() []float64 {
+	if x != nil {
+		return x.Et0FaoEvapotranspiration
+	}
+	return nil
+}
+
+func (x *HourlyData) GetVapourPressureDeficit() []float64 {
+	if x != nil {
+		return x.VapourPressureDeficit
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindSpeed_10M() []float64 {
+	if x != nil {
+		return x.WindSpeed_10M
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindSpeed_80M() []float64 {
+	if x != nil {
+		return x.WindSpeed_80M
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindSpeed_120M() []float64 {
+	if x != nil {
+		return x.WindSpeed_120M
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindSpeed_180M() []float64 {
+	if x != nil {
+		return x.WindSpeed_180M
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindDirection_10M() []int32 {
+	if x != nil {
+		return x.WindDirection_10M
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindDirection_80M() []int32 {
+	if x != nil {
+		return x.WindDirection_80M
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindDirection_120M() []int32 {
+	if x != nil {
+		return x.WindDirection_120M
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindDirection_180M() []int32 {
+	if x != nil {
+		return x.WindDirection_180M
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindGusts_10M() []float64 {
+	if x != nil {
+		return x.WindGusts_10M
+	}
+	return nil
+}
+
+func (x *HourlyData) GetTemperature_80M() []float64 {
+	if x != nil {
+		return x.Temperature_80M
+	}
+	return nil
+}
+
+func (x *HourlyData) GetTemperature_120M() []float64 {
+	if x != nil {
+		return x.Temperature_120M
+	}
+	return nil
+}
+
+func (x *HourlyData) GetTemperature_180M() []float64 {
+	if x != nil {
+		return x.Temperature_180M
+	}
+	return nil
+}
+
+func (x *HourlyData) GetUvIndex() []float64 {
+	if x != nil {
+		return x.UvIndex
+	}
+	return nil
+}
+
+func (x *HourlyData) GetUvIndexClearSky() []float64 {
+	if x != nil {
+		return x.UvIndexClearSky
+	}
+	return nil
+}
+
+func (x *HourlyData) GetIsDay() []int32 {
+	if x != nil {
+		return x.IsDay
+	}
+	return nil
+}
+
+func (x *HourlyData) GetSunshineDuration() []float64 {
+	if x != nil {
+		return x.SunshineDuration
+	}
+	return nil
+}
+
+func (x *HourlyData) GetTotalColumnIntegratedWaterVapour() []float64 {
+	if x != nil {
+		return x.TotalColumnIntegratedWaterVapour
+	}
+	return nil
+}
+
+func (x *HourlyData) GetCape() []float64 {
+	if x != nil {
+		return x.Cape
+	}
+	return nil
+}
+
+func (x *HourlyData) GetLiftedIndex() []float64 {
+	if x != nil {
+		return x.LiftedIndex
+	}
+	return nil
+}
+
+func (x *HourlyData) GetConvectiveInhibition() []float64 {
+	if x != nil {
+		return x.ConvectiveInhibition
+	}
+	return nil
+}
+
+func (x *HourlyData) GetFreezingLevelHeight() []float64 {
+	if x != nil {
+		return x.FreezingLevelHeight
+	}
+	return nil
+}
+
+func (x *HourlyData) GetBoundaryLayerHeight() []float64 {
+	if x != nil {
+		return x.BoundaryLayerHeight
+	}
+	return nil
+}
+
+func (x *HourlyData) GetTemperature_1000Hpa() []float64 {
+	if x != nil {
+		return x.Temperature_1000Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetTemperature_975Hpa() []float64 {
+	if x != nil {
+		return x.Temperature_975Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetTemperature_950Hpa() []float64 {
+	if x != nil {
+		return x.Temperature_950Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetTemperature_925Hpa() []float64 {
+	if x != nil {
+		return x.Temperature_925Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetTemperature_900Hpa() []float64 {
+	if x != nil {
+		return x.Temperature_900Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetTemperature_850Hpa() []float64 {
+	if x != nil {
+		return x.Temperature_850Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetTemperature_800Hpa() []float64 {
+	if x != nil {
+		return x.Temperature_800Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetTemperature_700Hpa() []float64 {
+	if x != nil {
+		return x.Temperature_700Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetTemperature_600Hpa() []float64 {
+	if x != nil {
+		return x.Temperature_600Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetTemperature_500Hpa() []float64 {
+	if x != nil {
+		return x.Temperature_500Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetTemperature_400Hpa() []float64 {
+	if x != nil {
+		return x.Temperature_400Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetRelativeHumidity_1000Hpa() []int32 {
+	if x != nil {
+		return x.RelativeHumidity_1000Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetRelativeHumidity_975Hpa() []int32 {
+	if x != nil {
+		return x.RelativeHumidity_975Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetRelativeHumidity_950Hpa() []int32 {
+	if x != nil {
+		return x.RelativeHumidity_950Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetRelativeHumidity_925Hpa() []int32 {
+	if x != nil {
+		return x.RelativeHumidity_925Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetRelativeHumidity_900Hpa() []int32 {
+	if x != nil {
+		return x.RelativeHumidity_900Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetRelativeHumidity_850Hpa() []int32 {
+	if x != nil {
+		return x.RelativeHumidity_850Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetRelativeHumidity_800Hpa() []int32 {
+	if x != nil {
+		return x.RelativeHumidity_800Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetRelativeHumidity_700Hpa() []int32 {
+	if x != nil {
+		return x.RelativeHumidity_700Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetRelativeHumidity_600Hpa() []int32 {
+	if x != nil {
+		return x.RelativeHumidity_600Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetRelativeHumidity_500Hpa() []int32 {
+	if x != nil {
+		return x.RelativeHumidity_500Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetRelativeHumidity_400Hpa() []int32 {
+	if x != nil {
+		return x.RelativeHumidity_400Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetCloudCover_1000Hpa() []int32 {
+	if x != nil {
+		return x.CloudCover_1000Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetCloudCover_975Hpa() []int32 {
+	if x != nil {
+		return x.CloudCover_975Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetCloudCover_950Hpa() []int32 {
+	if x != nil {
+		return x.CloudCover_950Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetCloudCover_925Hpa() []int32 {
+	if x != nil {
+		return x.CloudCover_925Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetCloudCover_900Hpa() []int32 {
+	if x != nil {
+		return x.CloudCover_900Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetCloudCover_850Hpa() []int32 {
+	if x != nil {
+		return x.CloudCover_850Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetCloudCover_800Hpa() []int32 {
+	if x != nil {
+		return x.CloudCover_800Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetCloudCover_700Hpa() []int32 {
+	if x != nil {
+		return x.CloudCover_700Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetCloudCover_600Hpa() []int32 {
+	if x != nil {
+		return x.CloudCover_600Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetCloudCover_500Hpa() []int32 {
+	if x != nil {
+		return x.CloudCover_500Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetCloudCover_400Hpa() []int32 {
+	if x != nil {
+		return x.CloudCover_400Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindSpeed_1000Hpa() []float64 {
+	if x != nil {
+		return x.WindSpeed_1000Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindSpeed_975Hpa() []float64 {
+	if x != nil {
+		return x.WindSpeed_975Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindSpeed_950Hpa() []float64 {
+	if x != nil {
+		return x.WindSpeed_950Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindSpeed_925Hpa() []float64 {
+	if x != nil {
+		return x.WindSpeed_925Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindSpeed_900Hpa() []float64 {
+	if x != nil {
+		return x.WindSpeed_900Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindSpeed_850Hpa() []float64 {
+	if x != nil {
+		return x.WindSpeed_850Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindSpeed_800Hpa() []float64 {
+	if x != nil {
+		return x.WindSpeed_800Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindSpeed_700Hpa() []float64 {
+	if x != nil {
+		return x.WindSpeed_700Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindSpeed_600Hpa() []float64 {
+	if x != nil {
+		return x.WindSpeed_600Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindSpeed_500Hpa() []float64 {
+	if x != nil {
+		return x.WindSpeed_500Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindSpeed_400Hpa() []float64 {
+	if x != nil {
+		return x.WindSpeed_400Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindDirection_1000Hpa() []int32 {
+	if x != nil {
+		return x.WindDirection_1000Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindDirection_975Hpa() []int32 {
+	if x != nil {
+		return x.WindDirection_975Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindDirection_950Hpa() []int32 {
+	if x != nil {
+		return x.WindDirection_950Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindDirection_925Hpa() []int32 {
+	if x != nil {
+		return x.WindDirection_925Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindDirection_900Hpa() []int32 {
+	if x != nil {
+		return x.WindDirection_900Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindDirection_850Hpa() []int32 {
+	if x != nil {
+		return x.WindDirection_850Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindDirection_800Hpa() []int32 {
+	if x != nil {
+		return x.WindDirection_800Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindDirection_700Hpa() []int32 {
+	if x != nil {
+		return x.WindDirection_700Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindDirection_600Hpa() []int32 {
+	if x != nil {
+		return x.WindDirection_600Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindDirection_500Hpa() []int32 {
+	if x != nil {
+		return x.WindDirection_500Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetWindDirection_400Hpa() []int32 {
+	if x != nil {
+		return x.WindDirection_400Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetGeopotentialHeight_1000Hpa() []float64 {
+	if x != nil {
+		return x.GeopotentialHeight_1000Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetGeopotentialHeight_975Hpa() []float64 {
+	if x != nil {
+		return x.GeopotentialHeight_975Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetGeopotentialHeight_950Hpa() []float64 {
+	if x != nil {
+		return x.GeopotentialHeight_950Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetGeopotentialHeight_925Hpa() []float64 {
+	if x != nil {
+		return x.GeopotentialHeight_925Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetGeopotentialHeight_900Hpa() []float64 {
+	if x != nil {
+		return x.GeopotentialHeight_900Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetGeopotentialHeight_850Hpa() []float64 {
+	if x != nil {
+		return x.GeopotentialHeight_850Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetGeopotentialHeight_800Hpa() []float64 {
+	if x != nil {
+		return x.GeopotentialHeight_800Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetGeopotentialHeight_700Hpa() []float64 {
+	if x != nil {
+		return x.GeopotentialHeight_700Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetGeopotentialHeight_600Hpa() []float64 {
+	if x != nil {
+		return x.GeopotentialHeight_600Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetGeopotentialHeight_500Hpa() []float64 {
+	if x != nil {
+		return x.GeopotentialHeight_500Hpa
+	}
+	return nil
+}
+
+func (x *HourlyData) GetGeopotentialHeight_400Hpa() []float64 {
+	if x != nil {
+		return x.GeopotentialHeight_400Hpa
+	}
+	return nil
+}
+
+type DailyData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time                        []int64   `protobuf:"varint,1,rep,packed,name=time,proto3" json:"time,omitempty"`                                                                                     // Unix timestamps
+	WeatherCode                 []int32   `protobuf:"varint,2,rep,packed,name=weather_code,json=weatherCode,proto3" json:"weather_code,omitempty"`                                                    // Weather codes according to WMO
+	Temperature_2MMax           []float64 `protobuf:"fixed64,3,rep,packed,name=temperature_2m_max,json=temperature2mMax,proto3" json:"temperature_2m_max,omitempty"`                                  // Maximum temperature at 2 meters above ground in °C
+	Temperature_2MMin           []float64 `protobuf:"fixed64,4,rep,packed,name=temperature_2m_min,json=temperature2mMin,proto3" json:"temperature_2m_min,omitempty"`                                  // Minimum temperature at 2 meters above ground in °C
+	ApparentTemperatureMax      []float64 `protobuf:"fixed64,5,rep,packed,name=apparent_temperature_max,json=apparentTemperatureMax,proto3" json:"apparent_temperature_max,omitempty"`                // Maximum apparent temperature in °C
+	ApparentTemperatureMin      []float64 `protobuf:"fixed64,6,rep,packed,name=apparent_temperature_min,json=apparentTemperatureMin,proto3" json:"apparent_temperature_min,omitempty"`                // Minimum apparent temperature in °C
+	Sunrise                     []int64   `protobuf:"varint,7,rep,packed,name=sunrise,proto3" json:"sunrise,omitempty"`                                                                               // Unix timestamps for sunrise
+	Sunset                      []int64   `protobuf:"varint,8,rep,packed,name=sunset,proto3" json:"sunset,omitempty"`                                                                                 // Unix timestamps for sunset
+	DaylightDuration            []float64 `protobuf:"fixed64,9,rep,packed,name=daylight_duration,json=daylightDuration,proto3" json:"daylight_duration,omitempty"`                                    // Duration of daylight in seconds
+	SunshineDuration            []float64 `protobuf:"fixed64,10,rep,packed,name=sunshine_duration,json=sunshineDuration,proto3" json:"sunshine_duration,omitempty"`                                   // Duration of sunshine in seconds
+	UvIndexMax                  []float64 `protobuf:"fixed64,11,rep,packed,name=uv_index_max,json=uvIndexMax,proto3" json:"uv_index_max,omitempty"`                                                   // Maximum UV index
+	UvIndexClearSkyMax          []float64 `protobuf:"fixed64,12,rep,packed,name=uv_index_clear_sky_max,json=uvIndexClearSkyMax,proto3" json:"uv_index_clear_sky_max,omitempty"`                       // Maximum UV index under clear sky
+	PrecipitationSum            []float64 `protobuf:"fixed64,13,rep,packed,name=precipitation_sum,json=precipitationSum,proto3" json:"precipitation_sum,omitempty"`                                   // Total precipitation in mm
+	PrecipitationHours          []float64 `protobuf:"fixed64,14,rep,packed,name=precipitation_hours,json=precipitationHours,proto3" json:"precipitation_hours,omitempty"`                             // Hours of precipitation
+	PrecipitationProbabilityMax []int32   `protobuf:"varint,15,rep,packed,name=precipitation_probability_max,json=precipitationProbabilityMax,proto3" json:"precipitation_probability_max,omitempty"` // Maximum probability of precipitation in %
+	WindSpeed_10MMax            []float64 `protobuf:"fixed64,16,rep,packed,name=wind_speed_10m_max,json=windSpeed10mMax,proto3" json:"wind_speed_10m_max,omitempty"`                                  // Maximum wind speed at 10 meters above ground in km/h
+	WindGusts_10MMax            []float64 `protobuf:"fixed64,17,rep,packed,name=wind_gusts_10m_max,json=windGusts10mMax,proto3" json:"wind_gusts_10m_max,omitempty"`                                  // Maximum wind gusts at 10 meters above ground in km/h
+	WindDirection_10MDominant   []int32   `protobuf:"varint,18,rep,packed,name=wind_direction_10m_dominant,json=windDirection10mDominant,proto3" json:"wind_direction_10m_dominant,omitempty"`        // Dominant wind direction at 10 meters above ground in degrees
+	ShortwaveRadiationSum       []float64 `protobuf:"fixed64,19,rep,packed,name=shortwave_radiation_sum,json=shortwaveRadiationSum,proto3" json:"shortwave_radiation_sum,omitempty"`                  // Sum of shortwave radiation in MJ/m²
+	Et0FaoEvapotranspiration    []float64 `protobuf:"fixed64,20,rep,packed,name=et0_fao_evapotranspiration,json=et0FaoEvapotranspiration,proto3" json:"et0_fao_evapotranspiration,omitempty"`         // Evapotranspiration in mm
+}
+
+func (x *DailyData) Reset() {
+	*x = DailyData{}
+	mi := &file_forecast_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailyData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyData) ProtoMessage() {}
+
+func (x *DailyData) ProtoReflect() protoreflect.Message {
+	mi := &file_forecast_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyData.ProtoReflect.Descriptor instead.
+func (*DailyData) Descriptor() ([]byte, []int) {
+	return file_forecast_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DailyData) GetTime() []int64 {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *DailyData) GetWeatherCode() []int32 {
+	if x != nil {
+		return x.WeatherCode
+	}
+	return nil
+}
+
+func (x *DailyData) GetTemperature_2MMax() []float64 {
+	if x != nil {
+		return x.Temperature_2MMax
+	}
+	return nil
+}
+
+func (x *DailyData) GetTemperature_2MMin() []float64 {
+	if x != nil {
+		return x.Temperature_2MMin
+	}
+	return nil
+}
+
+func (x *DailyData) GetApparentTemperatureMax() []float64 {
+	if x != nil {
+		return x.ApparentTemperatureMax
+	}
+	return nil
+}
+
+func (x *DailyData) GetApparentTemperatureMin() []float64 {
+	if x != nil {
+		return x.ApparentTemperatureMin
+	}
+	return nil
+}
+
+func (x *DailyData) GetSunrise() []int64 {
+	if x != nil {
+		return x.Sunrise
+	}
+	return nil
+}
+
+func (x *DailyData) GetSunset() []int64 {
+	if x != nil {
+		return x.Sunset
+	}
+	return nil
+}
+
+func (x *DailyData) GetDaylightDuration() []float64 {
+	if x != nil {
+		return x.DaylightDuration
+	}
+	return nil
+}
+
+func (x *DailyData) GetSunshineDuration() []float64 {
+	if x != nil {
+		return x.SunshineDuration
+	}
+	return nil
+}
+
+func (x *DailyData) GetUvIndexMax() []float64 {
+	if x != nil {
+		return x.UvIndexMax
+	}
+	return nil
+}
+
+func (x *DailyData) GetUvIndexClearSkyMax() []float64 {
+	if x != nil {
+		return x.UvIndexClearSkyMax
+	}
+	return nil
+}
+
+func (x *DailyData) GetPrecipitationSum() []float64 {
+	if x != nil {
+		return x.PrecipitationSum
+	}
+	return nil
+}
+
+func (x *DailyData) GetPrecipitationHours() []float64 {
+	if x != nil {
+		return x.PrecipitationHours
+	}
+	return nil
+}
+
+func (x *DailyData) GetPrecipitationProbabilityMax() []int32 {
+	if x != nil {
+		return x.PrecipitationProbabilityMax
+	}
+	return nil
+}
+
+func (x *DailyData) GetWindSpeed_10MMax() []float64 {
+	if x != nil {
+		return x.WindSpeed_10MMax
+	}
+	return nil
+}
+
+func (x *DailyData) GetWindGusts_10MMax() []float64 {
+	if x != nil {
+		return x.WindGusts_10MMax
+	}
+	return nil
+}
+
+func (x *DailyData) GetWindDirection_10MDominant() []int32 {
+	if x != nil {
+		return x.WindDirection_10MDominant
+	}
+	return nil
+}
+
+func (x *DailyData) GetShortwaveRadiationSum() []float64 {
+	if x != nil {
+		return x.ShortwaveRadiationSum
+	}
+	return nil
+}
+
+func (x *DailyData) GetEt0FaoEvapotranspiration() []float64 {
+	if x != nil {
+		return x.Et0FaoEvapotranspiration
+	}
+	return nil
+}
+
+type AirQualityData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time                []int64   `protobuf:"varint,1,rep,packed,name=time,proto3" json:"time,omitempty"`                                                             // Unix timestamps
+	Pm2_5               []float64 `protobuf:"fixed64,2,rep,packed,name=pm2_5,json=pm25,proto3" json:"pm2_5,omitempty"`                                                // Particulate matter below 2.5 µm in µg/m³
+	Pm10                []float64 `protobuf:"fixed64,3,rep,packed,name=pm10,proto3" json:"pm10,omitempty"`                                                            // Particulate matter below 10 µm in µg/m³
+	Dust                []float64 `protobuf:"fixed64,4,rep,packed,name=dust,proto3" json:"dust,omitempty"`                                                            // Saharan and other mineral dust in µg/m³
+	AerosolOpticalDepth []float64 `protobuf:"fixed64,5,rep,packed,name=aerosol_optical_depth,json=aerosolOpticalDepth,proto3" json:"aerosol_optical_depth,omitempty"` // Haze of the whole column at 550 nm, dimensionless
+	Ozone               []float64 `protobuf:"fixed64,6,rep,packed,name=ozone,proto3" json:"ozone,omitempty"`                                                          // Ozone at ground level in µg/m³
+	EuropeanAqi         []int32   `protobuf:"varint,7,rep,packed,name=european_aqi,json=europeanAqi,proto3" json:"european_aqi,omitempty"`                            // European Air Quality Index, 0 to 100+
+	UsAqi               []int32   `protobuf:"varint,8,rep,packed,name=us_aqi,json=usAqi,proto3" json:"us_aqi,omitempty"`                                              // United States Air Quality Index, 0 to 500
+}
+
+func (x *AirQualityData) Reset() {
+	*x = AirQualityData{}
+	mi := &file_forecast_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AirQualityData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AirQualityData) ProtoMessage() {}
+
+func (x *AirQualityData) ProtoReflect() protoreflect.Message {
+	mi := &file_forecast_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AirQualityData.ProtoReflect.Descriptor instead.
+func (*AirQualityData) Descriptor() ([]byte, []int) {
+	return file_forecast_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AirQualityData) GetTime() []int64 {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *AirQualityData) GetPm2_5() []float64 {
+	if x != nil {
+		return x.Pm2_5
+	}
+	return nil
+}
+
+func (x *AirQualityData) GetPm10() []float64 {
+	if x != nil {
+		return x.Pm10
+	}
+	return nil
+}
+
+func (x *AirQualityData) GetDust() []float64 {
+	if x != nil {
+		return x.Dust
+	}
+	return nil
+}
+
+func (x *AirQualityData) GetAerosolOpticalDepth() []float64 {
+	if x != nil {
+		return x.AerosolOpticalDepth
+	}
+	return nil
+}
+
+func (x *AirQualityData) GetOzone() []float64 {
+	if x != nil {
+		return x.Ozone
+	}
+	return nil
+}
+
+func (x *AirQualityData) GetEuropeanAqi() []int32 {
+	if x != nil {
+		return x.EuropeanAqi
+	}
+	return nil
+}
+
+func (x *AirQualityData) GetUsAqi() []int32 {
+	if x != nil {
+		return x.UsAqi
+	}
+	return nil
+}
+
+type MarineData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time                  []int64   `protobuf:"varint,1,rep,packed,name=time,proto3" json:"time,omitempty"`                                                                    // Unix timestamps
+	WaveHeight            []float64 `protobuf:"fixed64,2,rep,packed,name=wave_height,json=waveHeight,proto3" json:"wave_height,omitempty"`                                     // Significant height of the combined waves in m
+	WaveDirection         []int32   `protobuf:"varint,3,rep,packed,name=wave_direction,json=waveDirection,proto3" json:"wave_direction,omitempty"`                             // Mean direction the combined waves come from in degrees
+	WavePeriod            []float64 `protobuf:"fixed64,4,rep,packed,name=wave_period,json=wavePeriod,proto3" json:"wave_period,omitempty"`                                     // Mean period of the combined waves in s
+	WindWaveHeight        []float64 `protobuf:"fixed64,5,rep,packed,name=wind_wave_height,json=windWaveHeight,proto3" json:"wind_wave_height,omitempty"`                       // Significant height of the waves raised by the local wind in m
+	WindWaveDirection     []int32   `protobuf:"varint,6,rep,packed,name=wind_wave_direction,json=windWaveDirection,proto3" json:"wind_wave_direction,omitempty"`               // In degrees
+	WindWavePeriod        []float64 `protobuf:"fixed64,7,rep,packed,name=wind_wave_period,json=windWavePeriod,proto3" json:"wind_wave_period,omitempty"`                       // In s
+	SwellWaveHeight       []float64 `protobuf:"fixed64,8,rep,packed,name=swell_wave_height,json=swellWaveHeight,proto3" json:"swell_wave_height,omitempty"`                    // Significant height of the swell from distant weather in m
+	SwellWaveDirection    []int32   `protobuf:"varint,9,rep,packed,name=swell_wave_direction,json=swellWaveDirection,proto3" json:"swell_wave_direction,omitempty"`            // In degrees
+	SwellWavePeriod       []float64 `protobuf:"fixed64,10,rep,packed,name=swell_wave_period,json=swellWavePeriod,proto3" json:"swell_wave_period,omitempty"`                   // In s
+	SeaSurfaceTemperature []float64 `protobuf:"fixed64,11,rep,packed,name=sea_surface_temperature,json=seaSurfaceTemperature,proto3" json:"sea_surface_temperature,omitempty"` // In °C
+}
+
+func (x *MarineData) Reset() {
+	*x = MarineData{}
+	mi := &file_forecast_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarineData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarineData) ProtoMessage() {}
+
+func (x *MarineData) ProtoReflect() protoreflect.Message {
+	mi := &file_forecast_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarineData.ProtoReflect.Descriptor instead.
+func (*MarineData) Descriptor() ([]byte, []int) {
+	return file_forecast_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MarineData) GetTime() []int64 {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *MarineData) GetWaveHeight() []float64 {
+	if x != nil {
+		return x.WaveHeight
+	}
+	return nil
+}
+
+func (x *MarineData) GetWaveDirection() []int32 {
+	if x != nil {
+		return x.WaveDirection
+	}
+	return nil
+}
+
+func (x *MarineData) GetWavePeriod() []float64 {
+	if x != nil {
+		return x.WavePeriod
+	}
+	return nil
+}
+
+func (x *MarineData) GetWindWaveHeight() []float64 {
+	if x != nil {
+		return x.WindWaveHeight
+	}
+	return nil
+}
+
+func (x *MarineData) GetWindWaveDirection() []int32 {
+	if x != nil {
+		return x.WindWaveDirection
+	}
+	return nil
+}
+
+func (x *MarineData) GetWindWavePeriod() []float64 {
+	if x != nil {
+		return x.WindWavePeriod
+	}
+	return nil
+}
+
+func (x *MarineData) GetSwellWaveHeight() []float64 {
+	if x != nil {
+		return x.SwellWaveHeight
+	}
+	return nil
+}
+
+func (x *MarineData) GetSwellWaveDirection() []int32 {
+	if x != nil {
+		return x.SwellWaveDirection
+	}
+	return nil
+}
+
+func (x *MarineData) GetSwellWavePeriod() []float64 {
+	if x != nil {
+		return x.SwellWavePeriod
+	}
+	return nil
+}
+
+func (x *MarineData) GetSeaSurfaceTemperature() []float64 {
+	if x != nil {
+		return x.SeaSurfaceTemperature
+	}
+	return nil
+}
+
+var File_forecast_proto protoreflect.FileDescriptor
+
+var file_forecast_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x66, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x08, 0x6d, 0x75, 0x6e, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x22, 0x47, 0x0a, 0x0b, 0x43, 0x6f,
+	0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74,
+	0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61, 0x74,
+	0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75,
+	0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74,
+	0x75, 0x64, 0x65, 0x22, 0xe7, 0x01, 0x0a, 0x0f, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64,
+	0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d,
+	0x75, 0x6e, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61,
+	0x74, 0x65, 0x73, 0x52, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05,
+	0x75, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x75, 0x6e, 0x69,
+	0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x30, 0x0a, 0x07, 0x6f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x75,
+	0x6e, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x0b,
+	0x61, 0x69, 0x72, 0x5f, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x61, 0x69, 0x72, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x22, 0xee, 0x01,
+	0x0a, 0x16, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x0b, 0x63, 0x6f, 0x6f, 0x72,
+	0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x6d, 0x75, 0x6e, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e,
+	0x61, 0x74, 0x65, 0x73, 0x52, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x14, 0x0a,
+	0x05, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x75, 0x6e,
+	0x69, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x30, 0x0a, 0x07, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d,
+	0x75, 0x6e, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x0a,
+	0x0b, 0x61, 0x69, 0x72, 0x5f, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0a, 0x61, 0x69, 0x72, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x22, 0x8a,
+	0x02, 0x0a, 0x0c, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73,
+	0x74, 0x5f, 0x64, 0x61, 0x79, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x66, 0x6f,
+	0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x44, 0x61, 0x79, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x66, 0x6f,
+	0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0d, 0x66, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x48, 0x6f, 0x75, 0x72,
+	0x73, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x73, 0x74, 0x5f, 0x64, 0x61, 0x79, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x73, 0x74, 0x44, 0x61, 0x79, 0x73, 0x12, 0x25,
+	0x0a, 0x0e, 0x63, 0x65, 0x6c, 0x6c, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x65, 0x6c, 0x6c, 0x53, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x44, 0x61, 0x74, 0x65,
+	0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x22, 0x8f, 0x01, 0x0a, 0x10,
+	0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x37, 0x0a, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x75, 0x6e, 0x63, 0x68, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x52, 0x0b, 0x63, 0x6f,
+	0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x75, 0x6e, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x42,
+	0x61, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xc3, 0x04,
+	0x0a, 0x08, 0x42, 0x61, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61,
+	0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61,
+	0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74,
+	0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69,
+	0x74, 0x75, 0x64, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x75, 0x74, 0x63, 0x5f, 0x6f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x10, 0x75, 0x74, 0x63, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x33,
+	0x0a, 0x15, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x5f, 0x61, 0x62, 0x62, 0x72, 0x65,
+	0x76, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x14, 0x74,
+	0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x41, 0x62, 0x62, 0x72, 0x65, 0x76, 0x69, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x6c, 0x65, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x65, 0x6c, 0x65, 0x76, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x2f, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x75, 0x6e, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x44, 0x61, 0x74, 0x61, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x68, 0x6f, 0x75, 0x72, 0x6c, 0x79, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x75, 0x6e, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f,
+	0x75, 0x72, 0x6c, 0x79, 0x44, 0x61, 0x74, 0x61, 0x52, 0x06, 0x68, 0x6f, 0x75, 0x72, 0x6c, 0x79,
+	0x12, 0x29, 0x0a, 0x05, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x6d, 0x75, 0x6e, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x69, 0x6c, 0x79,
+	0x44, 0x61, 0x74, 0x61, 0x52, 0x05, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x12, 0x39, 0x0a, 0x0b, 0x61,
+	0x69, 0x72, 0x5f, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x18, 0x2e, 0x6d, 0x75, 0x6e, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x69, 0x72, 0x51,
+	0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0a, 0x61, 0x69, 0x72, 0x51,
+	0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x2c, 0x0a, 0x06, 0x6d, 0x61, 0x72, 0x69, 0x6e, 0x65,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x75, 0x6e, 0x63, 0x68, 0x2e, 0x76,
+	0x31, 0x2e, 0x4d, 0x61, 0x72, 0x69, 0x6e, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x06, 0x6d, 0x61,
+	0x72, 0x69, 0x6e, 0x65, 0x12, 0x33, 0x0a, 0x05, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x0c, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x75, 0x6e, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x42,
+	0x61, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x05, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x1a, 0x38, 0x0a, 0x0a, 0x55, 0x6e, 0x69,
+	0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0xdc, 0x04, 0x0a, 0x0b, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x44,
+	0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x5f, 0x32, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x74, 0x65, 0x6d,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x32, 0x6d, 0x12, 0x30, 0x0a, 0x14, 0x72, 0x65,
+	0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x68, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x5f,
+	0x32, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69,
+	0x76, 0x65, 0x48, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x32, 0x6d, 0x12, 0x31, 0x0a, 0x14,
+	0x61, 0x70, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x13, 0x61, 0x70, 0x70, 0x61,
+	0x72, 0x65, 0x6e, 0x74, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12,
+	0x15, 0x0a, 0x06, 0x69, 0x73, 0x5f, 0x64, 0x61, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x69, 0x73, 0x44, 0x61, 0x79, 0x12, 0x24, 0x0a, 0x0d, 0x70, 0x72, 0x65, 0x63, 0x69, 0x70,
+	0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x70,
+	0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04,
+	0x72, 0x61, 0x69, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x72, 0x61, 0x69, 0x6e,
+	0x12, 0x18, 0x0a, 0x07, 0x73, 0x68, 0x6f, 0x77, 0x65, 0x72, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x07, 0x73, 0x68, 0x6f, 0x77, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6e,
+	0x6f, 0x77, 0x66, 0x61, 0x6c, 0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x73, 0x6e,
+	0x6f, 0x77, 0x66, 0x61, 0x6c, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65,
+	0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x77, 0x65,
+	0x61, 0x74, 0x68, 0x65, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x5f, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x75, 0x72, 0x65, 0x5f, 0x6d, 0x73, 0x6c, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0b, 0x70, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72, 0x65, 0x4d, 0x73, 0x6c, 0x12, 0x29, 0x0a,
+	0x10, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x5f, 0x70, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72,
+	0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x50, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x77, 0x69, 0x6e, 0x64,
+	0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x5f, 0x31, 0x30, 0x6d, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0c, 0x77, 0x69, 0x6e, 0x64, 0x53, 0x70, 0x65, 0x65, 0x64, 0x31, 0x30, 0x6d, 0x12, 0x2c,
+	0x0a, 0x12, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x31, 0x30, 0x6d, 0x18, 0x10, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x77, 0x69, 0x6e, 0x64,
+	0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x31, 0x30, 0x6d, 0x12, 0x24, 0x0a, 0x0e,
+	0x77, 0x69, 0x6e, 0x64, 0x5f, 0x67, 0x75, 0x73, 0x74, 0x73, 0x5f, 0x31, 0x30, 0x6d, 0x18, 0x11,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x77, 0x69, 0x6e, 0x64, 0x47, 0x75, 0x73, 0x74, 0x73, 0x31,
+	0x30, 0x6d, 0x22, 0x83, 0x28, 0x0a, 0x0a, 0x48, 0x6f, 0x75, 0x72, 0x6c, 0x79, 0x44, 0x61, 0x74,
+	0x61, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52,
+	0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x5f, 0x32, 0x6d, 0x18, 0x02, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0d, 0x74,
+	0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x32, 0x6d, 0x12, 0x30, 0x0a, 0x14,
+	0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x68, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74,
+	0x79, 0x5f, 0x32, 0x6d, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05, 0x52, 0x12, 0x72, 0x65, 0x6c, 0x61,
+	0x74, 0x69, 0x76, 0x65, 0x48, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x32, 0x6d, 0x12, 0x20,
+	0x0a, 0x0c, 0x64, 0x65, 0x77, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x32, 0x6d, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x01, 0x52, 0x0a, 0x64, 0x65, 0x77, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x32, 0x6d,
+	0x12, 0x31, 0x0a, 0x14, 0x61, 0x70, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x65, 0x6d,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x03, 0x28, 0x01, 0x52, 0x13,
+	0x61, 0x70, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x12, 0x3b, 0x0a, 0x19, 0x70, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x05, 0x52, 0x18, 0x70, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x12, 0x24, 0x0a, 0x0d, 0x70, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x07, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0d, 0x70, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65,
+	0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x08, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0b, 0x77, 0x65,
+	0x61, 0x74, 0x68, 0x65, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x75, 0x72, 0x65, 0x5f, 0x6d, 0x73, 0x6c, 0x18, 0x09, 0x20, 0x03, 0x28, 0x01, 0x52,
+	0x0b, 0x70, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72, 0x65, 0x4d, 0x73, 0x6c, 0x12, 0x29, 0x0a, 0x10,
+	0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x5f, 0x70, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72, 0x65,
+	0x18, 0x0a, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x50,
+	0x72, 0x65, 0x73, 0x73, 0x75, 0x72, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x5f, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0a, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x0f, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x5f, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x5f, 0x6c, 0x6f, 0x77, 0x18, 0x0c, 0x20, 0x03, 0x28,
+	0x05, 0x52, 0x0d, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x4c, 0x6f, 0x77,
+	0x12, 0x26, 0x0a, 0x0f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x5f,
+	0x6d, 0x69, 0x64, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0d, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x43, 0x6f, 0x76, 0x65, 0x72, 0x4d, 0x69, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x5f, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x5f, 0x68, 0x69, 0x67, 0x68, 0x18, 0x0e, 0x20, 0x03,
+	0x28, 0x05, 0x52, 0x0e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x48, 0x69,
+	0x67, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x18, 0x0f, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x12, 0x2e, 0x0a, 0x12, 0x65, 0x76, 0x61, 0x70, 0x6f, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x10, 0x20, 0x03, 0x28, 0x01, 0x52, 0x12,
+	0x65, 0x76, 0x61, 0x70, 0x6f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x3c, 0x0a, 0x1a, 0x65, 0x74, 0x30, 0x5f, 0x66, 0x61, 0x6f, 0x5f, 0x65, 0x76,
+	0x61, 0x70, 0x6f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x11, 0x20, 0x03, 0x28, 0x01, 0x52, 0x18, 0x65, 0x74, 0x30, 0x46, 0x61, 0x6f, 0x45, 0x76,
+	0x61, 0x70, 0x6f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x36, 0x0a, 0x17, 0x76, 0x61, 0x70, 0x6f, 0x75, 0x72, 0x5f, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x75, 0x72, 0x65, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x63, 0x69, 0x74, 0x18, 0x12, 0x20, 0x03, 0x28,
+	0x01, 0x52, 0x15, 0x76, 0x61, 0x70, 0x6f, 0x75, 0x72, 0x50, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72,
+	0x65, 0x44, 0x65, 0x66, 0x69, 0x63, 0x69, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x77, 0x69, 0x6e, 0x64,
+	0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x5f, 0x31, 0x30, 0x6d, 0x18, 0x13, 0x20, 0x03, 0x28, 0x01,
+	0x52, 0x0c, 0x77, 0x69, 0x6e, 0x64, 0x53, 0x70, 0x65, 0x65, 0x64, 0x31, 0x30, 0x6d, 0x12, 0x24,
+	0x0a, 0x0e, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x5f, 0x38, 0x30, 0x6d,
+	0x18, 0x14, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0c, 0x77, 0x69, 0x6e, 0x64, 0x53, 0x70, 0x65, 0x65,
+	0x64, 0x38, 0x30, 0x6d, 0x12, 0x26, 0x0a, 0x0f, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x73, 0x70, 0x65,
+	0x65, 0x64, 0x5f, 0x31, 0x32, 0x30, 0x6d, 0x18, 0x15, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0d, 0x77,
+	0x69, 0x6e, 0x64, 0x53, 0x70, 0x65, 0x65, 0x64, 0x31, 0x32, 0x30, 0x6d, 0x12, 0x26, 0x0a, 0x0f,
+	0x77, 0x69, 0x6e, 0x64, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x5f, 0x31, 0x38, 0x30, 0x6d, 0x18,
+	0x16, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0d, 0x77, 0x69, 0x6e, 0x64, 0x53, 0x70, 0x65, 0x65, 0x64,
+	0x31, 0x38, 0x30, 0x6d, 0x12, 0x2c, 0x0a, 0x12, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x64, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x31, 0x30, 0x6d, 0x18, 0x17, 0x20, 0x03, 0x28, 0x05,
+	0x52, 0x10, 0x77, 0x69, 0x6e, 0x64, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x31,
+	0x30, 0x6d, 0x12, 0x2c, 0x0a, 0x12, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x38, 0x30, 0x6d, 0x18, 0x18, 0x20, 0x03, 0x28, 0x05, 0x52, 0x10,
+	0x77, 0x69, 0x6e, 0x64, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x38, 0x30, 0x6d,
+	0x12, 0x2e, 0x0a, 0x13, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x31, 0x32, 0x30, 0x6d, 0x18, 0x19, 0x20, 0x03, 0x28, 0x05, 0x52, 0x11, 0x77,
+	0x69, 0x6e, 0x64, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x31, 0x32, 0x30, 0x6d,
+	0x12, 0x2e, 0x0a, 0x13, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x31, 0x38, 0x30, 0x6d, 0x18, 0x1a, 0x20, 0x03, 0x28, 0x05, 0x52, 0x11, 0x77,
+	0x69, 0x6e, 0x64, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x31, 0x38, 0x30, 0x6d,
+	0x12, 0x24, 0x0a, 0x0e, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x67, 0x75, 0x73, 0x74, 0x73, 0x5f, 0x31,
+	0x30, 0x6d, 0x18, 0x1b, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0c, 0x77, 0x69, 0x6e, 0x64, 0x47, 0x75,
+	0x73, 0x74, 0x73, 0x31, 0x30, 0x6d, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x38, 0x30, 0x6d, 0x18, 0x1c, 0x20, 0x03, 0x28, 0x01, 0x52,
+	0x0e, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x38, 0x30, 0x6d, 0x12,
+	0x29, 0x0a, 0x10, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x31,
+	0x32, 0x30, 0x6d, 0x18, 0x1d, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0f, 0x74, 0x65, 0x6d, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x31, 0x32, 0x30, 0x6d, 0x12, 0x29, 0x0a, 0x10, 0x74, 0x65,
+	0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x31, 0x38, 0x30, 0x6d, 0x18, 0x1e,
+	0x20, 0x03, 0x28, 0x01, 0x52, 0x0f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x31, 0x38, 0x30, 0x6d, 0x12, 0x19, 0x0a, 0x08, 0x75, 0x76, 0x5f, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x18, 0x1f, 0x20, 0x03, 0x28, 0x01, 0x52, 0x07, 0x75, 0x76, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x12, 0x2b, 0x0a, 0x12, 0x75, 0x76, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x63, 0x6c, 0x65,
+	0x61, 0x72, 0x5f, 0x73, 0x6b, 0x79, 0x18, 0x20, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0f, 0x75, 0x76,
+	0x49, 0x6e, 0x64, 0x65, 0x78, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x53, 0x6b, 0x79, 0x12, 0x15, 0x0a,
+	0x06, 0x69, 0x73, 0x5f, 0x64, 0x61, 0x79, 0x18, 0x21, 0x20, 0x03, 0x28, 0x05, 0x52, 0x05, 0x69,
+	0x73, 0x44, 0x61, 0x79, 0x12, 0x2b, 0x0a, 0x11, 0x73, 0x75, 0x6e, 0x73, 0x68, 0x69, 0x6e, 0x65,
+	0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x22, 0x20, 0x03, 0x28, 0x01, 0x52,
+	0x10, 0x73, 0x75, 0x6e, 0x73, 0x68, 0x69, 0x6e, 0x65, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x4e, 0x0a, 0x24, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d,
+	0x6e, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x77, 0x61, 0x74,
+	0x65, 0x72, 0x5f, 0x76, 0x61, 0x70, 0x6f, 0x75, 0x72, 0x18, 0x23, 0x20, 0x03, 0x28, 0x01, 0x52,
+	0x20, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x49, 0x6e, 0x74, 0x65,
+	0x67, 0x72, 0x61, 0x74, 0x65, 0x64, 0x57, 0x61, 0x74, 0x65, 0x72, 0x56, 0x61, 0x70, 0x6f, 0x75,
+	0x72, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x61, 0x70, 0x65, 0x18, 0x24, 0x20, 0x03, 0x28, 0x01, 0x52,
+	0x04, 0x63, 0x61, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x69, 0x66, 0x74, 0x65, 0x64, 0x5f,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x25, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0b, 0x6c, 0x69, 0x66,
+	0x74, 0x65, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x33, 0x0a, 0x15, 0x63, 0x6f, 0x6e, 0x76,
+	0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x69, 0x6e, 0x68, 0x69, 0x62, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x26, 0x20, 0x03, 0x28, 0x01, 0x52, 0x14, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x49, 0x6e, 0x68, 0x69, 0x62, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x32, 0x0a,
+	0x15, 0x66, 0x72, 0x65, 0x65, 0x7a, 0x69, 0x6e, 0x67, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x5f,
+	0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x27, 0x20, 0x03, 0x28, 0x01, 0x52, 0x13, 0x66, 0x72,
+	0x65, 0x65, 0x7a, 0x69, 0x6e, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x48, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x12, 0x32, 0x0a, 0x15, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x79, 0x5f, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x28, 0x20, 0x03, 0x28, 0x01,
+	0x52, 0x13, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x79, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x48,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x2f, 0x0a, 0x13, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x5f, 0x31, 0x30, 0x30, 0x30, 0x68, 0x70, 0x61, 0x18, 0x29, 0x20, 0x03,
+	0x28, 0x01, 0x52, 0x12, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x31,
+	0x30, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12, 0x2d, 0x0a, 0x12, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x39, 0x37, 0x35, 0x68, 0x70, 0x61, 0x18, 0x2a, 0x20, 0x03,
+	0x28, 0x01, 0x52, 0x11, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x39,
+	0x37, 0x35, 0x68, 0x70, 0x61, 0x12, 0x2d, 0x0a, 0x12, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x5f, 0x39, 0x35, 0x30, 0x68, 0x70, 0x61, 0x18, 0x2b, 0x20, 0x03, 0x28,
+	0x01, 0x52, 0x11, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x39, 0x35,
+	0x30, 0x68, 0x70, 0x61, 0x12, 0x2d, 0x0a, 0x12, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x5f, 0x39, 0x32, 0x35, 0x68, 0x70, 0x61, 0x18, 0x2c, 0x20, 0x03, 0x28, 0x01,
+	0x52, 0x11, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x39, 0x32, 0x35,
+	0x68, 0x70, 0x61, 0x12, 0x2d, 0x0a, 0x12, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x5f, 0x39, 0x30, 0x30, 0x68, 0x70, 0x61, 0x18, 0x2d, 0x20, 0x03, 0x28, 0x01, 0x52,
+	0x11, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x39, 0x30, 0x30, 0x68,
+	0x70, 0x61, 0x12, 0x2d, 0x0a, 0x12, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x5f, 0x38, 0x35, 0x30, 0x68, 0x70, 0x61, 0x18, 0x2e, 0x20, 0x03, 0x28, 0x01, 0x52, 0x11,
+	0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x38, 0x35, 0x30, 0x68, 0x70,
+	0x61, 0x12, 0x2d, 0x0a, 0x12, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x5f, 0x38, 0x30, 0x30, 0x68, 0x70, 0x61, 0x18, 0x2f, 0x20, 0x03, 0x28, 0x01, 0x52, 0x11, 0x74,
+	0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x38, 0x30, 0x30, 0x68, 0x70, 0x61,
+	0x12, 0x2d, 0x0a, 0x12, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f,
+	0x37, 0x30, 0x30, 0x68, 0x70, 0x61, 0x18, 0x30, 0x20, 0x03, 0x28, 0x01, 0x52, 0x11, 0x74, 0x65,
+	0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x37, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12,
+	0x2d, 0x0a, 0x12, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x36,
+	0x30, 0x30, 0x68, 0x70, 0x61, 0x18, 0x31, 0x20, 0x03, 0x28, 0x01, 0x52, 0x11, 0x74, 0x65, 0x6d,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x36, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12, 0x2d,
+	0x0a, 0x12, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x35, 0x30,
+	0x30, 0x68, 0x70, 0x61, 0x18, 0x32, 0x20, 0x03, 0x28, 0x01, 0x52, 0x11, 0x74, 0x65, 0x6d, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x35, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12, 0x2d, 0x0a,
+	0x12, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x34, 0x30, 0x30,
+	0x68, 0x70, 0x61, 0x18, 0x33, 0x20, 0x03, 0x28, 0x01, 0x52, 0x11, 0x74, 0x65, 0x6d, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x34, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12, 0x3a, 0x0a, 0x19,
+	0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x68, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74,
+	0x79, 0x5f, 0x31, 0x30, 0x30, 0x30, 0x68, 0x70, 0x61, 0x18, 0x34, 0x20, 0x03, 0x28, 0x05, 0x52,
+	0x17, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74,
+	0x79, 0x31, 0x30, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12, 0x38, 0x0a, 0x18, 0x72, 0x65, 0x6c, 0x61,
+	0x74, 0x69, 0x76, 0x65, 0x5f, 0x68, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x5f, 0x39, 0x37,
+	0x35, 0x68, 0x70, 0x61, 0x18, 0x35, 0x20, 0x03, 0x28, 0x05, 0x52, 0x16, 0x72, 0x65, 0x6c, 0x61,
+	0x74, 0x69, 0x76, 0x65, 0x48, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x39, 0x37, 0x35, 0x68,
+	0x70, 0x61, 0x12, 0x38, 0x0a, 0x18, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x68,
+	0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x5f, 0x39, 0x35, 0x30, 0x68, 0x70, 0x61, 0x18, 0x36,
+	0x20, 0x03, 0x28, 0x05, 0x52, 0x16, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x75,
+	0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x39, 0x35, 0x30, 0x68, 0x70, 0x61, 0x12, 0x38, 0x0a, 0x18,
+	0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x68, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74,
+	0x79, 0x5f, 0x39, 0x32, 0x35, 0x68, 0x70, 0x61, 0x18, 0x37, 0x20, 0x03, 0x28, 0x05, 0x52, 0x16,
+	0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79,
+	0x39, 0x32, 0x35, 0x68, 0x70, 0x61, 0x12, 0x38, 0x0a, 0x18, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69,
+	0x76, 0x65, 0x5f, 0x68, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x5f, 0x39, 0x30, 0x30, 0x68,
+	0x70, 0x61, 0x18, 0x38, 0x20, 0x03, 0x28, 0x05, 0x52, 0x16, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69,
+	0x76, 0x65, 0x48, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x39, 0x30, 0x30, 0x68, 0x70, 0x61,
+	0x12, 0x38, 0x0a, 0x18, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x68, 0x75, 0x6d,
+	0x69, 0x64, 0x69, 0x74, 0x79, 0x5f, 0x38, 0x35, 0x30, 0x68, 0x70, 0x61, 0x18, 0x39, 0x20, 0x03,
+	0x28, 0x05, 0x52, 0x16, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x75, 0x6d, 0x69,
+	0x64, 0x69, 0x74, 0x79, 0x38, 0x35, 0x30, 0x68, 0x70, 0x61, 0x12, 0x38, 0x0a, 0x18, 0x72, 0x65,
+	0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x68, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x5f,
+	0x38, 0x30, 0x30, 0x68, 0x70, 0x61, 0x18, 0x3a, 0x20, 0x03, 0x28, 0x05, 0x52, 0x16, 0x72, 0x65,
+	0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x38, 0x30,
+	0x30, 0x68, 0x70, 0x61, 0x12, 0x38, 0x0a, 0x18, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65,
+	0x5f, 0x68, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x5f, 0x37, 0x30, 0x30, 0x68, 0x70, 0x61,
+	0x18, 0x3b, 0x20, 0x03, 0x28, 0x05, 0x52, 0x16, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65,
+	0x48, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x37, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12, 0x38,
+	0x0a, 0x18, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x68, 0x75, 0x6d, 0x69, 0x64,
+	0x69, 0x74, 0x79, 0x5f, 0x36, 0x30, 0x30, 0x68, 0x70, 0x61, 0x18, 0x3c, 0x20, 0x03, 0x28, 0x05,
+	0x52, 0x16, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x75, 0x6d, 0x69, 0x64, 0x69,
+	0x74, 0x79, 0x36, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12, 0x38, 0x0a, 0x18, 0x72, 0x65, 0x6c, 0x61,
+	0x74, 0x69, 0x76, 0x65, 0x5f, 0x68, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x5f, 0x35, 0x30,
+	0x30, 0x68, 0x70, 0x61, 0x18, 0x3d, 0x20, 0x03, 0x28, 0x05, 0x52, 0x16, 0x72, 0x65, 0x6c, 0x61,
+	0x74, 0x69, 0x76, 0x65, 0x48, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x35, 0x30, 0x30, 0x68,
+	0x70, 0x61, 0x12, 0x38, 0x0a, 0x18, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x68,
+	0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x5f, 0x34, 0x30, 0x30, 0x68, 0x70, 0x61, 0x18, 0x3e,
+	0x20, 0x03, 0x28, 0x05, 0x52, 0x16, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x75,
+	0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x34, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12, 0x2e, 0x0a, 0x13,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x5f, 0x31, 0x30, 0x30, 0x30,
+	0x68, 0x70, 0x61, 0x18, 0x3f, 0x20, 0x03, 0x28, 0x05, 0x52, 0x11, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x43, 0x6f, 0x76, 0x65, 0x72, 0x31, 0x30, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12, 0x2c, 0x0a, 0x12,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x5f, 0x39, 0x37, 0x35, 0x68,
+	0x70, 0x61, 0x18, 0x40, 0x20, 0x03, 0x28, 0x05, 0x52, 0x10, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x43,
+	0x6f, 0x76, 0x65, 0x72, 0x39, 0x37, 0x35, 0x68, 0x70, 0x61, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x5f, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x5f, 0x39, 0x35, 0x30, 0x68, 0x70, 0x61,
+	0x18, 0x41, 0x20, 0x03, 0x28, 0x05, 0x52, 0x10, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x43, 0x6f, 0x76,
+	0x65, 0x72, 0x39, 0x35, 0x30, 0x68, 0x70, 0x61, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x5f, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x5f, 0x39, 0x32, 0x35, 0x68, 0x70, 0x61, 0x18, 0x42,
+	0x20, 0x03, 0x28, 0x05, 0x52, 0x10, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x43, 0x6f, 0x76, 0x65, 0x72,
+	0x39, 0x32, 0x35, 0x68, 0x70, 0x61, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f,
+	0x63, 0x6f, 0x76, 0x65, 0x72, 0x5f, 0x39, 0x30, 0x30, 0x68, 0x70, 0x61, 0x18, 0x43, 0x20, 0x03,
+	0x28, 0x05, 0x52, 0x10, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x39, 0x30,
+	0x30, 0x68, 0x70, 0x61, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x63, 0x6f,
+	0x76, 0x65, 0x72, 0x5f, 0x38, 0x35, 0x30, 0x68, 0x70, 0x61, 0x18, 0x44, 0x20, 0x03, 0x28, 0x05,
+	0x52, 0x10, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x38, 0x35, 0x30, 0x68,
+	0x70, 0x61, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x63, 0x6f, 0x76, 0x65,
+	0x72, 0x5f, 0x38, 0x30, 0x30, 0x68, 0x70, 0x61, 0x18, 0x45, 0x20, 0x03, 0x28, 0x05, 0x52, 0x10,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x38, 0x30, 0x30, 0x68, 0x70, 0x61,
+	0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x5f,
+	0x37, 0x30, 0x30, 0x68, 0x70, 0x61, 0x18, 0x46, 0x20, 0x03, 0x28, 0x05, 0x52, 0x10, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x37, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12, 0x2c,
+	0x0a, 0x12, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x5f, 0x36, 0x30,
+	0x30, 0x68, 0x70, 0x61, 0x18, 0x47, 0x20, 0x03, 0x28, 0x05, 0x52, 0x10, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x36, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12, 0x2c, 0x0a, 0x12,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x5f, 0x35, 0x30, 0x30, 0x68,
+	0x70, 0x61, 0x18, 0x48, 0x20, 0x03, 0x28, 0x05, 0x52, 0x10, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x43,
+	0x6f, 0x76, 0x65, 0x72, 0x35, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x5f, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x5f, 0x34, 0x30, 0x30, 0x68, 0x70, 0x61,
+	0x18, 0x49, 0x20, 0x03, 0x28, 0x05, 0x52, 0x10, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x43, 0x6f, 0x76,
+	0x65, 0x72, 0x34, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12, 0x2c, 0x0a, 0x12, 0x77, 0x69, 0x6e, 0x64,
+	0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x5f, 0x31, 0x30, 0x30, 0x30, 0x68, 0x70, 0x61, 0x18, 0x4a,
+	0x20, 0x03, 0x28, 0x01, 0x52, 0x10, 0x77, 0x69, 0x6e, 0x64, 0x53, 0x70, 0x65, 0x65, 0x64, 0x31,
+	0x30, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12, 0x2a, 0x0a, 0x11, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x73,
+	0x70, 0x65, 0x65, 0x64, 0x5f, 0x39, 0x37, 0x35, 0x68, 0x70, 0x61, 0x18, 0x4b, 0x20, 0x03, 0x28,
+	0x01, 0x52, 0x0f, 0x77, 0x69, 0x6e, 0x64, 0x53, 0x70, 0x65, 0x65, 0x64, 0x39, 0x37, 0x35, 0x68,
+	0x70, 0x61, 0x12, 0x2a, 0x0a, 0x11, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64,
+	0x5f, 0x39, 0x35, 0x30, 0x68, 0x70, 0x61, 0x18, 0x4c, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0f, 0x77,
+	0x69, 0x6e, 0x64, 0x53, 0x70, 0x65, 0x65, 0x64, 0x39, 0x35, 0x30, 0x68, 0x70, 0x61, 0x12, 0x2a,
+	0x0a, 0x11, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x5f, 0x39, 0x32, 0x35,
+	0x68, 0x70, 0x61, 0x18, 0x4d, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0f, 0x77, 0x69, 0x6e, 0x64, 0x53,
+	0x70, 0x65, 0x65, 0x64, 0x39, 0x32, 0x35, 0x68, 0x70, 0x61, 0x12, 0x2a, 0x0a, 0x11, 0x77, 0x69,
+	0x6e, 0x64, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x5f, 0x39, 0x30, 0x30, 0x68, 0x70, 0x61, 0x18,
+	0x4e, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0f, 0x77, 0x69, 0x6e, 0x64, 0x53, 0x70, 0x65, 0x65, 0x64,
+	0x39, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12, 0x2a, 0x0a, 0x11, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x73,
+	0x70, 0x65, 0x65, 0x64, 0x5f, 0x38, 0x35, 0x30, 0x68, 0x70, 0x61, 0x18, 0x4f, 0x20, 0x03, 0x28,
+	0x01, 0x52, 0x0f, 0x77, 0x69, 0x6e, 0x64, 0x53, 0x70, 0x65, 0x65, 0x64, 0x38, 0x35, 0x30, 0x68,
+	0x70, 0x61, 0x12, 0x2a, 0x0a, 0x11, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64,
+	0x5f, 0x38, 0x30, 0x30, 0x68, 0x70, 0x61, 0x18, 0x50, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0f, 0x77,
+	0x69, 0x6e, 0x64, 0x53, 0x70, 0x65, 0x65, 0x64, 0x38, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12, 0x2a,
+	0x0a, 0x11, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x5f, 0x37, 0x30, 0x30,
+	0x68, 0x70, 0x61, 0x18, 0x51, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0f, 0x77, 0x69, 0x6e, 0x64, 0x53,
+	0x70, 0x65, 0x65, 0x64, 0x37, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12, 0x2a, 0x0a, 0x11, 0x77, 0x69,
+	0x6e, 0x64, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x5f, 0x36, 0x30, 0x30, 0x68, 0x70, 0x61, 0x18,
+	0x52, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0f, 0x77, 0x69, 0x6e, 0x64, 0x53, 0x70, 0x65, 0x65, 0x64,
+	0x36, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12, 0x2a, 0x0a, 0x11, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x73,
+	0x70, 0x65, 0x65, 0x64, 0x5f, 0x35, 0x30, 0x30, 0x68, 0x70, 0x61, 0x18, 0x53, 0x20, 0x03, 0x28,
+	0x01, 0x52, 0x0f, 0x77, 0x69, 0x6e, 0x64, 0x53, 0x70, 0x65, 0x65, 0x64, 0x35, 0x30, 0x30, 0x68,
+	0x70, 0x61, 0x12, 0x2a, 0x0a, 0x11, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64,
+	0x5f, 0x34, 0x30, 0x30, 0x68, 0x70, 0x61, 0x18, 0x54, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0f, 0x77,
+	0x69, 0x6e, 0x64, 0x53, 0x70, 0x65, 0x65, 0x64, 0x34, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12, 0x34,
+	0x0a, 0x16, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x31, 0x30, 0x30, 0x30, 0x68, 0x70, 0x61, 0x18, 0x55, 0x20, 0x03, 0x28, 0x05, 0x52, 0x14,
+	0x77, 0x69, 0x6e, 0x64, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x31, 0x30, 0x30,
+	0x30, 0x68, 0x70, 0x61, 0x12, 0x32, 0x0a, 0x15, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x64, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x39, 0x37, 0x35, 0x68, 0x70, 0x61, 0x18, 0x56, 0x20,
+	0x03, 0x28, 0x05, 0x52, 0x13, 0x77, 0x69, 0x6e, 0x64, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x39, 0x37, 0x35, 0x68, 0x70, 0x61, 0x12, 0x32, 0x0a, 0x15, 0x77, 0x69, 0x6e, 0x64,
+	0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x39, 0x35, 0x30, 0x68, 0x70,
+	0x61, 0x18, 0x57, 0x20, 0x03, 0x28, 0x05, 0x52, 0x13, 0x77, 0x69, 0x6e, 0x64, 0x44, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x39, 0x35, 0x30, 0x68, 0x70, 0x61, 0x12, 0x32, 0x0a, 0x15,
+	0x77, 0x69, 0x6e, 0x64, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x39,
+	0x32, 0x35, 0x68, 0x70, 0x61, 0x18, 0x58, 0x20, 0x03, 0x28, 0x05, 0x52, 0x13, 0x77, 0x69, 0x6e,
+	0x64, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x39, 0x32, 0x35, 0x68, 0x70, 0x61,
+	0x12, 0x32, 0x0a, 0x15, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x39, 0x30, 0x30, 0x68, 0x70, 0x61, 0x18, 0x59, 0x20, 0x03, 0x28, 0x05, 0x52,
+	0x13, 0x77, 0x69, 0x6e, 0x64, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x39, 0x30,
+	0x30, 0x68, 0x70, 0x61, 0x12, 0x32, 0x0a, 0x15, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x64, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x38, 0x35, 0x30, 0x68, 0x70, 0x61, 0x18, 0x5a, 0x20,
+	0x03, 0x28, 0x05, 0x52, 0x13, 0x77, 0x69, 0x6e, 0x64, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x38, 0x35, 0x30, 0x68, 0x70, 0x61, 0x12, 0x32, 0x0a, 0x15, 0x77, 0x69, 0x6e, 0x64,
+	0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x38, 0x30, 0x30, 0x68, 0x70,
+	0x61, 0x18, 0x5b, 0x20, 0x03, 0x28, 0x05, 0x52, 0x13, 0x77, 0x69, 0x6e, 0x64, 0x44, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x38, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12, 0x32, 0x0a, 0x15,
+	0x77, 0x69, 0x6e, 0x64, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x37,
+	0x30, 0x30, 0x68, 0x70, 0x61, 0x18, 0x5c, 0x20, 0x03, 0x28, 0x05, 0x52, 0x13, 0x77, 0x69, 0x6e,
+	0x64, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x37, 0x30, 0x30, 0x68, 0x70, 0x61,
+	0x12, 0x32, 0x0a, 0x15, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x36, 0x30, 0x30, 0x68, 0x70, 0x61, 0x18, 0x5d, 0x20, 0x03, 0x28, 0x05, 0x52,
+	0x13, 0x77, 0x69, 0x6e, 0x64, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x36, 0x30,
+	0x30, 0x68, 0x70, 0x61, 0x12, 0x32, 0x0a, 0x15, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x64, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x35, 0x30, 0x30, 0x68, 0x70, 0x61, 0x18, 0x5e, 0x20,
+	0x03, 0x28, 0x05, 0x52, 0x13, 0x77, 0x69, 0x6e, 0x64, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x35, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12, 0x32, 0x0a, 0x15, 0x77, 0x69, 0x6e, 0x64,
+	0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x34, 0x30, 0x30, 0x68, 0x70,
+	0x61, 0x18, 0x5f, 0x20, 0x03, 0x28, 0x05, 0x52, 0x13, 0x77, 0x69, 0x6e, 0x64, 0x44, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x34, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12, 0x3e, 0x0a, 0x1b,
+	0x67, 0x65, 0x6f, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x68, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x5f, 0x31, 0x30, 0x30, 0x30, 0x68, 0x70, 0x61, 0x18, 0x60, 0x20, 0x03, 0x28,
+	0x01, 0x52, 0x19, 0x67, 0x65, 0x6f, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x48,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x31, 0x30, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12, 0x3c, 0x0a, 0x1a,
+	0x67, 0x65, 0x6f, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x68, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x5f, 0x39, 0x37, 0x35, 0x68, 0x70, 0x61, 0x18, 0x61, 0x20, 0x03, 0x28, 0x01,
+	0x52, 0x18, 0x67, 0x65, 0x6f, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x48, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x39, 0x37, 0x35, 0x68, 0x70, 0x61, 0x12, 0x3c, 0x0a, 0x1a, 0x67, 0x65,
+	0x6f, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x5f, 0x39, 0x35, 0x30, 0x68, 0x70, 0x61, 0x18, 0x62, 0x20, 0x03, 0x28, 0x01, 0x52, 0x18,
+	0x67, 0x65, 0x6f, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x48, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x39, 0x35, 0x30, 0x68, 0x70, 0x61, 0x12, 0x3c, 0x0a, 0x1a, 0x67, 0x65, 0x6f, 0x70,
+	0x6f, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x5f,
+	0x39, 0x32, 0x35, 0x68, 0x70, 0x61, 0x18, 0x63, 0x20, 0x03, 0x28, 0x01, 0x52, 0x18, 0x67, 0x65,
+	0x6f, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x39, 0x32, 0x35, 0x68, 0x70, 0x61, 0x12, 0x3c, 0x0a, 0x1a, 0x67, 0x65, 0x6f, 0x70, 0x6f, 0x74,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x39, 0x30,
+	0x30, 0x68, 0x70, 0x61, 0x18, 0x64, 0x20, 0x03, 0x28, 0x01, 0x52, 0x18, 0x67, 0x65, 0x6f, 0x70,
+	0x6f, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x39, 0x30,
+	0x30, 0x68, 0x70, 0x61, 0x12, 0x3c, 0x0a, 0x1a, 0x67, 0x65, 0x6f, 0x70, 0x6f, 0x74, 0x65, 0x6e,
+	0x74, 0x69, 0x61, 0x6c, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x38, 0x35, 0x30, 0x68,
+	0x70, 0x61, 0x18, 0x65, 0x20, 0x03, 0x28, 0x01, 0x52, 0x18, 0x67, 0x65, 0x6f, 0x70, 0x6f, 0x74,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x38, 0x35, 0x30, 0x68,
+	0x70, 0x61, 0x12, 0x3c, 0x0a, 0x1a, 0x67, 0x65, 0x6f, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x69,
+	0x61, 0x6c, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x38, 0x30, 0x30, 0x68, 0x70, 0x61,
+	0x18, 0x66, 0x20, 0x03, 0x28, 0x01, 0x52, 0x18, 0x67, 0x65, 0x6f, 0x70, 0x6f, 0x74, 0x65, 0x6e,
+	0x74, 0x69, 0x61, 0x6c, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x38, 0x30, 0x30, 0x68, 0x70, 0x61,
+	0x12, 0x3c, 0x0a, 0x1a, 0x67, 0x65, 0x6f, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c,
+	0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x37, 0x30, 0x30, 0x68, 0x70, 0x61, 0x18, 0x67,
+	0x20, 0x03, 0x28, 0x01, 0x52, 0x18, 0x67, 0x65, 0x6f, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x69,
+	0x61, 0x6c, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x37, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12, 0x3c,
+	0x0a, 0x1a, 0x67, 0x65, 0x6f, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x68,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x36, 0x30, 0x30, 0x68, 0x70, 0x61, 0x18, 0x68, 0x20, 0x03,
+	0x28, 0x01, 0x52, 0x18, 0x67, 0x65, 0x6f, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c,
+	0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x36, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12, 0x3c, 0x0a, 0x1a,
+	0x67, 0x65, 0x6f, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x68, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x5f, 0x35, 0x30, 0x30, 0x68, 0x70, 0x61, 0x18, 0x69, 0x20, 0x03, 0x28, 0x01,
+	0x52, 0x18, 0x67, 0x65, 0x6f, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x48, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x35, 0x30, 0x30, 0x68, 0x70, 0x61, 0x12, 0x3c, 0x0a, 0x1a, 0x67, 0x65,
+	0x6f, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x5f, 0x34, 0x30, 0x30, 0x68, 0x70, 0x61, 0x18, 0x6a, 0x20, 0x03, 0x28, 0x01, 0x52, 0x18,
+	0x67, 0x65, 0x6f, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x48, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x34, 0x30, 0x30, 0x68, 0x70, 0x61, 0x22, 0xa5, 0x07, 0x0a, 0x09, 0x44, 0x61, 0x69,
+	0x6c, 0x79, 0x44, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x65,
+	0x61, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x05,
+	0x52, 0x0b, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x2c, 0x0a,
+	0x12, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x32, 0x6d, 0x5f,
+	0x6d, 0x61, 0x78, 0x18, 0x03, 0x20, 0x03, 0x28, 0x01, 0x52, 0x10, 0x74, 0x65, 0x6d, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x32, 0x6d, 0x4d, 0x61, 0x78, 0x12, 0x2c, 0x0a, 0x12, 0x74,
+	0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x32, 0x6d, 0x5f, 0x6d, 0x69,
+	0x6e, 0x18, 0x04, 0x20, 0x03, 0x28, 0x01, 0x52, 0x10, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x32, 0x6d, 0x4d, 0x69, 0x6e, 0x12, 0x38, 0x0a, 0x18, 0x61, 0x70, 0x70,
+	0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x05, 0x20, 0x03, 0x28, 0x01, 0x52, 0x16, 0x61, 0x70, 0x70,
+	0x61, 0x72, 0x65, 0x6e, 0x74, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x4d, 0x61, 0x78, 0x12, 0x38, 0x0a, 0x18, 0x61, 0x70, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f,
+	0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x6d, 0x69, 0x6e, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x01, 0x52, 0x16, 0x61, 0x70, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x54,
+	0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x4d, 0x69, 0x6e, 0x12, 0x18, 0x0a,
+	0x07, 0x73, 0x75, 0x6e, 0x72, 0x69, 0x73, 0x65, 0x18, 0x07, 0x20, 0x03, 0x28, 0x03, 0x52, 0x07,
+	0x73, 0x75, 0x6e, 0x72, 0x69, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x75, 0x6e, 0x73, 0x65,
+	0x74, 0x18, 0x08, 0x20, 0x03, 0x28, 0x03, 0x52, 0x06, 0x73, 0x75, 0x6e, 0x73, 0x65, 0x74, 0x12,
+	0x2b, 0x0a, 0x11, 0x64, 0x61, 0x79, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x03, 0x28, 0x01, 0x52, 0x10, 0x64, 0x61, 0x79, 0x6c,
+	0x69, 0x67, 0x68, 0x74, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x11,
+	0x73, 0x75, 0x6e, 0x73, 0x68, 0x69, 0x6e, 0x65, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x01, 0x52, 0x10, 0x73, 0x75, 0x6e, 0x73, 0x68, 0x69, 0x6e,
+	0x65, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0c, 0x75, 0x76, 0x5f,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x01, 0x52,
+	0x0a, 0x75, 0x76, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4d, 0x61, 0x78, 0x12, 0x32, 0x0a, 0x16, 0x75,
+	0x76, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x5f, 0x73, 0x6b,
+	0x79, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x01, 0x52, 0x12, 0x75, 0x76, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x53, 0x6b, 0x79, 0x4d, 0x61, 0x78, 0x12,
+	0x2b, 0x0a, 0x11, 0x70, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x73, 0x75, 0x6d, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x01, 0x52, 0x10, 0x70, 0x72, 0x65, 0x63,
+	0x69, 0x70, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x75, 0x6d, 0x12, 0x2f, 0x0a, 0x13,
+	0x70, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x6f,
+	0x75, 0x72, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x01, 0x52, 0x12, 0x70, 0x72, 0x65, 0x63, 0x69,
+	0x70, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x12, 0x42, 0x0a,
+	0x1d, 0x70, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70,
+	0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x0f,
+	0x20, 0x03, 0x28, 0x05, 0x52, 0x1b, 0x70, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x4d, 0x61,
+	0x78, 0x12, 0x2b, 0x0a, 0x12, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x5f,
+	0x31, 0x30, 0x6d, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x10, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0f, 0x77,
+	0x69, 0x6e, 0x64, 0x53, 0x70, 0x65, 0x65, 0x64, 0x31, 0x30, 0x6d, 0x4d, 0x61, 0x78, 0x12, 0x2b,
+	0x0a, 0x12, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x67, 0x75, 0x73, 0x74, 0x73, 0x5f, 0x31, 0x30, 0x6d,
+	0x5f, 0x6d, 0x61, 0x78, 0x18, 0x11, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0f, 0x77, 0x69, 0x6e, 0x64,
+	0x47, 0x75, 0x73, 0x74, 0x73, 0x31, 0x30, 0x6d, 0x4d, 0x61, 0x78, 0x12, 0x3d, 0x0a, 0x1b, 0x77,
+	0x69, 0x6e, 0x64, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x31, 0x30,
+	0x6d, 0x5f, 0x64, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x12, 0x20, 0x03, 0x28, 0x05,
+	0x52, 0x18, 0x77, 0x69, 0x6e, 0x64, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x31,
+	0x30, 0x6d, 0x44, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x6e, 0x74, 0x12, 0x36, 0x0a, 0x17, 0x73, 0x68,
+	0x6f, 0x72, 0x74, 0x77, 0x61, 0x76, 0x65, 0x5f, 0x72, 0x61, 0x64, 0x69, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x73, 0x75, 0x6d, 0x18, 0x13, 0x20, 0x03, 0x28, 0x01, 0x52, 0x15, 0x73, 0x68, 0x6f,
+	0x72, 0x74, 0x77, 0x61, 0x76, 0x65, 0x52, 0x61, 0x64, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x75, 0x6d, 0x12, 0x3c, 0x0a, 0x1a, 0x65, 0x74, 0x30, 0x5f, 0x66, 0x61, 0x6f, 0x5f, 0x65, 0x76,
+	0x61, 0x70, 0x6f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x14, 0x20, 0x03, 0x28, 0x01, 0x52, 0x18, 0x65, 0x74, 0x30, 0x46, 0x61, 0x6f, 0x45, 0x76,
+	0x61, 0x70, 0x6f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x22, 0xe5, 0x01, 0x0a, 0x0e, 0x41, 0x69, 0x72, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x44,
+	0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x13, 0x0a, 0x05, 0x70, 0x6d, 0x32, 0x5f, 0x35,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x01, 0x52, 0x04, 0x70, 0x6d, 0x32, 0x35, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x6d, 0x31, 0x30, 0x18, 0x03, 0x20, 0x03, 0x28, 0x01, 0x52, 0x04, 0x70, 0x6d, 0x31, 0x30,
+	0x12, 0x12, 0x0a, 0x04, 0x64, 0x75, 0x73, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x01, 0x52, 0x04,
+	0x64, 0x75, 0x73, 0x74, 0x12, 0x32, 0x0a, 0x15, 0x61, 0x65, 0x72, 0x6f, 0x73, 0x6f, 0x6c, 0x5f,
+	0x6f, 0x70, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x01, 0x52, 0x13, 0x61, 0x65, 0x72, 0x6f, 0x73, 0x6f, 0x6c, 0x4f, 0x70, 0x74, 0x69,
+	0x63, 0x61, 0x6c, 0x44, 0x65, 0x70, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x7a, 0x6f, 0x6e,
+	0x65, 0x18, 0x06, 0x20, 0x03, 0x28, 0x01, 0x52, 0x05, 0x6f, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x21,
+	0x0a, 0x0c, 0x65, 0x75, 0x72, 0x6f, 0x70, 0x65, 0x61, 0x6e, 0x5f, 0x61, 0x71, 0x69, 0x18, 0x07,
+	0x20, 0x03, 0x28, 0x05, 0x52, 0x0b, 0x65, 0x75, 0x72, 0x6f, 0x70, 0x65, 0x61, 0x6e, 0x41, 0x71,
+	0x69, 0x12, 0x15, 0x0a, 0x06, 0x75, 0x73, 0x5f, 0x61, 0x71, 0x69, 0x18, 0x08, 0x20, 0x03, 0x28,
+	0x05, 0x52, 0x05, 0x75, 0x73, 0x41, 0x71, 0x69, 0x22, 0xcf, 0x03, 0x0a, 0x0a, 0x4d, 0x61, 0x72,
+	0x69, 0x6e, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x77,
+	0x61, 0x76, 0x65, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x01,
+	0x52, 0x0a, 0x77, 0x61, 0x76, 0x65, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x25, 0x0a, 0x0e,
+	0x77, 0x61, 0x76, 0x65, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x05, 0x52, 0x0d, 0x77, 0x61, 0x76, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x77, 0x61, 0x76, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x69,
+	0x6f, 0x64, 0x18, 0x04, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0a, 0x77, 0x61, 0x76, 0x65, 0x50, 0x65,
+	0x72, 0x69, 0x6f, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x77, 0x61, 0x76,
+	0x65, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0e,
+	0x77, 0x69, 0x6e, 0x64, 0x57, 0x61, 0x76, 0x65, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x2e,
+	0x0a, 0x13, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x77, 0x61, 0x76, 0x65, 0x5f, 0x64, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x03, 0x28, 0x05, 0x52, 0x11, 0x77, 0x69, 0x6e,
+	0x64, 0x57, 0x61, 0x76, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x28,
+	0x0a, 0x10, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x77, 0x61, 0x76, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x69,
+	0x6f, 0x64, 0x18, 0x07, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0e, 0x77, 0x69, 0x6e, 0x64, 0x57, 0x61,
+	0x76, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x73, 0x77, 0x65, 0x6c,
+	0x6c, 0x5f, 0x77, 0x61, 0x76, 0x65, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x08, 0x20,
+	0x03, 0x28, 0x01, 0x52, 0x0f, 0x73, 0x77, 0x65, 0x6c, 0x6c, 0x57, 0x61, 0x76, 0x65, 0x48, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x73, 0x77, 0x65, 0x6c, 0x6c, 0x5f, 0x77, 0x61,
+	0x76, 0x65, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x03,
+	0x28, 0x05, 0x52, 0x12, 0x73, 0x77, 0x65, 0x6c, 0x6c, 0x57, 0x61, 0x76, 0x65, 0x44, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x11, 0x73, 0x77, 0x65, 0x6c, 0x6c, 0x5f,
+	0x77, 0x61, 0x76, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x0a, 0x20, 0x03, 0x28,
+	0x01, 0x52, 0x0f, 0x73, 0x77, 0x65, 0x6c, 0x6c, 0x57, 0x61, 0x76, 0x65, 0x50, 0x65, 0x72, 0x69,
+	0x6f, 0x64, 0x12, 0x36, 0x0a, 0x17, 0x73, 0x65, 0x61, 0x5f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63,
+	0x65, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x0b, 0x20,
+	0x03, 0x28, 0x01, 0x52, 0x15, 0x73, 0x65, 0x61, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x54,
+	0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x32, 0xa3, 0x01, 0x0a, 0x08, 0x46,
+	0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x12, 0x44, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x46, 0x6f,
+	0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x12, 0x19, 0x2e, 0x6d, 0x75, 0x6e, 0x63, 0x68, 0x2e, 0x76,
+	0x31, 0x2e, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1a, 0x2e, 0x6d, 0x75, 0x6e, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f, 0x72,
+	0x65, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a,
+	0x0f, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x73,
+	0x12, 0x20, 0x2e, 0x6d, 0x75, 0x6e, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x6d, 0x75, 0x6e, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f,
+	0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01,
+	0x42, 0x2b, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74,
+	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x73, 0x68, 0x61, 0x63, 0x6b, 0x2f, 0x6d, 0x65, 0x74, 0x65, 0x6f,
+	0x6d, 0x75, 0x6e, 0x63, 0x68, 0x2f, 0x6d, 0x75, 0x6e, 0x63, 0x68, 0x70, 0x62, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_forecast_proto_rawDescOnce sync.Once
+	file_forecast_proto_rawDescData = file_forecast_proto_rawDesc
+)
+
+func file_forecast_proto_rawDescGZIP() []byte {
+	file_forecast_proto_rawDescOnce.Do(func() {
+		file_forecast_proto_rawDescData = protoimpl.X.CompressGZIP(file_forecast_proto_rawDescData)
+	})
+	return file_forecast_proto_rawDescData
+}
+
+var file_forecast_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_forecast_proto_goTypes = []any{
+	(*Coordinates)(nil),            // 0: munch.v1.Coordinates
+	(*ForecastRequest)(nil),        // 1: munch.v1.ForecastRequest
+	(*StreamForecastsRequest)(nil), // 2: munch.v1.StreamForecastsRequest
+	(*FetchOptions)(nil),           // 3: munch.v1.FetchOptions
+	(*ForecastResponse)(nil),       // 4: munch.v1.ForecastResponse
+	(*BaseData)(nil),               // 5: munch.v1.BaseData
+	(*CurrentData)(nil),            // 6: munch.v1.CurrentData
+	(*HourlyData)(nil),             // 7: munch.v1.HourlyData
+	(*DailyData)(nil),              // 8: munch.v1.DailyData
+	(*AirQualityData)(nil),         // 9: munch.v1.AirQualityData
+	(*MarineData)(nil),             // 10: munch.v1.MarineData
+	nil,                            // 11: munch.v1.BaseData.UnitsEntry
+}
+var file_forecast_proto_depIdxs = []int32{
+	0,  // 0: munch.v1.ForecastRequest.coordinates:type_name -> munch.v1.Coordinates
+	3,  // 1: munch.v1.ForecastRequest.options:type_name -> munch.v1.FetchOptions
+	0,  // 2: munch.v1.StreamForecastsRequest.coordinates:type_name -> munch.v1.Coordinates
+	3,  // 3: munch.v1.StreamForecastsRequest.options:type_name -> munch.v1.FetchOptions
+	0,  // 4: munch.v1.ForecastResponse.coordinates:type_name -> munch.v1.Coordinates
+	5,  // 5: munch.v1.ForecastResponse.data:type_name -> munch.v1.BaseData
+	6,  // 6: munch.v1.BaseData.current:type_name -> munch.v1.CurrentData
+	7,  // 7: munch.v1.BaseData.hourly:type_name -> munch.v1.HourlyData
+	8,  // 8: munch.v1.BaseData.daily:type_name -> munch.v1.DailyData
+	9,  // 9: munch.v1.BaseData.air_quality:type_name -> munch.v1.AirQualityData
+	10, // 10: munch.v1.BaseData.marine:type_name -> munch.v1.MarineData
+	11, // 11: munch.v1.BaseData.units:type_name -> munch.v1.BaseData.UnitsEntry
+	1,  // 12: munch.v1.Forecast.GetForecast:input_type -> munch.v1.ForecastRequest
+	2,  // 13: munch.v1.Forecast.StreamForecasts:input_type -> munch.v1.StreamForecastsRequest
+	4,  // 14: munch.v1.Forecast.GetForecast:output_type -> munch.v1.ForecastResponse
+	4,  // 15: munch.v1.Forecast.StreamForecasts:output_type -> munch.v1.ForecastResponse
+	14, // [14:16] is the sub-list for method output_type
+	12, // [12:14] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_forecast_proto_init() }
+func file_forecast_proto_init() {
+	if File_forecast_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_forecast_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_forecast_proto_goTypes,
+		DependencyIndexes: file_forecast_proto_depIdxs,
+		MessageInfos:      file_forecast_proto_msgTypes,
+	}.Build()
+	File_forecast_proto = out.File
+	file_forecast_proto_rawDesc = nil
+	file_forecast_proto_goTypes = nil
+	file_forecast_proto_depIdxs = nil
+}
@@ -0,0 +1,179 @@
+// Protobuf schema for the munch gRPC transport.
+//
+// The data messages mirror the plumber package structures field for field, and
+// the field names match their JSON tags so that both transports read the same.
+// Regenerate the Go code with `go generate ./munchpb` after editing this file.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: forecast.proto
+
+package munchpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Forecast_GetForecast_FullMethodName     = "/munch.v1.Forecast/GetForecast"
+	Forecast_StreamForecasts_FullMethodName = "/munch.v1.Forecast/StreamForecasts"
+)
+
+// ForecastClient is the client API for Forecast service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Forecast serves meteo data munched from the configured providers
+type ForecastClient interface {
+	// GetForecast returns the forecast for a single location
+	GetForecast(ctx context.Context, in *ForecastRequest, opts ...grpc.CallOption) (*ForecastResponse, error)
+	// StreamForecasts fetches the forecast for each of the given locations and
+	// streams them back in the order they complete
+	StreamForecasts(ctx context.Context, in *StreamForecastsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ForecastResponse], error)
+}
+
+type forecastClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewForecastClient(cc grpc.ClientConnInterface) ForecastClient {
+	return &forecastClient{cc}
+}
+
+func (c *forecastClient) GetForecast(ctx context.Context, in *ForecastRequest, opts ...grpc.CallOption) (*ForecastResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ForecastResponse)
+	err := c.cc.Invoke(ctx, Forecast_GetForecast_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forecastClient) StreamForecasts(ctx context.Context, in *StreamForecastsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ForecastResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Forecast_ServiceDesc.Streams[0], Forecast_StreamForecasts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamForecastsRequest, ForecastResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Forecast_StreamForecastsClient = grpc.ServerStreamingClient[ForecastResponse]
+
+// ForecastServer is the server API for Forecast service.
+// All implementations must embed UnimplementedForecastServer
+// for forward compatibility.
+//
+// Forecast serves meteo data munched from the configured providers
+type ForecastServer interface {
+	// GetForecast returns the forecast for a single location
+	GetForecast(context.Context, *ForecastRequest) (*ForecastResponse, error)
+	// StreamForecasts fetches the forecast for each of the given locations and
+	// streams them back in the order they complete
+	StreamForecasts(*StreamForecastsRequest, grpc.ServerStreamingServer[ForecastResponse]) error
+	mustEmbedUnimplementedForecastServer()
+}
+
+// UnimplementedForecastServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedForecastServer struct{}
+
+func (UnimplementedForecastServer) GetForecast(context.Context, *ForecastRequest) (*ForecastResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetForecast not implemented")
+}
+func (UnimplementedForecastServer) StreamForecasts(*StreamForecastsRequest, grpc.ServerStreamingServer[ForecastResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamForecasts not implemented")
+}
+func (UnimplementedForecastServer) mustEmbedUnimplementedForecastServer() {}
+func (UnimplementedForecastServer) testEmbeddedByValue()                  {}
+
+// UnsafeForecastServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ForecastServer will
+// result in compilation errors.
+type UnsafeForecastServer interface {
+	mustEmbedUnimplementedForecastServer()
+}
+
+func RegisterForecastServer(s grpc.ServiceRegistrar, srv ForecastServer) {
+	// If the following call pancis, it indicates UnimplementedForecastServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Forecast_ServiceDesc, srv)
+}
+
+func _Forecast_GetForecast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForecastRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForecastServer).GetForecast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Forecast_GetForecast_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForecastServer).GetForecast(ctx, req.(*ForecastRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Forecast_StreamForecasts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamForecastsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ForecastServer).StreamForecasts(m, &grpc.GenericServerStream[StreamForecastsRequest, ForecastResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Forecast_StreamForecastsServer = grpc.ServerStreamingServer[ForecastResponse]
+
+// Forecast_ServiceDesc is the grpc.ServiceDesc for Forecast service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Forecast_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "munch.v1.Forecast",
+	HandlerType: (*ForecastServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetForecast",
+			Handler:    _Forecast_GetForecast_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamForecasts",
+			Handler:       _Forecast_StreamForecasts_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "forecast.proto",
+}
@@ -0,0 +1,6 @@
+// Package munchpb holds the protobuf messages and gRPC service definitions for munch.
+//
+// The Go code is generated from forecast.proto with buf, protoc-gen-go and protoc-gen-go-grpc.
+package munchpb
+
+//go:generate buf generate
@@ -0,0 +1,48 @@
+package plumber
+
+import (
+	"reflect"
+	"slices"
+)
+
+// AirQualityData holds the hourly air quality; dust and smoke haze the sky and dampen the heating that drives thermals
+type AirQualityData struct {
+	Time                []int64   `json:"time"`                  // Unix timestamps
+	PM2_5               []float64 `json:"pm2_5"`                 // Particulate matter below 2.5 µm in µg/m³
+	PM10                []float64 `json:"pm10"`                  // Particulate matter below 10 µm in µg/m³
+	Dust                []float64 `json:"dust"`                  // Saharan and other mineral dust in µg/m³
+	AerosolOpticalDepth []float64 `json:"aerosol_optical_depth"` // Haze of the whole column at 550 nm, dimensionless
+	Ozone               []float64 `json:"ozone"`                 // Ozone at ground level in µg/m³
+	EuropeanAQI         []int     `json:"european_aqi"`          // European Air Quality Index, 0 to 100+
+	USAQI               []int     `json:"us_aqi"`                // United States Air Quality Index, 0 to 500
+}
+
+// AirQualityFields lists the JSON names of the air quality fields, excluding the time axis
+func AirQualityFields() []string {
+	return seriesFields(reflect.TypeOf(AirQualityData{}))
+}
+
+// Index returns the position of the Unix timestamp t in the air quality time axis, or -1 if it isn't on it
+func (a *AirQualityData) Index(t int64) int {
+	return slices.Index(a.Time, t)
+}
+
+// SetAirQuality attaches the air quality to the data, aligned with its hourly time axis
+//
+// Only the hours on the hourly time axis are kept, in its order, so that AirQuality.Time is the stretch of
+// Hourly.Time that the air quality covers. Without an hourly time axis the air quality is attached as is.
+func (bd *BaseData) SetAirQuality(aq *AirQualityData) {
+	if aq == nil || len(bd.Hourly.Time) == 0 {
+		bd.AirQuality = aq
+		return
+	}
+	var positions []int
+	for _, t := range bd.Hourly.Time {
+		if i := aq.Index(t); i >= 0 {
+			positions = append(positions, i)
+		}
+	}
+	aligned := new(AirQualityData)
+	pickSeries(reflect.ValueOf(aligned).Elem(), reflect.ValueOf(aq).Elem(), positions)
+	bd.AirQuality = aligned
+}
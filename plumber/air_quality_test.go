@@ -0,0 +1,48 @@
+package plumber
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSetAirQuality(t *testing.T) {
+	aq := &AirQualityData{
+		Time:        []int64{0, 3600, 7200, 10800},
+		PM10:        []float64{10, 11, 12, 13},
+		Dust:        []float64{1, 2}, // Not along the whole time axis
+		EuropeanAQI: []int{20, 21, 22, 23},
+	}
+
+	tests := []struct {
+		name   string
+		hourly []int64
+		want   *AirQualityData
+	}{
+		{"same hours", []int64{0, 3600, 7200, 10800}, &AirQualityData{
+			Time: []int64{0, 3600, 7200, 10800}, PM10: []float64{10, 11, 12, 13}, EuropeanAQI: []int{20, 21, 22, 23},
+		}},
+		{"stretch of the hours", []int64{3600, 7200}, &AirQualityData{
+			Time: []int64{3600, 7200}, PM10: []float64{11, 12}, EuropeanAQI: []int{21, 22},
+		}},
+		{"beyond the air quality", []int64{7200, 10800, 14400, 18000}, &AirQualityData{
+			Time: []int64{7200, 10800}, PM10: []float64{12, 13}, EuropeanAQI: []int{22, 23},
+		}},
+		{"no hours in common", []int64{1800}, &AirQualityData{Time: []int64{}, PM10: []float64{}, EuropeanAQI: []int{}}},
+		{"no hourly time axis", nil, aq},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bd := BaseData{Hourly: HourlyData{Time: tt.hourly}}
+			bd.SetAirQuality(aq)
+			if !reflect.DeepEqual(bd.AirQuality, tt.want) {
+				t.Errorf("AirQuality = %+v, want %+v", bd.AirQuality, tt.want)
+			}
+		})
+	}
+
+	bd := BaseData{Hourly: HourlyData{Time: []int64{0}}}
+	bd.SetAirQuality(nil)
+	if bd.AirQuality != nil {
+		t.Errorf("AirQuality = %+v, want nil", bd.AirQuality)
+	}
+}
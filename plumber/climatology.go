@@ -0,0 +1,307 @@
+package plumber
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// DefaultClimateFields are the hourly fields a Climatology averages when none are asked for
+var DefaultClimateFields = []string{"temperature_2m", "relative_humidity_2m", "precipitation", "cloud_cover", "sunshine_duration", "wind_speed_10m", "wind_gusts_10m", "boundary_layer_height"}
+
+// ClimatePercentiles are the percentiles of the bands of a Climatology
+var ClimatePercentiles = []float64{10, 50, 90}
+
+// ThermalTopPercentiles are the percentiles of the thermal tops of a Climatology, the typical range of a soarable day
+var ThermalTopPercentiles = []float64{25, 50, 75}
+
+// WindSpeedBins are the upper bounds, in km/h, of the speed classes of a WindRose; the last class is open ended
+var WindSpeedBins = []float64{10, 20, 30}
+
+const (
+	// MinFlyableHours is how many flyable hours make a day flyable
+	MinFlyableHours = 3
+	// calmWind is the wind speed in km/h below which a WindRose doesn't count the direction
+	calmWind = 2
+	// windSectors is the number of compass sectors of a WindRose
+	windSectors = 16
+)
+
+// Climatology summarises the weather of a site over years of historical data
+//
+// Months, days and hours of the day are local to the data's UTC offset.
+type Climatology struct {
+	Start   int64          `json:"start"` // First hour covered, Unix timestamp
+	End     int64          `json:"end"`   // Last hour covered
+	Hours   int            `json:"hours"`
+	Days    int            `json:"days"`
+	Monthly []MonthClimate `json:"monthly"`   // Calendar months with data, January first
+	Diurnal []HourClimate  `json:"diurnal"`   // Hours of the day with data, midnight first
+	Wind    *WindRose      `json:"wind_rose"` // Over the whole period, nil without wind data
+}
+
+// MonthClimate is the climate of a calendar month
+type MonthClimate struct {
+	Month int                `json:"month"` // 1 for January
+	Hours int                `json:"hours"`
+	Days  int                `json:"days"`
+	Means map[string]float64 `json:"means"` // Mean of every hourly field
+	// Values of ClimatePercentiles per hourly field, e.g. "temperature_2m": {"p10": 2.1, "p50": 7.4, "p90": 13.2}
+	Percentiles map[string]map[string]float64 `json:"percentiles"`
+	// Share of the days with at least MinFlyableHours flyable hours, nil without boundary layer height
+	FlyableDays *float64 `json:"flyable_days"`
+	// Values of ThermalTopPercentiles of the highest usable thermal ceiling, above mean sea level in m,
+	// of the days with thermals worth soaring
+	ThermalTop map[string]float64 `json:"thermal_top,omitempty"`
+	Wind       *WindRose          `json:"wind_rose"`
+}
+
+// HourClimate is the climate of an hour of the day
+type HourClimate struct {
+	Hour  int                `json:"hour"`  // 0 for midnight to 1 am
+	Means map[string]float64 `json:"means"` // Mean of every hourly field
+}
+
+// WindRose is the frequency of the wind at 10 m by direction and speed
+type WindRose struct {
+	SpeedBins  []float64    `json:"speed_bins"` // WindSpeedBins
+	Sectors    []WindSector `json:"sectors"`    // windSectors of them, clockwise from north
+	Calm       float64      `json:"calm"`       // Share of the hours with wind below 2 km/h
+	Prevailing float64      `json:"prevailing"` // Direction of the most frequent sector in degrees
+}
+
+// WindSector is the wind from a sector of the compass
+type WindSector struct {
+	Direction float64   `json:"direction"` // Centre of the sector in degrees
+	Frequency float64   `json:"frequency"` // Share of all the hours with wind from the sector
+	Speeds    []float64 `json:"speeds"`    // Share of all the hours per speed class of WindSpeedBins
+}
+
+// Climatology computes the climate of the location of the data from its hourly fields, those of DefaultClimateFields
+// if none are given
+//
+// The flyable days and thermal tops come from the Soaring forecast of every hour against the limits, and are
+// left out if the data lacks the boundary layer height. Fields the data doesn't carry are left out as well.
+func (bd *BaseData) Climatology(fields []string, limits SoaringLimits) (*Climatology, error) {
+	h := &bd.Hourly
+	if len(h.Time) == 0 {
+		return nil, errors.New("no hourly data")
+	}
+	if len(fields) == 0 {
+		fields = DefaultClimateFields
+	}
+	for _, field := range fields {
+		if !IsField(BlockHourly, field) {
+			return nil, fmt.Errorf("%w: hourly.%s", ErrUnknownField, field)
+		}
+	}
+
+	soaring, err := bd.Soaring(limits)
+	if err != nil && !errors.Is(err, ErrNoBoundaryLayer) {
+		return nil, err
+	}
+
+	var months [12]monthAccumulator
+	var hours [24]map[string][]float64
+	days := make(map[int]bool)
+	rose := newWindAccumulator()
+	for i, t := range h.Time {
+		local := time.Unix(t+int64(bd.UTCOffsetSeconds), 0).UTC()
+		y, mo, d := local.Date()
+		day := y*10000 + int(mo)*100 + d
+		days[day] = true
+
+		m := &months[mo-1]
+		m.add(day)
+		if hours[local.Hour()] == nil {
+			hours[local.Hour()] = make(map[string][]float64)
+		}
+		for _, field := range fields {
+			if v, ok := h.Value(field, i); ok {
+				m.values[field] = append(m.values[field], v)
+				hours[local.Hour()][field] = append(hours[local.Hour()][field], v)
+			}
+		}
+		if len(h.WindSpeed10M) > i && len(h.WindDirection10M) > i {
+			rose.add(h.WindSpeed10M[i], float64(h.WindDirection10M[i]))
+			m.wind.add(h.WindSpeed10M[i], float64(h.WindDirection10M[i]))
+		}
+		if soaring != nil {
+			s := soaring[i]
+			if s.Flyable {
+				m.flyable[day]++
+			}
+			if s.ThermalStrength >= limits.MinThermal && s.ThermalCeiling > m.tops[day] {
+				m.tops[day] = s.ThermalCeiling
+			}
+		}
+	}
+
+	c := &Climatology{
+		Start: h.Time[0],
+		End:   h.Time[len(h.Time)-1],
+		Hours: len(h.Time),
+		Days:  len(days),
+		Wind:  rose.rose(),
+	}
+	for i := range months {
+		if months[i].hours == 0 {
+			continue
+		}
+		c.Monthly = append(c.Monthly, months[i].climate(i+1, soaring != nil))
+	}
+	for hour, values := range hours {
+		if values == nil {
+			continue
+		}
+		c.Diurnal = append(c.Diurnal, HourClimate{Hour: hour, Means: means(values)})
+	}
+	return c, nil
+}
+
+// monthAccumulator gathers the hours of a calendar month
+type monthAccumulator struct {
+	hours   int
+	days    map[int]bool
+	values  map[string][]float64
+	flyable map[int]int     // Flyable hours by day
+	tops    map[int]float64 // Highest thermal ceiling by day, of days with thermals worth soaring
+	wind    *windAccumulator
+}
+
+// add counts an hour of the day, initialising the accumulator on the first
+func (m *monthAccumulator) add(day int) {
+	if m.hours == 0 {
+		m.days = make(map[int]bool)
+		m.values = make(map[string][]float64)
+		m.flyable = make(map[int]int)
+		m.tops = make(map[int]float64)
+		m.wind = newWindAccumulator()
+	}
+	m.hours++
+	m.days[day] = true
+}
+
+// climate returns the climate of the month; soaring tells whether the hours were scored for flyability
+func (m *monthAccumulator) climate(month int, soaring bool) MonthClimate {
+	c := MonthClimate{
+		Month:       month,
+		Hours:       m.hours,
+		Days:        len(m.days),
+		Means:       means(m.values),
+		Percentiles: make(map[string]map[string]float64, len(m.values)),
+		Wind:        m.wind.rose(),
+	}
+	for field, values := range m.values {
+		c.Percentiles[field] = percentiles(values, ClimatePercentiles)
+	}
+	if !soaring {
+		return c
+	}
+
+	flyable := 0
+	for _, n := range m.flyable {
+		if n >= MinFlyableHours {
+			flyable++
+		}
+	}
+	share := math.Round(float64(flyable)/float64(len(m.days))*1000) / 1000
+	c.FlyableDays = &share
+	if len(m.tops) > 0 {
+		tops := make([]float64, 0, len(m.tops))
+		for _, top := range m.tops {
+			tops = append(tops, top)
+		}
+		c.ThermalTop = percentiles(tops, ThermalTopPercentiles)
+	}
+	return c
+}
+
+// windAccumulator counts hours of wind by sector and speed class
+type windAccumulator struct {
+	hours  int
+	calm   int
+	counts [windSectors][]int
+}
+
+func newWindAccumulator() *windAccumulator {
+	w := &windAccumulator{}
+	for i := range w.counts {
+		w.counts[i] = make([]int, len(WindSpeedBins)+1)
+	}
+	return w
+}
+
+// add counts an hour of wind of the given speed in km/h from the given direction in degrees
+func (w *windAccumulator) add(speed, direction float64) {
+	w.hours++
+	if speed < calmWind {
+		w.calm++
+		return
+	}
+	sector := int(math.Mod(direction+180.0/windSectors+360, 360) / (360.0 / windSectors))
+	bin := len(WindSpeedBins)
+	for i, upper := range WindSpeedBins {
+		if speed < upper {
+			bin = i
+			break
+		}
+	}
+	w.counts[sector%windSectors][bin]++
+}
+
+// rose returns the wind rose of the hours counted, nil if there are none
+func (w *windAccumulator) rose() *WindRose {
+	if w.hours == 0 {
+		return nil
+	}
+	share := func(n int) float64 {
+		return math.Round(float64(n)/float64(w.hours)*1000) / 1000
+	}
+	r := &WindRose{
+		SpeedBins: WindSpeedBins,
+		Sectors:   make([]WindSector, windSectors),
+		Calm:      share(w.calm),
+	}
+	most := -1
+	for i, counts := range w.counts {
+		total := 0
+		speeds := make([]float64, len(counts))
+		for bin, n := range counts {
+			total += n
+			speeds[bin] = share(n)
+		}
+		r.Sectors[i] = WindSector{Direction: float64(i) * 360 / windSectors, Frequency: share(total), Speeds: speeds}
+		if total > most {
+			most = total
+			r.Prevailing = r.Sectors[i].Direction
+		}
+	}
+	return r
+}
+
+// means returns the mean of the values of every field, rounded to 0.01
+func means(values map[string][]float64) map[string]float64 {
+	out := make(map[string]float64, len(values))
+	for field, v := range values {
+		sum := 0.0
+		for _, x := range v {
+			sum += x
+		}
+		out[field] = math.Round(sum/float64(len(v))*100) / 100
+	}
+	return out
+}
+
+// percentiles returns the given percentiles of the values, keyed p10, p50 and so on; values are sorted in place
+func percentiles(values []float64, ps []float64) map[string]float64 {
+	slices.Sort(values)
+	out := make(map[string]float64, len(ps))
+	for _, p := range ps {
+		out["p"+strconv.FormatFloat(p, 'f', -1, 64)] = percentile(values, p)
+	}
+	return out
+}
@@ -0,0 +1,177 @@
+package plumber
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWindAccumulator(t *testing.T) {
+	tests := []struct {
+		name      string
+		speed     float64
+		direction float64
+		sector    int // -1 for calm
+		bin       int
+	}{
+		{"north", 15, 0, 0, 1},
+		{"just west of north", 15, 348.75, 0, 1},
+		{"north-northwest", 15, 348.74, 15, 1},
+		{"just short of north-northeast", 15, 11.24, 0, 1},
+		{"north-northeast", 15, 11.25, 1, 1},
+		{"full circle", 15, 360, 0, 1},
+		{"south", 15, 180, 8, 1},
+		{"slowest class", 2, 90, 4, 0},
+		{"bin bound", 10, 90, 4, 1},
+		{"open class", 45, 270, 12, 3},
+		{"calm", 1.9, 90, -1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newWindAccumulator()
+			w.add(tt.speed, tt.direction)
+			if w.hours != 1 {
+				t.Errorf("hours = %d, want 1", w.hours)
+			}
+			if tt.sector < 0 {
+				if w.calm != 1 {
+					t.Errorf("calm hours = %d, want 1", w.calm)
+				}
+				return
+			}
+			for sector, counts := range w.counts {
+				for bin, n := range counts {
+					if want := sector == tt.sector && bin == tt.bin; (n == 1) != want {
+						t.Errorf("sector %d, class %d counts %d", sector, bin, n)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestWindRose(t *testing.T) {
+	if r := newWindAccumulator().rose(); r != nil {
+		t.Errorf("rose without hours = %+v, want nil", r)
+	}
+
+	w := newWindAccumulator()
+	for _, wind := range [][2]float64{{5, 270}, {15, 270}, {25, 265}, {15, 90}, {1, 0}, {0, 180}, {40, 0}, {12, 3}} {
+		w.add(wind[0], wind[1])
+	}
+	r := w.rose()
+	if r.Calm != 0.25 || r.Prevailing != 270 || len(r.Sectors) != windSectors || !slices.Equal(r.SpeedBins, WindSpeedBins) {
+		t.Fatalf("calm %v, prevailing %v, %d sectors, want 0.25, 270 and %d", r.Calm, r.Prevailing, len(r.Sectors), windSectors)
+	}
+	tests := []struct {
+		sector    int
+		direction float64
+		frequency float64
+		speeds    []float64
+	}{
+		{0, 0, 0.25, []float64{0, 0.125, 0, 0.125}},
+		{4, 90, 0.125, []float64{0, 0.125, 0, 0}},
+		{8, 180, 0, []float64{0, 0, 0, 0}},
+		{12, 270, 0.375, []float64{0.125, 0.125, 0.125, 0}},
+	}
+	for _, tt := range tests {
+		s := r.Sectors[tt.sector]
+		if s.Direction != tt.direction || s.Frequency != tt.frequency || !slices.Equal(s.Speeds, tt.speeds) {
+			t.Errorf("sector %d = %+v, want %v° at %v with %v", tt.sector, s, tt.direction, tt.frequency, tt.speeds)
+		}
+	}
+}
+
+func TestMonthClimate(t *testing.T) {
+	var m monthAccumulator
+	// Four days: the first two flyable, the third with two flyable hours only, the last without thermals
+	for day, flyable := range []int{3, 5, 2, 0} {
+		for hour := 0; hour < 6; hour++ {
+			m.add(20240701 + day)
+			m.values["temperature_2m"] = append(m.values["temperature_2m"], float64(10*day+hour))
+		}
+		if flyable > 0 {
+			m.flyable[20240701+day] = flyable
+		}
+	}
+	m.tops[20240701], m.tops[20240702], m.tops[20240703] = 2000, 2600, 3000
+	m.wind.add(15, 270)
+
+	c := m.climate(7, true)
+	if c.Month != 7 || c.Hours != 24 || c.Days != 4 {
+		t.Errorf("month %d, %d hours, %d days, want July with 24 hours over 4 days", c.Month, c.Hours, c.Days)
+	}
+	if c.Means["temperature_2m"] != 17.5 {
+		t.Errorf("mean temperature = %v, want 17.5", c.Means["temperature_2m"])
+	}
+	if want := map[string]float64{"p10": 2.3, "p50": 17.5, "p90": 32.7}; !reflect.DeepEqual(c.Percentiles["temperature_2m"], want) {
+		t.Errorf("temperature bands = %v, want %v", c.Percentiles["temperature_2m"], want)
+	}
+	if c.FlyableDays == nil || *c.FlyableDays != 0.5 {
+		t.Errorf("flyable days = %v, want 0.5", c.FlyableDays)
+	}
+	if want := map[string]float64{"p25": 2300, "p50": 2600, "p75": 2800}; !reflect.DeepEqual(c.ThermalTop, want) {
+		t.Errorf("thermal tops = %v, want %v", c.ThermalTop, want)
+	}
+	if c.Wind == nil || c.Wind.Prevailing != 270 {
+		t.Errorf("wind rose = %+v, want the west wind", c.Wind)
+	}
+
+	// Hours that weren't scored for flyability have no flyable days or thermal tops
+	if c := m.climate(7, false); c.FlyableDays != nil || c.ThermalTop != nil {
+		t.Errorf("flyable days %v, thermal tops %v without soaring, want none", c.FlyableDays, c.ThermalTop)
+	}
+}
+
+func TestClimatology(t *testing.T) {
+	// Six hours either side of the end of January in a site two hours ahead of UTC
+	start := time.Date(2024, time.January, 31, 19, 0, 0, 0, time.UTC).Unix()
+	bd := BaseData{UTCOffsetSeconds: 7200}
+	for i := 0; i < 6; i++ {
+		bd.Hourly.Time = append(bd.Hourly.Time, start+int64(i)*3600)
+		bd.Hourly.Temperature2M = append(bd.Hourly.Temperature2M, float64(i))
+		bd.Hourly.WindSpeed10M = append(bd.Hourly.WindSpeed10M, 15)
+		bd.Hourly.WindDirection10M = append(bd.Hourly.WindDirection10M, 180)
+	}
+
+	c, err := bd.Climatology([]string{"temperature_2m", "cape"}, ParaglidingLimits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Start != start || c.End != start+5*3600 || c.Hours != 6 || c.Days != 2 {
+		t.Errorf("start %d, end %d, %d hours, %d days", c.Start, c.End, c.Hours, c.Days)
+	}
+	// 21:00 to 02:00 local time: three hours of January, three of February
+	if len(c.Monthly) != 2 || c.Monthly[0].Month != 1 || c.Monthly[1].Month != 2 || c.Monthly[0].Hours != 3 {
+		t.Fatalf("monthly = %+v, want three hours of January and three of February", c.Monthly)
+	}
+	if jan, feb := c.Monthly[0].Means["temperature_2m"], c.Monthly[1].Means["temperature_2m"]; jan != 1 || feb != 4 {
+		t.Errorf("mean temperature of January %v, February %v, want 1 and 4", jan, feb)
+	}
+	if _, ok := c.Monthly[0].Means["cape"]; ok {
+		t.Error("cape averaged without any data")
+	}
+	// Without the boundary layer height the flyability can't be judged
+	if c.Monthly[0].FlyableDays != nil {
+		t.Errorf("flyable days = %v, want nil without boundary layer height", *c.Monthly[0].FlyableDays)
+	}
+	var hours []int
+	for _, h := range c.Diurnal {
+		hours = append(hours, h.Hour)
+	}
+	if want := []int{0, 1, 2, 21, 22, 23}; !slices.Equal(hours, want) {
+		t.Errorf("diurnal hours = %v, want %v", hours, want)
+	}
+	if c.Wind == nil || c.Wind.Prevailing != 180 || c.Wind.Sectors[8].Frequency != 1 {
+		t.Errorf("wind rose = %+v, want the south wind throughout", c.Wind)
+	}
+
+	if _, err := bd.Climatology([]string{"nope"}, ParaglidingLimits); !errors.Is(err, ErrUnknownField) {
+		t.Errorf("Climatology(nope) = %v, want ErrUnknownField", err)
+	}
+	if _, err := (&BaseData{}).Climatology(nil, ParaglidingLimits); err == nil {
+		t.Error("climatology of no hourly data")
+	}
+}
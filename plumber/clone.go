@@ -0,0 +1,54 @@
+package plumber
+
+import "reflect"
+
+// Clone returns a deep copy of the data, so that a forecast shared between callers can be handed out and modified safely
+func (bd *BaseData) Clone() *BaseData {
+	if bd == nil {
+		return nil
+	}
+	c := new(BaseData)
+	deepCopy(reflect.ValueOf(c).Elem(), reflect.ValueOf(bd).Elem())
+	return c
+}
+
+// deepCopy copies src into dst, which must be settable and of the same type, allocating new slices, maps and pointers
+func deepCopy(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				deepCopy(dst.Field(i), src.Field(i))
+			}
+		}
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		s := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			deepCopy(s.Index(i), src.Index(i))
+		}
+		dst.Set(s)
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		m := reflect.MakeMapWithSize(src.Type(), src.Len())
+		for it := src.MapRange(); it.Next(); {
+			v := reflect.New(it.Value().Type()).Elem()
+			deepCopy(v, it.Value())
+			m.SetMapIndex(it.Key(), v)
+		}
+		dst.Set(m)
+	case reflect.Pointer:
+		if src.IsNil() {
+			return
+		}
+		p := reflect.New(src.Elem().Type())
+		deepCopy(p.Elem(), src.Elem())
+		dst.Set(p)
+	default:
+		dst.Set(src)
+	}
+}
@@ -0,0 +1,179 @@
+package plumber
+
+import "math"
+
+// Convection holds the thermal convection parameters derived from a sounding
+//
+// Heights are above mean sea level in m. Indices that need a pressure level the sounding doesn't
+// carry are left nil. The parcel computations skip the virtual temperature correction and are
+// bounded by the highest level of the sounding, 400 hPa for HourlyData, so CAPE of deep convection
+// is underestimated.
+type Convection struct {
+	Time int64 `json:"time"` // Unix timestamp
+
+	LapseRate0To3Km   *float64 `json:"lapse_rate_0_3km"`   // Environmental lapse rate from the surface to 3 km above ground in °C/km
+	LapseRate850To500 *float64 `json:"lapse_rate_850_500"` // Environmental lapse rate between 850 and 500 hPa in °C/km
+	LapseRate700To500 *float64 `json:"lapse_rate_700_500"` // Environmental lapse rate between 700 and 500 hPa in °C/km
+
+	LCLPressure    float64  `json:"lcl_pressure"`    // Pressure of the surface parcel's lifting condensation level in hPa
+	LCLTemperature float64  `json:"lcl_temperature"` // Temperature at the lifting condensation level in °C
+	LCLHeight      float64  `json:"lcl_height"`      // Height of the lifting condensation level
+	CumulusBase    *float64 `json:"cumulus_base"`    // Cumulus cloud base, nil when thermals top out below the LCL (blue thermals)
+	ThermalTop     float64  `json:"thermal_top"`     // Where the surface dry adiabat meets the environmental temperature
+
+	KIndex      *float64 `json:"k_index"`      // (T850 - T500) + Td850 - (T700 - Td700) in °C
+	TotalTotals *float64 `json:"total_totals"` // T850 + Td850 - 2 T500 in °C
+	Showalter   *float64 `json:"showalter"`    // T500 minus the temperature of a parcel lifted from 850 to 500 hPa in °C
+
+	CAPE float64 `json:"cape"` // Surface based convective available potential energy in J/kg
+	CIN  float64 `json:"cin"`  // Surface based convective inhibition in J/kg, zero or negative
+}
+
+// Convection computes the thermal convection parameters for every hour of the data
+func (bd *BaseData) Convection() ([]Convection, error) {
+	soundings, err := bd.Hourly.Soundings(bd.Elevation)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]Convection, len(soundings))
+	for i := range soundings {
+		out[i] = soundings[i].Convection()
+	}
+	return out, nil
+}
+
+// Convection computes the thermal convection parameters of the sounding
+func (s *Sounding) Convection() Convection {
+	c := Convection{Time: s.Time}
+	sfc := s.Surface
+
+	if t3, ok := s.TemperatureAtHeight(s.Elevation + 3000); ok {
+		c.LapseRate0To3Km = ptr((sfc.Temperature - t3) / ((s.Elevation + 3000 - sfc.Height) / 1000))
+	}
+	c.LapseRate850To500 = s.lapseRate(850, 500)
+	c.LapseRate700To500 = s.lapseRate(700, 500)
+
+	c.LCLPressure, c.LCLTemperature = lcl(sfc.Pressure, sfc.Temperature, sfc.DewPoint)
+	if h, ok := s.HeightAt(c.LCLPressure); ok {
+		c.LCLHeight = h
+	} else {
+		c.LCLHeight = sfc.Height + espyMetresPerK*(sfc.Temperature-sfc.DewPoint)
+	}
+
+	c.ThermalTop = s.thermalTop()
+	if c.ThermalTop > c.LCLHeight {
+		c.CumulusBase = ptr(c.LCLHeight)
+	}
+
+	l850, ok850 := s.level(850)
+	l700, ok700 := s.level(700)
+	l500, ok500 := s.level(500)
+	if ok850 && ok700 && ok500 {
+		c.KIndex = ptr((l850.Temperature - l500.Temperature) + l850.DewPoint - (l700.Temperature - l700.DewPoint))
+	}
+	if ok850 && ok500 {
+		c.TotalTotals = ptr(l850.Temperature + l850.DewPoint - 2*l500.Temperature)
+		c.Showalter = ptr(l500.Temperature - parcel(850, l850.Temperature, l850.DewPoint, 500))
+	}
+
+	c.CAPE, c.CIN = s.capeCIN()
+	return c
+}
+
+// lapseRate returns the environmental lapse rate between two pressure levels in °C/km
+func (s *Sounding) lapseRate(lower, upper float64) *float64 {
+	lo, ok := s.level(lower)
+	if !ok {
+		return nil
+	}
+	up, ok := s.level(upper)
+	if !ok || up.Height <= lo.Height {
+		return nil
+	}
+	return ptr((lo.Temperature - up.Temperature) / ((up.Height - lo.Height) / 1000))
+}
+
+// thermalTop returns the height where a dry adiabat from the surface temperature meets the environmental profile
+//
+// This is the top of dry thermals; if the profile never becomes stable enough the top of the sounding is returned.
+func (s *Sounding) thermalTop() float64 {
+	profile := s.Profile()
+	sfc := profile[0]
+	excess := func(l Level) float64 {
+		parcel := sfc.Temperature - dryLapseRate*(l.Height-sfc.Height)/1000
+		return parcel - l.Temperature
+	}
+
+	prev := profile[0]
+	prevExcess := 0.0
+	for _, l := range profile[1:] {
+		e := excess(l)
+		if e <= 0 {
+			if prevExcess <= 0 {
+				return prev.Height
+			}
+			return prev.Height + (l.Height-prev.Height)*prevExcess/(prevExcess-e)
+		}
+		prev, prevExcess = l, e
+	}
+	return prev.Height
+}
+
+// capeCIN integrates the buoyancy of a surface parcel through the sounding
+//
+// CAPE is the positive area between the level of free convection and the equilibrium level,
+// CIN the negative area between the surface and the level of free convection. Without a level of
+// free convection both are zero.
+func (s *Sounding) capeCIN() (float64, float64) {
+	sfc := s.Surface
+	top := s.Levels[len(s.Levels)-1].Pressure
+	plcl, _ := lcl(sfc.Pressure, sfc.Temperature, sfc.DewPoint)
+
+	type layer struct {
+		p, buoyancy float64 // Temperature excess of the parcel in K
+	}
+	var layers []layer
+	for p := sfc.Pressure; p >= top; p -= capeStepHPa {
+		te, _, ok := s.At(p)
+		if !ok {
+			break
+		}
+		layers = append(layers, layer{p: p, buoyancy: parcel(sfc.Pressure, sfc.Temperature, sfc.DewPoint, p) - te})
+	}
+
+	lfc, el := -1, -1
+	for i, l := range layers {
+		if l.buoyancy > 0 && l.p <= plcl {
+			if lfc < 0 {
+				lfc = i
+				// A parcel that's buoyant all the way up to its LCL is free from there on
+				for lfc > 0 && layers[lfc-1].buoyancy > 0 {
+					lfc--
+				}
+			}
+			el = i
+		}
+	}
+	if lfc < 0 {
+		return 0, 0
+	}
+
+	// Rd * (Tp - Te) * dln(p) in pressure coordinates is the same work as g * (Tp - Te) / Te * dz
+	var cape, cin float64
+	for i := 1; i < len(layers); i++ {
+		lower, upper := layers[i-1], layers[i]
+		area := rd * (lower.buoyancy + upper.buoyancy) / 2 * math.Log(lower.p/upper.p)
+		switch {
+		case i <= lfc:
+			cin += math.Min(area, 0)
+		case i <= el:
+			cape += math.Max(area, 0)
+		}
+	}
+	return cape, cin
+}
+
+func ptr(v float64) *float64 {
+	return &v
+}
@@ -0,0 +1,104 @@
+package plumber
+
+import (
+	"math"
+	"testing"
+)
+
+// parcelSounding returns a sounding over a saturated surface at 1000 hPa and 20 °C whose levels are offset
+// from the surface parcel's moist adiabat by offset(p) K
+func parcelSounding(offset func(p float64) float64) *Sounding {
+	s := &Sounding{Surface: Level{Pressure: 1000, Temperature: 20, DewPoint: 20}}
+	for _, p := range []float64{950, 900, 850, 800, 700, 600, 500, 400} {
+		t := parcel(1000, 20, 20, p) + offset(p)
+		s.Levels = append(s.Levels, Level{Pressure: p, Temperature: t, DewPoint: t - 10})
+	}
+	return s
+}
+
+func TestCapeCIN(t *testing.T) {
+	area := func(k, lower, upper float64) float64 { return rd * k * math.Log(lower/upper) }
+	tests := []struct {
+		name      string
+		offset    func(p float64) float64
+		cape, cin float64
+	}{
+		{
+			name:   "stable",
+			offset: func(float64) float64 { return 2 },
+		},
+		{
+			// Buoyant by 2 K from 950 hPa up, ramping up from the surface
+			name:   "unstable",
+			offset: func(float64) float64 { return -2 },
+			cape:   area(2, 950, 400) + area(1, 1000, 950),
+		},
+		{
+			// Capped by 1 K up to 900 hPa, then buoyant by 2 K, crossing a third of the way to 850 hPa
+			name: "capped",
+			offset: func(p float64) float64 {
+				if p >= 900 {
+					return 1
+				}
+				return -2
+			},
+			cape: area(2, 850, 400) + area(1, 900, 850)*2/3,
+			cin:  -(area(0.5, 1000, 950) + area(1, 950, 900) + area(0.5, 900, 850)/3),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cape, cin := parcelSounding(tt.offset).capeCIN()
+			// Within 5% and 2 J/kg, the environment being linear in ln(p) between levels unlike the parcel
+			if math.Abs(cape-tt.cape) > 0.05*tt.cape+2 {
+				t.Errorf("CAPE = %.1f J/kg, want %.1f J/kg", cape, tt.cape)
+			}
+			if math.Abs(cin-tt.cin) > 0.05*-tt.cin+2 {
+				t.Errorf("CIN = %.1f J/kg, want %.1f J/kg", cin, tt.cin)
+			}
+		})
+	}
+}
+
+func TestStabilityIndices(t *testing.T) {
+	s := &Sounding{
+		Surface: Level{Pressure: 1000, Height: 100, Temperature: 28, DewPoint: 18},
+		Levels: []Level{
+			{Pressure: 850, Height: 1500, Temperature: 20, DewPoint: 15},
+			{Pressure: 700, Height: 3100, Temperature: 8, DewPoint: 2},
+			{Pressure: 500, Height: 5800, Temperature: -12, DewPoint: -30},
+		},
+	}
+	c := s.Convection()
+	tests := []struct {
+		name string
+		got  *float64
+		want float64
+		tol  float64
+	}{
+		// (20 - -12) + 15 - (8 - 2)
+		{"k_index", c.KIndex, 41, 1e-9},
+		// 20 + 15 - 2 * -12
+		{"total_totals", c.TotalTotals, 59, 1e-9},
+		// -12 minus the 850 hPa parcel lifted to 500 hPa, -4.21 °C holding Bolton's equivalent potential temperature
+		{"showalter", c.Showalter, -7.79, 1},
+		// (20 - -12) over 4.3 km
+		{"lapse_rate_850_500", c.LapseRate850To500, 32 / 4.3, 1e-9},
+	}
+	for _, tt := range tests {
+		if tt.got == nil {
+			t.Errorf("%s is nil", tt.name)
+			continue
+		}
+		if math.Abs(*tt.got-tt.want) > tt.tol {
+			t.Errorf("%s = %.2f, want %.2f", tt.name, *tt.got, tt.want)
+		}
+	}
+
+	// Without 700 hPa there's no K-index, but Total Totals remain
+	s.Levels = append(s.Levels[:1], s.Levels[2])
+	c = s.Convection()
+	if c.KIndex != nil || c.TotalTotals == nil {
+		t.Errorf("without 700 hPa: k_index = %v, total_totals = %v", c.KIndex, c.TotalTotals)
+	}
+}
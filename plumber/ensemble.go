@@ -0,0 +1,225 @@
+package plumber
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrNoMembers is returned by the statistics of an Ensemble that has no data for the field
+var ErrNoMembers = errors.New("no ensemble members for field")
+
+// Ensemble is an ensemble forecast: the control run as BaseData and the perturbed members alongside it
+//
+// Members share the hourly time axis of the control run. Their float fields are NaN at hours a member has
+// no value for, which the statistics skip and JSON carries as null.
+type Ensemble struct {
+	BaseData
+	Model   string       `json:"model"`
+	Members []HourlyData `json:"members"`
+}
+
+// MarshalJSON writes the missing values of the hourly control run and the members as null, as JSON has no NaN
+func (e Ensemble) MarshalJSON() ([]byte, error) {
+	type ensemble Ensemble // Without the method
+	members := make([]map[string]any, len(e.Members))
+	for i := range e.Members {
+		members[i] = nullSeries(reflect.ValueOf(&e.Members[i]).Elem())
+	}
+	return json.Marshal(struct {
+		ensemble
+		Hourly  map[string]any   `json:"hourly"`
+		Members []map[string]any `json:"members"`
+	}{ensemble(e), nullSeries(reflect.ValueOf(&e.Hourly).Elem()), members})
+}
+
+// DefaultPercentiles are the percentiles of EnsembleStats when none are asked for
+var DefaultPercentiles = []float64{10, 50, 90}
+
+// DefaultEnsembleFields are the hourly fields EnsembleStats covers when none are asked for
+var DefaultEnsembleFields = []string{"temperature_2m", "wind_speed_10m", "wind_gusts_10m", "precipitation", "cloud_cover"}
+
+// DefaultThresholds are the thresholds EnsembleStats gives exceedance probabilities for when none are asked for
+var DefaultThresholds = []Threshold{
+	{Field: "wind_speed_10m", Value: 25},
+	{Field: "wind_gusts_10m", Value: 40},
+	{Field: "precipitation", Value: 1},
+}
+
+// Threshold is a value of an hourly field, in CommonUnits, whose exceedance probability is of interest
+type Threshold struct {
+	Field string  `json:"field"`
+	Value float64 `json:"value"`
+}
+
+// ParseThreshold parses a threshold in the form field:value, e.g. wind_speed_10m:25
+func ParseThreshold(s string) (Threshold, error) {
+	field, value, ok := strings.Cut(s, ":")
+	if !ok {
+		return Threshold{}, fmt.Errorf("threshold %q isn't in the form field:value", s)
+	}
+	if !IsField(BlockHourly, field) {
+		return Threshold{}, fmt.Errorf("%w: hourly.%s", ErrUnknownField, field)
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return Threshold{}, fmt.Errorf("threshold value of %s must be a number", field)
+	}
+	return Threshold{Field: field, Value: v}, nil
+}
+
+// Exceedance is the probability, per hour, of a field exceeding a threshold
+type Exceedance struct {
+	Threshold
+	Probability []*float64 `json:"probability"` // Share of the members above the threshold, from 0 to 1; null without members
+}
+
+// EnsembleStats summarises an ensemble per hour
+type EnsembleStats struct {
+	Time    []int64 `json:"time"`
+	Members int     `json:"members"` // Including the control run
+	// Values of the percentiles per field, e.g. "wind_speed_10m": {"p10": [...], "p50": [...], "p90": [...]},
+	// null at hours no member has a value for
+	Percentiles   map[string]map[string][]*float64 `json:"percentiles"`
+	Probabilities []Exceedance                     `json:"probabilities"`
+}
+
+// Size returns the number of members, counting the control run
+func (e *Ensemble) Size() int {
+	return len(e.Members) + 1
+}
+
+// members returns the hourly data of every member, the control run first
+func (e *Ensemble) members() []*HourlyData {
+	out := make([]*HourlyData, 0, e.Size())
+	out = append(out, &e.Hourly)
+	for i := range e.Members {
+		out = append(out, &e.Members[i])
+	}
+	return out
+}
+
+// values returns the values of the members for a field at position i of the time axis
+func (e *Ensemble) values(field string, i int) []float64 {
+	var values []float64
+	for _, m := range e.members() {
+		if v, ok := m.Value(field, i); ok && !math.IsNaN(v) {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// Percentile returns, per hour, the p-th percentile (0 to 100) of a field across the members
+//
+// Percentiles are interpolated linearly between the members' values. Hours no member has a value for are nil.
+func (e *Ensemble) Percentile(field string, p float64) ([]*float64, error) {
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		return nil, fmt.Errorf("percentile %v out of range [0, 100]", p)
+	}
+	return e.perHour(field, func(values []float64) float64 {
+		slices.Sort(values)
+		return percentile(values, p)
+	})
+}
+
+// Exceedance returns, per hour, the share of the members whose field is above the threshold; nil at hours
+// no member has a value for
+func (e *Ensemble) Exceedance(t Threshold) ([]*float64, error) {
+	return e.perHour(t.Field, func(values []float64) float64 {
+		above := 0
+		for _, v := range values {
+			if v > t.Value {
+				above++
+			}
+		}
+		return math.Round(float64(above)/float64(len(values))*1000) / 1000
+	})
+}
+
+// perHour folds the members' values of a field at every hour; hours without any value are nil, not a value of 0
+func (e *Ensemble) perHour(field string, fold func([]float64) float64) ([]*float64, error) {
+	if !IsField(BlockHourly, field) {
+		return nil, fmt.Errorf("%w: hourly.%s", ErrUnknownField, field)
+	}
+	out := make([]*float64, len(e.Hourly.Time))
+	found := false
+	for i := range e.Hourly.Time {
+		values := e.values(field, i)
+		if len(values) == 0 {
+			continue
+		}
+		found = true
+		v := fold(values)
+		out[i] = &v
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrNoMembers, field)
+	}
+	return out, nil
+}
+
+// Stats computes the percentiles of the fields and the exceedance probabilities of the thresholds,
+// falling back to DefaultEnsembleFields, DefaultPercentiles and DefaultThresholds for those not given
+//
+// Fields and thresholds the ensemble has no data for are left out.
+func (e *Ensemble) Stats(fields []string, percentiles []float64, thresholds []Threshold) (*EnsembleStats, error) {
+	if len(fields) == 0 {
+		fields = DefaultEnsembleFields
+	}
+	if len(percentiles) == 0 {
+		percentiles = DefaultPercentiles
+	}
+	if thresholds == nil {
+		thresholds = DefaultThresholds
+	}
+
+	s := &EnsembleStats{
+		Time:          e.Hourly.Time,
+		Members:       e.Size(),
+		Percentiles:   make(map[string]map[string][]*float64, len(fields)),
+		Probabilities: []Exceedance{},
+	}
+	for _, field := range fields {
+		for _, p := range percentiles {
+			values, err := e.Percentile(field, p)
+			if errors.Is(err, ErrNoMembers) {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			if s.Percentiles[field] == nil {
+				s.Percentiles[field] = make(map[string][]*float64, len(percentiles))
+			}
+			s.Percentiles[field]["p"+strconv.FormatFloat(p, 'f', -1, 64)] = values
+		}
+	}
+	for _, t := range thresholds {
+		probability, err := e.Exceedance(t)
+		if errors.Is(err, ErrNoMembers) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		s.Probabilities = append(s.Probabilities, Exceedance{Threshold: t, Probability: probability})
+	}
+	return s, nil
+}
+
+// percentile interpolates the p-th percentile of sorted values
+func percentile(sorted []float64, p float64) float64 {
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	v := sorted[lo]
+	if lo < len(sorted)-1 {
+		v += (rank - float64(lo)) * (sorted[lo+1] - sorted[lo])
+	}
+	return math.Round(v*100) / 100
+}
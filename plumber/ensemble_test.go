@@ -0,0 +1,124 @@
+package plumber
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+// testEnsemble has three members over three hours; no member has wind speed at the last hour, where the
+// control run is NaN and the others end early
+func testEnsemble() *Ensemble {
+	return &Ensemble{
+		BaseData: BaseData{Hourly: HourlyData{
+			Time:         []int64{0, 3600, 7200},
+			WindSpeed10M: []float64{10, 30, math.NaN()},
+		}},
+		Members: []HourlyData{
+			{Time: []int64{0, 3600, 7200}, WindSpeed10M: []float64{20, 0}},
+			{Time: []int64{0, 3600, 7200}, WindSpeed10M: []float64{30, 0}},
+		},
+	}
+}
+
+func TestEnsemblePerHour(t *testing.T) {
+	ens := testEnsemble()
+	tests := []struct {
+		name string
+		got  func() ([]*float64, error)
+		want []*float64
+	}{
+		{"p50", func() ([]*float64, error) { return ens.Percentile("wind_speed_10m", 50) }, []*float64{ptr(20), ptr(0), nil}},
+		{"p90", func() ([]*float64, error) { return ens.Percentile("wind_speed_10m", 90) }, []*float64{ptr(28), ptr(24), nil}},
+		{"exceedance", func() ([]*float64, error) {
+			return ens.Exceedance(Threshold{Field: "wind_speed_10m", Value: 25})
+		}, []*float64{ptr(0.333), ptr(0.333), nil}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.got()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d hours, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				switch {
+				case tt.want[i] == nil && got[i] != nil:
+					t.Errorf("hour %d = %v, want nil", i, *got[i])
+				case tt.want[i] != nil && (got[i] == nil || *got[i] != *tt.want[i]):
+					t.Errorf("hour %d = %v, want %v", i, got[i], *tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEnsembleStatsNullHours(t *testing.T) {
+	stats, err := testEnsemble().Stats([]string{"wind_speed_10m", "temperature_2m"}, []float64{50}, []Threshold{{Field: "wind_speed_10m", Value: 25}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := stats.Percentiles["temperature_2m"]; ok {
+		t.Error("temperature_2m has percentiles without any member data")
+	}
+	b, err := json.Marshal(stats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Percentiles   map[string]map[string][]any `json:"percentiles"`
+		Probabilities []struct {
+			Probability []any `json:"probability"`
+		} `json:"probabilities"`
+	}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if v := doc.Percentiles["wind_speed_10m"]["p50"][2]; v != nil {
+		t.Errorf("p50 of an hour without members = %v, want null", v)
+	}
+	if v := doc.Probabilities[0].Probability[2]; v != nil {
+		t.Errorf("probability of an hour without members = %v, want null", v)
+	}
+	if v := doc.Probabilities[0].Probability[1]; v != 0.333 {
+		t.Errorf("probability = %v, want 0.333", v)
+	}
+}
+
+func TestEnsembleMembersJSON(t *testing.T) {
+	ens := testEnsemble()
+	ens.Model = "icon_seamless"
+	ens.Members[0].WindSpeed10M = []float64{20, math.NaN(), 5}
+	ens.Members[0].CloudCover = []int{50}
+
+	b, err := json.Marshal(ens)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Model   string           `json:"model"`
+		Hourly  map[string]any   `json:"hourly"`
+		Members []map[string]any `json:"members"`
+	}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Model != "icon_seamless" || len(doc.Hourly["time"].([]any)) != 3 || len(doc.Members) != 2 {
+		t.Fatalf("model %q, hourly %v, %d members, want the control run and both members", doc.Model, doc.Hourly, len(doc.Members))
+	}
+	if wind := doc.Hourly["wind_speed_10m"].([]any); len(wind) != 3 || wind[2] != nil {
+		t.Errorf("wind speed of the control run = %v, want null at the last hour", wind)
+	}
+	wind := doc.Members[0]["wind_speed_10m"].([]any)
+	if len(wind) != 3 || wind[0] != 20.0 || wind[1] != nil || wind[2] != 5.0 {
+		t.Errorf("wind speed of the member = %v, want [20 null 5]", wind)
+	}
+	if cloud := doc.Members[0]["cloud_cover"].([]any); len(cloud) != 1 || cloud[0] != 50.0 {
+		t.Errorf("cloud cover of the member = %v, want [50]", cloud)
+	}
+	if v, ok := doc.Members[1]["temperature_2m"]; !ok || v != nil {
+		t.Errorf("temperature of the member = %v, want null", v)
+	}
+}
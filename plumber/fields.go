@@ -0,0 +1,173 @@
+package plumber
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ErrUnknownField is returned by ParseFields for names that aren't fields of BaseData
+var ErrUnknownField = errors.New("unknown field")
+
+// Blocks of BaseData that Fields select from, by JSON name
+const (
+	BlockCurrent    = "current"
+	BlockHourly     = "hourly"
+	BlockDaily      = "daily"
+	BlockAirQuality = "air_quality"
+	BlockMarine     = "marine"
+)
+
+// blocks lists the blocks of BaseData in the order they're laid out
+var blocks = []string{BlockCurrent, BlockHourly, BlockDaily, BlockAirQuality, BlockMarine}
+
+// Fields selects fields of the current, hourly, daily, air quality and marine blocks of BaseData, e.g. to trim
+// a forecast for a mobile client
+//
+// Fields are keyed by block and named by their JSON names. A block with no names selects all of its fields,
+// a block that's missing none of them. A nil Fields selects everything.
+type Fields map[string][]string
+
+// ParseFields parses a comma separated selection like hourly.temperature_2m,hourly.cape,daily.sunrise
+//
+// A bare block name, e.g. current, selects the whole block. Names are validated against the JSON tags of
+// CurrentData, HourlyData, DailyData, AirQualityData and MarineData; the time axis and interval are always
+// included.
+func ParseFields(s string) (Fields, error) {
+	f := make(Fields)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		block, name, named := strings.Cut(item, ".")
+		if _, ok := blockFields[block]; !ok {
+			return nil, fmt.Errorf("%w: %s, blocks are %s", ErrUnknownField, item, strings.Join(blocks, ", "))
+		}
+		names, selected := f[block]
+		switch {
+		case !named:
+			f[block] = []string{} // The whole block, even if some of its fields were named already
+		case !IsField(block, name):
+			return nil, fmt.Errorf("%w: %s", ErrUnknownField, item)
+		case selected && len(names) == 0:
+			// The whole block is selected already
+		case !slices.Contains(names, name):
+			f[block] = append(names, name)
+		}
+	}
+	if len(f) == 0 {
+		return nil, nil
+	}
+	for _, names := range f {
+		slices.Sort(names)
+	}
+	return f, nil
+}
+
+// blockFields lists the JSON names of the fields of every block, excluding time and interval
+var blockFields = map[string][]string{
+	BlockCurrent: slices.DeleteFunc(structFields(reflect.TypeOf(CurrentData{})), func(name string) bool {
+		return name == "time" || name == "interval"
+	}),
+	BlockHourly:     HourlyFields(),
+	BlockDaily:      DailyFields(),
+	BlockAirQuality: AirQualityFields(),
+	BlockMarine:     MarineFields(),
+}
+
+// IsField reports whether name is the JSON name of a field of the block, other than time and interval
+func IsField(block, name string) bool {
+	return slices.Contains(blockFields[block], name)
+}
+
+func structFields(typ reflect.Type) []string {
+	names := make([]string, typ.NumField())
+	for f := range names {
+		names[f] = jsonName(typ.Field(f))
+	}
+	return names
+}
+
+// Block returns the fields selected in a block; all is true if the whole block is selected
+//
+// Names are nil with all false if the block isn't selected at all.
+func (f Fields) Block(block string) (names []string, all bool) {
+	if f == nil {
+		return nil, true
+	}
+	names, ok := f[block]
+	return names, ok && len(names) == 0
+}
+
+// Selects reports whether the field with the given JSON name of a block is selected
+func (f Fields) Selects(block, name string) bool {
+	names, all := f.Block(block)
+	return all || slices.Contains(names, name)
+}
+
+// String returns the selection in the form ParseFields parses, with blocks and names sorted; empty for a nil Fields
+func (f Fields) String() string {
+	var items []string
+	for _, block := range blocks {
+		names, ok := f[block]
+		if !ok {
+			continue
+		}
+		if len(names) == 0 {
+			items = append(items, block)
+		}
+		for _, name := range names {
+			items = append(items, block+"."+name)
+		}
+	}
+	return strings.Join(items, ",")
+}
+
+// Project returns v, a BaseData or Consensus or a struct embedding either, as a JSON object holding only
+// the selected fields of its blocks, along with their time and interval; the same goes for a consensus spread
+//
+// Everything outside the blocks, such as the coordinates and units, is kept as is.
+func (f Fields) Project(v any) (map[string]any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out map[string]any
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber() // Numbers come out as they went in
+	if err := d.Decode(&out); err != nil {
+		return nil, err
+	}
+	f.prune(out)
+	if spread, ok := out["spread"].(map[string]any); ok {
+		f.prune(spread)
+	}
+	return out, nil
+}
+
+// prune removes the unselected blocks and fields from a JSON object holding the blocks of BaseData
+func (f Fields) prune(obj map[string]any) {
+	if f == nil {
+		return
+	}
+	for _, block := range blocks {
+		names, all := f.Block(block)
+		switch {
+		case all:
+		case names == nil:
+			delete(obj, block)
+		default:
+			fields, _ := obj[block].(map[string]any)
+			for name := range fields {
+				if name != "time" && name != "interval" && !slices.Contains(names, name) {
+					delete(fields, name)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package plumber
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestParseFields(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want Fields
+		err  bool
+	}{
+		{"nothing", "", nil, false},
+		{"only commas", " , ,", nil, false},
+		{"named fields", "hourly.temperature_2m,hourly.cape,daily.sunrise", Fields{"hourly": {"cape", "temperature_2m"}, "daily": {"sunrise"}}, false},
+		{"bare block", "current", Fields{"current": {}}, false},
+		{"spaces", " hourly.cape , marine ", Fields{"hourly": {"cape"}, "marine": {}}, false},
+		{"duplicates", "hourly.cape,hourly.cape", Fields{"hourly": {"cape"}}, false},
+		{"block after its fields", "hourly.cape,hourly", Fields{"hourly": {}}, false},
+		{"fields after their block", "hourly,hourly.cape", Fields{"hourly": {}}, false},
+		{"air quality", "air_quality.pm10", Fields{"air_quality": {"pm10"}}, false},
+		{"unknown block", "weekly", nil, true},
+		{"unknown field", "hourly.nope", nil, true},
+		{"field of another block", "current.sunrise", nil, true},
+		{"time axis", "hourly.time", nil, true},
+		{"Go name", "hourly.Temperature2M", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFields(tt.in)
+			if tt.err {
+				if !errors.Is(err, ErrUnknownField) {
+					t.Errorf("ParseFields(%q) = %v, %v, want ErrUnknownField", tt.in, got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFields(%q) = %#v, want %#v", tt.in, got, tt.want)
+			}
+			// String is the canonical form ParseFields parses back
+			if again, err := ParseFields(got.String()); err != nil || !reflect.DeepEqual(again, got) {
+				t.Errorf("ParseFields(%q) = %v, %v, want %v", got.String(), again, err, got)
+			}
+		})
+	}
+}
+
+func TestFieldsSelects(t *testing.T) {
+	f := Fields{"hourly": {"cape"}, "current": {}}
+	tests := []struct {
+		fields       Fields
+		block, name  string
+		selects, all bool
+	}{
+		{nil, "daily", "sunrise", true, true},
+		{f, "hourly", "cape", true, false},
+		{f, "hourly", "temperature_2m", false, false},
+		{f, "current", "temperature_2m", true, true},
+		{f, "daily", "sunrise", false, false},
+	}
+	for _, tt := range tests {
+		if got := tt.fields.Selects(tt.block, tt.name); got != tt.selects {
+			t.Errorf("%v selects %s.%s = %v, want %v", tt.fields, tt.block, tt.name, got, tt.selects)
+		}
+		if _, all := tt.fields.Block(tt.block); all != tt.all {
+			t.Errorf("%v selects all of %s = %v, want %v", tt.fields, tt.block, all, tt.all)
+		}
+	}
+	if got := f.String(); got != "current,hourly.cape" {
+		t.Errorf("String() = %q, want current,hourly.cape", got)
+	}
+}
+
+func TestFieldsProject(t *testing.T) {
+	bd := BaseData{
+		Latitude: 46.68, Longitude: 7.86, Units: map[string]string{"temperature": "°F"},
+		Current: CurrentData{Time: 1720000800, Interval: 900, Temperature2M: 20},
+		Hourly:  HourlyData{Time: []int64{1720000800}, Temperature2M: []float64{20}, Cape: []float64{150}, WindSpeed10M: []float64{10}},
+		Daily:   DailyData{Time: []int64{1719964800}, Sunrise: []int64{1719978000}},
+	}
+	c := Consensus{
+		BaseData: bd,
+		Method:   MergeMean,
+		Spread: Spread{
+			Current: map[string]float64{"temperature_2m": 1},
+			Hourly:  map[string][]float64{"temperature_2m": {1}, "cape": {50}, "wind_speed_10m": {4}},
+			Daily:   map[string][]float64{"sunrise": {60}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		fields Fields
+		v      any
+		want   map[string]any // The blocks and spread kept, any other is left out
+	}{
+		{"named fields", Fields{"hourly": {"cape", "temperature_2m"}}, bd, map[string]any{
+			"hourly": map[string]any{"time": []int64{1720000800}, "temperature_2m": []float64{20}, "cape": []float64{150}},
+		}},
+		{"bare block", Fields{"current": {}}, &bd, map[string]any{"current": bd.Current}},
+		{"consensus spread", Fields{"hourly": {"cape"}}, c, map[string]any{
+			"hourly": map[string]any{"time": []int64{1720000800}, "cape": []float64{150}},
+			"spread": map[string]any{"hourly": map[string]any{"cape": []float64{50}}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fields.Project(tt.v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got["latitude"] != json.Number("46.68") || got["units"].(map[string]any)["temperature"] != "°F" {
+				t.Errorf("latitude %v, units %v, want them kept", got["latitude"], got["units"])
+			}
+			if _, ok := tt.v.(Consensus); ok && got["method"] != "mean" {
+				t.Errorf("method = %v, want it kept", got["method"])
+			}
+			for _, key := range append(slices.Clone(blocks), "spread") {
+				g, ok := got[key]
+				w, want := tt.want[key]
+				switch {
+				case !want && ok:
+					t.Errorf("unselected %s in the projection", key)
+				case want && !sameJSON(t, g, w):
+					gj, _ := json.Marshal(g)
+					wj, _ := json.Marshal(w)
+					t.Errorf("%s = %s, want %s", key, gj, wj)
+				}
+			}
+		})
+	}
+
+	// Without a selection everything is kept
+	got, err := Fields(nil).Project(bd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sameJSON(t, got, bd) {
+		t.Errorf("Project without a selection = %v, want everything", got)
+	}
+}
+
+// sameJSON reports whether a and b marshal to the same JSON
+func sameJSON(t *testing.T, a, b any) bool {
+	t.Helper()
+	ja, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jb, err := json.Marshal(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var va, vb any
+	json.Unmarshal(ja, &va)
+	json.Unmarshal(jb, &vb)
+	return reflect.DeepEqual(va, vb)
+}
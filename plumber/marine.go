@@ -0,0 +1,32 @@
+package plumber
+
+import (
+	"reflect"
+	"slices"
+)
+
+// MarineData holds the hourly sea state of a coastal site; the sea breeze that shapes coastal soaring follows
+// the gap between the sea surface and the land temperature
+type MarineData struct {
+	Time                  []int64   `json:"time"`                    // Unix timestamps
+	WaveHeight            []float64 `json:"wave_height"`             // Significant height of the combined waves in m
+	WaveDirection         []int     `json:"wave_direction"`          // Mean direction the combined waves come from in degrees
+	WavePeriod            []float64 `json:"wave_period"`             // Mean period of the combined waves in s
+	WindWaveHeight        []float64 `json:"wind_wave_height"`        // Significant height of the waves raised by the local wind in m
+	WindWaveDirection     []int     `json:"wind_wave_direction"`     // In degrees
+	WindWavePeriod        []float64 `json:"wind_wave_period"`        // In s
+	SwellWaveHeight       []float64 `json:"swell_wave_height"`       // Significant height of the swell from distant weather in m
+	SwellWaveDirection    []int     `json:"swell_wave_direction"`    // In degrees
+	SwellWavePeriod       []float64 `json:"swell_wave_period"`       // In s
+	SeaSurfaceTemperature []float64 `json:"sea_surface_temperature"` // In °C
+}
+
+// MarineFields lists the JSON names of the marine fields, excluding the time axis
+func MarineFields() []string {
+	return seriesFields(reflect.TypeOf(MarineData{}))
+}
+
+// Index returns the position of the Unix timestamp t in the marine time axis, or -1 if it isn't on it
+func (m *MarineData) Index(t int64) int {
+	return slices.Index(m.Time, t)
+}
@@ -0,0 +1,43 @@
+package plumber
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMarineFields(t *testing.T) {
+	fields := MarineFields()
+	if len(fields) != 10 || slices.Contains(fields, "time") || !slices.Contains(fields, "sea_surface_temperature") {
+		t.Errorf("MarineFields() = %v", fields)
+	}
+	for _, f := range fields {
+		if !IsField(BlockMarine, f) || IsField(BlockHourly, f) {
+			t.Errorf("%s isn't a field of the marine block alone", f)
+		}
+	}
+}
+
+func TestMarineProject(t *testing.T) {
+	bd := BaseData{
+		Hourly: HourlyData{Time: []int64{0, 3600}, Temperature2M: []float64{20, 21}},
+		Marine: &MarineData{Time: []int64{0, 3600}, WaveHeight: []float64{0.4, 0.5}, SeaSurfaceTemperature: []float64{24, 24.2}},
+	}
+	if i := bd.Marine.Index(3600); i != 1 || bd.Marine.Index(1800) != -1 {
+		t.Errorf("Index(3600) = %d, Index(1800) = %d, want 1 and -1", i, bd.Marine.Index(1800))
+	}
+
+	got, err := Fields{"marine": {"wave_height"}}.Project(bd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	marine, ok := got["marine"].(map[string]any)
+	if !ok {
+		t.Fatalf("projection = %v, want the marine block", got)
+	}
+	if _, ok := marine["sea_surface_temperature"]; ok || marine["time"] == nil || marine["wave_height"] == nil {
+		t.Errorf("marine = %v, want the time axis and wave height", marine)
+	}
+	if _, ok := got["hourly"]; ok {
+		t.Error("unselected hourly block in the projection")
+	}
+}
@@ -0,0 +1,461 @@
+package plumber
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MergeMethod decides how the values of several providers are folded into one consensus value
+type MergeMethod string
+
+const (
+	MergeMean     MergeMethod = "mean"     // Arithmetic mean, every provider counts the same
+	MergeMedian   MergeMethod = "median"   // Median, robust against a single provider going astray
+	MergeWeighted MergeMethod = "weighted" // Mean weighted by each provider's Weight
+)
+
+// Validate reports whether the merge method is supported
+func (m MergeMethod) Validate() error {
+	switch m {
+	case MergeMean, MergeMedian, MergeWeighted:
+		return nil
+	default:
+		return fmt.Errorf("unknown merge method %q, expected one of mean, median, weighted", string(m))
+	}
+}
+
+// Fetcher fetches meteo data for a location; providers.Registry.Sources adapts providers to it
+type Fetcher interface {
+	FetchData(ctx context.Context, coords *Coordinates) (*BaseData, error)
+}
+
+// Source is a named Fetcher taking part in a merge
+type Source struct {
+	Name    string
+	Fetcher Fetcher
+	Weight  float64 // Relative weight for MergeWeighted, non-positive weights count as 1
+}
+
+// Result is the data a single provider returned for a merge
+type Result struct {
+	Name   string
+	Weight float64
+	Data   *BaseData
+}
+
+// Consensus is the merged BaseData along with how much the providers disagreed
+type Consensus struct {
+	BaseData
+	Method    MergeMethod       `json:"method"`
+	Providers []string          `json:"providers"`        // Providers that contributed to the consensus
+	Errors    map[string]string `json:"errors,omitempty"` // Providers that failed to deliver, with the reason
+	Spread    Spread            `json:"spread"`
+}
+
+// Spread holds, per field JSON name, the range (max - min) of the providers' values.
+// For directions it's the largest angular difference between any two providers, in degrees,
+// and for categories like weather_code it's the share of providers disagreeing with the consensus, from 0 to 1.
+type Spread struct {
+	Current map[string]float64   `json:"current"`
+	Hourly  map[string][]float64 `json:"hourly"`
+	Daily   map[string][]float64 `json:"daily"`
+}
+
+// Merger fetches from several providers concurrently and merges their data into a Consensus
+type Merger struct {
+	method  MergeMethod
+	sources []Source
+}
+
+// NewMerger returns a Merger over the given sources
+func NewMerger(method MergeMethod, sources ...Source) (*Merger, error) {
+	if err := method.Validate(); err != nil {
+		return nil, err
+	}
+	if len(sources) == 0 {
+		return nil, errors.New("merger needs at least one source")
+	}
+	for _, s := range sources {
+		if s.Fetcher == nil {
+			return nil, fmt.Errorf("source %s has no fetcher", s.Name)
+		}
+	}
+	return &Merger{method: method, sources: sources}, nil
+}
+
+// Merge fetches coords from every source concurrently and merges whatever arrived
+//
+// Sources that fail are listed in Consensus.Errors; Merge only fails if none of them delivered.
+func (m *Merger) Merge(ctx context.Context, coords *Coordinates) (*Consensus, error) {
+	results := make([]*Result, len(m.sources))
+	errs := make([]error, len(m.sources))
+
+	var wg sync.WaitGroup
+	for i, s := range m.sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bd, err := s.Fetcher.FetchData(ctx, coords)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			results[i] = &Result{Name: s.Name, Weight: s.Weight, Data: bd}
+		}()
+	}
+	wg.Wait()
+
+	var ok []Result
+	failed := make(map[string]string)
+	for i, r := range results {
+		if r == nil {
+			failed[m.sources[i].Name] = errs[i].Error()
+			continue
+		}
+		ok = append(ok, *r)
+	}
+	if len(ok) == 0 {
+		return nil, fmt.Errorf("all providers failed: %w", errors.Join(errs...))
+	}
+
+	c, err := Combine(m.method, ok)
+	if err != nil {
+		return nil, err
+	}
+	c.Latitude, c.Longitude = coords.Latitude, coords.Longitude
+	if len(failed) > 0 {
+		c.Errors = failed
+	}
+	return c, nil
+}
+
+// Combine merges data that was already fetched from several providers
+//
+// Hourly and daily series are aligned on the timestamps that every provider covers, so providers with
+// different horizons or resolutions still line up. Fields a provider doesn't report are left out of the
+// consensus for that provider alone; a field no provider reports stays empty.
+func Combine(method MergeMethod, results []Result) (*Consensus, error) {
+	if err := method.Validate(); err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, errors.New("nothing to combine")
+	}
+
+	c := &Consensus{
+		Method: method,
+		Spread: Spread{
+			Current: make(map[string]float64),
+			Hourly:  make(map[string][]float64),
+			Daily:   make(map[string][]float64),
+		},
+	}
+
+	weights := make([]float64, len(results))
+	var elevation []float64
+	for i, r := range results {
+		c.Providers = append(c.Providers, r.Name)
+		weights[i] = r.Weight
+		if weights[i] <= 0 {
+			weights[i] = 1
+		}
+		elevation = append(elevation, r.Data.Elevation)
+	}
+
+	first := results[0].Data
+	c.Latitude, c.Longitude = first.Latitude, first.Longitude
+	c.UTCOffsetSeconds = first.UTCOffsetSeconds
+	c.Timezone, c.TimezoneAbbreviation = first.Timezone, first.TimezoneAbbreviation
+	c.Elevation, _ = fold(method, elevation, weights, scalar)
+
+	mergeCurrent(method, results, weights, &c.Current, c.Spread.Current)
+	mergeSeries(method, results, weights, func(bd *BaseData) any { return &bd.Hourly }, &c.Hourly, c.Spread.Hourly)
+	mergeSeries(method, results, weights, func(bd *BaseData) any { return &bd.Daily }, &c.Daily, c.Spread.Daily)
+
+	return c, nil
+}
+
+// mergeCurrent folds the scalar fields of the providers' current conditions
+func mergeCurrent(method MergeMethod, results []Result, weights []float64, out *CurrentData, spread map[string]float64) {
+	var current []reflect.Value
+	var w []float64
+	for i, r := range results {
+		if r.Data.Current.Time == 0 {
+			continue // Provider didn't report current conditions
+		}
+		current = append(current, reflect.ValueOf(&r.Data.Current).Elem())
+		w = append(w, weights[i])
+	}
+	if len(current) == 0 {
+		return
+	}
+
+	dst := reflect.ValueOf(out).Elem()
+	typ := dst.Type()
+	for f := 0; f < typ.NumField(); f++ {
+		name := jsonName(typ.Field(f))
+		values := make([]float64, len(current))
+		for i, v := range current {
+			values[i] = toFloat(v.Field(f))
+		}
+
+		switch name {
+		case "time", "interval":
+			// The most recent observation time is the one that best describes the consensus
+			setFloat(dst.Field(f), slices.Max(values))
+			continue
+		}
+
+		v, s := fold(method, values, w, kindOf(name))
+		setFloat(dst.Field(f), v)
+		spread[name] = s
+	}
+}
+
+// mergeSeries folds the slice fields of HourlyData or DailyData on their common time axis
+//
+// block returns a pointer to the HourlyData or DailyData of a BaseData, out is a pointer of the same type.
+func mergeSeries(method MergeMethod, results []Result, weights []float64, block func(*BaseData) any, out any, spread map[string][]float64) {
+	var series []reflect.Value
+	var w []float64
+	for i, r := range results {
+		v := reflect.ValueOf(block(r.Data)).Elem()
+		if v.FieldByName("Time").Len() == 0 {
+			continue
+		}
+		series = append(series, v)
+		w = append(w, weights[i])
+	}
+	if len(series) == 0 {
+		return
+	}
+
+	axis := commonTimes(series)
+	if len(axis) == 0 {
+		return
+	}
+
+	// index[p][t] is the position of axis[t] in provider p's series
+	index := make([][]int, len(series))
+	for p, s := range series {
+		pos := make(map[int64]int)
+		times := s.FieldByName("Time")
+		for i := 0; i < times.Len(); i++ {
+			pos[times.Index(i).Int()] = i
+		}
+		index[p] = make([]int, len(axis))
+		for t, ts := range axis {
+			index[p][t] = pos[ts]
+		}
+	}
+
+	dst := reflect.ValueOf(out).Elem()
+	dst.FieldByName("Time").Set(reflect.ValueOf(axis))
+
+	typ := dst.Type()
+	for f := 0; f < typ.NumField(); f++ {
+		field := typ.Field(f)
+		if field.Name == "Time" || field.Type.Kind() != reflect.Slice {
+			continue
+		}
+		name := jsonName(field)
+		kind := kindOf(name)
+
+		// Only providers whose series covers their whole time axis take part for this field
+		var members []int
+		for p, s := range series {
+			if s.Field(f).Len() == s.FieldByName("Time").Len() {
+				members = append(members, p)
+			}
+		}
+		if len(members) == 0 {
+			continue
+		}
+
+		merged := reflect.MakeSlice(field.Type, len(axis), len(axis))
+		spreads := make([]float64, len(axis))
+		values := make([]float64, len(members))
+		mw := make([]float64, len(members))
+		for t := range axis {
+			for i, p := range members {
+				values[i] = toFloat(series[p].Field(f).Index(index[p][t]))
+				mw[i] = w[p]
+			}
+			v, s := fold(method, values, mw, kind)
+			setFloat(merged.Index(t), v)
+			spreads[t] = s
+		}
+		dst.Field(f).Set(merged)
+		spread[name] = spreads
+	}
+}
+
+// commonTimes returns the sorted timestamps present in every series
+func commonTimes(series []reflect.Value) []int64 {
+	count := make(map[int64]int)
+	for _, s := range series {
+		times := s.FieldByName("Time")
+		seen := make(map[int64]bool, times.Len())
+		for i := 0; i < times.Len(); i++ {
+			t := times.Index(i).Int()
+			if !seen[t] {
+				seen[t] = true
+				count[t]++
+			}
+		}
+	}
+
+	var axis []int64
+	for t, n := range count {
+		if n == len(series) {
+			axis = append(axis, t)
+		}
+	}
+	sort.Slice(axis, func(i, j int) bool { return axis[i] < axis[j] })
+	return axis
+}
+
+// fieldKind tells fold how the values of a field relate to each other
+type fieldKind int
+
+const (
+	scalar      fieldKind = iota // Plain quantities that can be averaged
+	direction                    // Angles in degrees that wrap around at 360
+	categorical                  // Codes and flags that can't be averaged
+)
+
+// kindOf classifies a field by its JSON name
+func kindOf(name string) fieldKind {
+	switch {
+	case strings.Contains(name, "direction"):
+		return direction
+	case name == "weather_code" || name == "is_day":
+		return categorical
+	default:
+		return scalar
+	}
+}
+
+// fold reduces the providers' values into the consensus value and their spread
+func fold(method MergeMethod, values, weights []float64, kind fieldKind) (float64, float64) {
+	switch kind {
+	case direction:
+		return foldDirections(method, values, weights)
+	case categorical:
+		return foldCategories(method, values, weights)
+	}
+
+	lo, hi := slices.Min(values), slices.Max(values)
+	switch method {
+	case MergeMedian:
+		return median(values), hi - lo
+	case MergeWeighted:
+		var sum, total float64
+		for i, v := range values {
+			sum += v * weights[i]
+			total += weights[i]
+		}
+		return sum / total, hi - lo
+	default:
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		return sum / float64(len(values)), hi - lo
+	}
+}
+
+// foldDirections reduces directions in degrees using a circular mean, so that 350° and 10° make 0° rather than 180°
+//
+// A circular median isn't well defined, so MergeMedian falls back to the unweighted circular mean.
+func foldDirections(method MergeMethod, values, weights []float64) (float64, float64) {
+	var x, y float64
+	for i, v := range values {
+		w := 1.0
+		if method == MergeWeighted {
+			w = weights[i]
+		}
+		rad := v * math.Pi / 180
+		x += w * math.Cos(rad)
+		y += w * math.Sin(rad)
+	}
+	mean := math.Mod(math.Atan2(y, x)*180/math.Pi+360, 360)
+
+	var spread float64
+	for i := range values {
+		for j := i + 1; j < len(values); j++ {
+			d := math.Abs(math.Mod(values[i]-values[j]+540, 360) - 180)
+			spread = math.Max(spread, d)
+		}
+	}
+	return mean, spread
+}
+
+// foldCategories picks the most common value, by weight for MergeWeighted, preferring the higher (more severe) code on ties
+func foldCategories(method MergeMethod, values, weights []float64) (float64, float64) {
+	votes := make(map[float64]float64)
+	var total float64
+	for i, v := range values {
+		w := 1.0
+		if method == MergeWeighted {
+			w = weights[i]
+		}
+		votes[v] += w
+		total += w
+	}
+
+	var mode float64
+	best := -1.0
+	for v, n := range votes {
+		if n > best || (n == best && v > mode) {
+			mode, best = v, n
+		}
+	}
+	return mode, 1 - best/total
+}
+
+func median(values []float64) float64 {
+	s := slices.Clone(values)
+	slices.Sort(s)
+	n := len(s)
+	if n%2 == 1 {
+		return s[n/2]
+	}
+	return (s[n/2-1] + s[n/2]) / 2
+}
+
+// jsonName returns the JSON key of a plumber struct field
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+func toFloat(v reflect.Value) float64 {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	default:
+		return 0
+	}
+}
+
+func setFloat(v reflect.Value, f float64) {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(f)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(math.Round(f)))
+	}
+}
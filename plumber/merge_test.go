@@ -0,0 +1,227 @@
+package plumber
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// mergeFixtures are the forecasts of three providers: a and b overlap at 3600 and 7200, c covers only those hours
+func mergeFixtures() (a, b, c *BaseData) {
+	a = &BaseData{
+		Latitude: 46.7, Longitude: 7.9, Elevation: 500,
+		Current: CurrentData{Time: 1000, Temperature2M: 10, WindDirection10M: 350, WeatherCode: 3},
+		Hourly: HourlyData{
+			Time:             []int64{0, 3600, 7200},
+			Temperature2M:    []float64{10, 12, 14},
+			WindDirection10M: []int{0, 90, 180},
+			WeatherCode:      []int{3, 61, 61},
+			Precipitation:    []float64{1, 2, 3},
+		},
+		Daily: DailyData{Time: []int64{0, 86400}, Temperature2MMax: []float64{20, 22}},
+	}
+	b = &BaseData{
+		Latitude: 46.8, Longitude: 7.8, Elevation: 700,
+		Current: CurrentData{Time: 1200, Temperature2M: 14, WindDirection10M: 30, WeatherCode: 61},
+		Hourly: HourlyData{
+			Time:             []int64{3600, 7200, 10800},
+			Temperature2M:    []float64{16, 18, 20},
+			WindDirection10M: []int{10, 270, 180},
+			WeatherCode:      []int{61, 3, 3},
+			Precipitation:    []float64{0.5}, // Shorter than the time axis, so left out
+		},
+		Daily: DailyData{Time: []int64{0, 86400}, Temperature2MMax: []float64{24, 26}},
+	}
+	c = &BaseData{
+		Hourly: HourlyData{
+			Time:             []int64{3600, 7200},
+			Temperature2M:    []float64{30, 40},
+			WindDirection10M: []int{10, 270},
+			WeatherCode:      []int{3, 3},
+		},
+	}
+	return a, b, c
+}
+
+func TestCombine(t *testing.T) {
+	a, b, c := mergeFixtures()
+	two := []Result{{Name: "a", Weight: 1, Data: a}, {Name: "b", Weight: 3, Data: b}}
+
+	tests := []struct {
+		name    string
+		method  MergeMethod
+		results []Result
+		check   func(t *testing.T, c *Consensus)
+	}{
+		{"mean", MergeMean, two, func(t *testing.T, c *Consensus) {
+			if !slices.Equal(c.Hourly.Time, []int64{3600, 7200}) {
+				t.Errorf("hourly time = %v, want the hours both providers cover", c.Hourly.Time)
+			}
+			wantSeries(t, "hourly temperature", c.Hourly.Temperature2M, []float64{14, 16})
+			wantSeries(t, "hourly temperature spread", c.Spread.Hourly["temperature_2m"], []float64{4, 4})
+			// 90° and 10° average to 50°, 180° and 270° to 225°, not to the 130° and 225° of their plain means
+			if !slices.Equal(c.Hourly.WindDirection10M, []int{50, 225}) {
+				t.Errorf("hourly wind direction = %v, want [50 225]", c.Hourly.WindDirection10M)
+			}
+			wantSeries(t, "hourly wind direction spread", c.Spread.Hourly["wind_direction_10m"], []float64{80, 90})
+			// A tie goes to the more severe code
+			if !slices.Equal(c.Hourly.WeatherCode, []int{61, 61}) {
+				t.Errorf("hourly weather code = %v, want [61 61]", c.Hourly.WeatherCode)
+			}
+			wantSeries(t, "hourly weather code spread", c.Spread.Hourly["weather_code"], []float64{0, 0.5})
+			wantSeries(t, "hourly precipitation", c.Hourly.Precipitation, []float64{2, 3})
+			if c.Hourly.Visibility != nil || c.Spread.Hourly["visibility"] != nil {
+				t.Errorf("visibility = %v, want none as no provider reports it", c.Hourly.Visibility)
+			}
+			wantSeries(t, "daily maximum temperature", c.Daily.Temperature2MMax, []float64{22, 24})
+
+			if c.Current.Time != 1200 || c.Current.Temperature2M != 12 || c.Spread.Current["temperature_2m"] != 4 {
+				t.Errorf("current = %+v, want the latest time and a temperature of 12 ± 4", c.Current)
+			}
+			// 350° and 30° meet at 10°, 40° apart
+			if c.Current.WindDirection10M != 10 || c.Spread.Current["wind_direction_10m"] != 40 {
+				t.Errorf("current wind direction = %d, spread %v, want 10, 40", c.Current.WindDirection10M, c.Spread.Current["wind_direction_10m"])
+			}
+			if c.Elevation != 600 || c.Latitude != a.Latitude || !slices.Equal(c.Providers, []string{"a", "b"}) {
+				t.Errorf("elevation %v, latitude %v, providers %v", c.Elevation, c.Latitude, c.Providers)
+			}
+		}},
+		{"weighted", MergeWeighted, two, func(t *testing.T, c *Consensus) {
+			wantSeries(t, "hourly temperature", c.Hourly.Temperature2M, []float64{15, 17})
+			// atan2(sin 90° + 3 sin 10°, cos 90° + 3 cos 10°) = 27.2°
+			if c.Hourly.WindDirection10M[0] != 27 {
+				t.Errorf("hourly wind direction = %v, want 27 at 3600", c.Hourly.WindDirection10M)
+			}
+			// b outvotes a three to one
+			if !slices.Equal(c.Hourly.WeatherCode, []int{61, 3}) {
+				t.Errorf("hourly weather code = %v, want [61 3]", c.Hourly.WeatherCode)
+			}
+			wantSeries(t, "hourly weather code spread", c.Spread.Hourly["weather_code"], []float64{0, 0.25})
+			if c.Current.Temperature2M != 13 || c.Elevation != 650 {
+				t.Errorf("current temperature %v, elevation %v, want 13, 650", c.Current.Temperature2M, c.Elevation)
+			}
+		}},
+		{"median", MergeMedian, []Result{{Name: "a", Data: a}, {Name: "b", Data: b}, {Name: "c", Data: c}}, func(t *testing.T, c *Consensus) {
+			wantSeries(t, "hourly temperature", c.Hourly.Temperature2M, []float64{16, 18})
+			wantSeries(t, "hourly temperature spread", c.Spread.Hourly["temperature_2m"], []float64{18, 26})
+			if !slices.Equal(c.Hourly.WeatherCode, []int{61, 3}) {
+				t.Errorf("hourly weather code = %v, want [61 3]", c.Hourly.WeatherCode)
+			}
+			// c reports no current conditions, so a and b alone make them up
+			if c.Current.Temperature2M != 12 {
+				t.Errorf("current temperature = %v, want 12", c.Current.Temperature2M)
+			}
+			// c has no daily data and leaves the daily consensus to a and b
+			wantSeries(t, "daily maximum temperature", c.Daily.Temperature2MMax, []float64{22, 24})
+		}},
+		{"no common hours", MergeMean, []Result{{Name: "a", Data: a}, {Name: "d", Data: &BaseData{Hourly: HourlyData{Time: []int64{36000}, Temperature2M: []float64{5}}}}}, func(t *testing.T, c *Consensus) {
+			if c.Hourly.Time != nil || c.Hourly.Temperature2M != nil {
+				t.Errorf("hourly = %+v, want none", c.Hourly)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Combine(tt.method, tt.results)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Method != tt.method {
+				t.Errorf("method = %s, want %s", got.Method, tt.method)
+			}
+			tt.check(t, got)
+		})
+	}
+
+	if _, err := Combine("mode", two); err == nil {
+		t.Error("combined with an unknown method")
+	}
+	if _, err := Combine(MergeMean, nil); err == nil {
+		t.Error("combined nothing")
+	}
+}
+
+func TestCommonTimes(t *testing.T) {
+	tests := []struct {
+		name   string
+		series []HourlyData
+		want   []int64
+	}{
+		{"same", []HourlyData{{Time: []int64{0, 3600}}, {Time: []int64{0, 3600}}}, []int64{0, 3600}},
+		{"overlap", []HourlyData{{Time: []int64{0, 3600, 7200}}, {Time: []int64{3600, 7200, 10800}}}, []int64{3600, 7200}},
+		{"coarser resolution", []HourlyData{{Time: []int64{0, 3600, 7200, 10800}}, {Time: []int64{10800, 0}}}, []int64{0, 10800}},
+		{"repeated hour", []HourlyData{{Time: []int64{0, 0, 3600}}, {Time: []int64{3600}}}, []int64{3600}},
+		{"disjoint", []HourlyData{{Time: []int64{0}}, {Time: []int64{3600}}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series := make([]reflect.Value, len(tt.series))
+			for i := range tt.series {
+				series[i] = reflect.ValueOf(&tt.series[i]).Elem()
+			}
+			if got := commonTimes(series); !slices.Equal(got, tt.want) {
+				t.Errorf("commonTimes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fetcherFunc adapts a function to a Fetcher
+type fetcherFunc func(ctx context.Context, coords *Coordinates) (*BaseData, error)
+
+func (f fetcherFunc) FetchData(ctx context.Context, coords *Coordinates) (*BaseData, error) {
+	return f(ctx, coords)
+}
+
+func TestMerge(t *testing.T) {
+	a, b, _ := mergeFixtures()
+	serve := func(bd *BaseData) Fetcher {
+		return fetcherFunc(func(context.Context, *Coordinates) (*BaseData, error) { return bd, nil })
+	}
+	fail := fetcherFunc(func(context.Context, *Coordinates) (*BaseData, error) { return nil, errors.New("upstream down") })
+
+	m, err := NewMerger(MergeMean, Source{Name: "a", Fetcher: serve(a)}, Source{Name: "b", Fetcher: serve(b)}, Source{Name: "down", Fetcher: fail})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := m.Merge(context.Background(), NewCoordinates(46.68, 7.86))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(c.Providers, []string{"a", "b"}) || c.Errors["down"] != "upstream down" || len(c.Errors) != 1 {
+		t.Errorf("providers %v, errors %v, want a and b with down failing", c.Providers, c.Errors)
+	}
+	if c.Latitude != 46.68 || c.Longitude != 7.86 {
+		t.Errorf("coordinates = %v, %v, want those asked for", c.Latitude, c.Longitude)
+	}
+	wantSeries(t, "hourly temperature", c.Hourly.Temperature2M, []float64{14, 16})
+
+	m, _ = NewMerger(MergeMean, Source{Name: "down", Fetcher: fail})
+	if _, err := m.Merge(context.Background(), NewCoordinates(46.68, 7.86)); err == nil {
+		t.Error("merged without a single provider delivering")
+	}
+
+	for _, sources := range [][]Source{nil, {{Name: "nil"}}} {
+		if _, err := NewMerger(MergeMean, sources...); err == nil {
+			t.Errorf("NewMerger(%v) succeeded", sources)
+		}
+	}
+}
+
+// wantSeries compares the values of a series to those expected, to within rounding
+func wantSeries(t *testing.T, name string, got, expected []float64) {
+	t.Helper()
+	if len(got) != len(expected) {
+		t.Errorf("%s = %v, want %v", name, got, expected)
+		return
+	}
+	for i := range got {
+		if math.Abs(got[i]-expected[i]) > 1e-9 {
+			t.Errorf("%s = %v, want %v", name, got, expected)
+			return
+		}
+	}
+}
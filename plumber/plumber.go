@@ -3,6 +3,11 @@
 // It defines structures and methods to filter and move meteo data from multiple providers.
 package plumber
 
+import (
+	"fmt"
+	"math"
+)
+
 type Coordinates struct {
 	Latitude  float64 // in degrees
 	Longitude float64 // in degrees
@@ -17,6 +22,17 @@ func NewCoordinates(latitude float64, longitude float64) *Coordinates {
 	}
 }
 
+// Validate reports whether the coordinates fall within the WGS84 latitude and longitude bounds
+func (c *Coordinates) Validate() error {
+	if math.IsNaN(c.Latitude) || c.Latitude < -90 || c.Latitude > 90 {
+		return fmt.Errorf("latitude %f out of range [-90, 90]", c.Latitude)
+	}
+	if math.IsNaN(c.Longitude) || c.Longitude < -180 || c.Longitude > 180 {
+		return fmt.Errorf("longitude %f out of range [-180, 180]", c.Longitude)
+	}
+	return nil
+}
+
 // Models like Copernicus GLO-90 provide elevation with an accuracy of <4 meters. So, it may not be necessary to capture accuracy separately.
 type Elevation uint // in meters
 
@@ -46,6 +62,12 @@ type BaseData struct {
 	Current              CurrentData `json:"current"`
 	Hourly               HourlyData  `json:"hourly"`
 	Daily                DailyData   `json:"daily"`
+	// Hourly air quality, aligned with the hourly time axis, if asked for; see SetAirQuality
+	AirQuality *AirQualityData `json:"air_quality,omitempty"`
+	// Hourly sea state, as fetched by the marine providers
+	Marine *MarineData `json:"marine,omitempty"`
+	// Units of the values per quantity, e.g. "speed": "kn", as set by units.System.Convert; nil means CommonUnits
+	Units map[string]string `json:"units,omitempty"`
 }
 
 /*
@@ -273,7 +295,7 @@ var CommonUnits = map[string]string{
 	"WindSpeed":                 "km/h",
 	"Humidity":                  "%",
 	"Pressure":                  "hPa",
-	"Visibility":                "km",
+	"Visibility":                "m",
 	"Precipitation":             "mm",
 	"CloudCover":                "%",
 	"SunshineHours":             "s",
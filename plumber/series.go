@@ -0,0 +1,202 @@
+package plumber
+
+import (
+	"math"
+	"reflect"
+	"slices"
+)
+
+// Index returns the position of the Unix timestamp t in the hourly time axis, or -1 if it isn't on it
+func (h *HourlyData) Index(t int64) int {
+	return slices.Index(h.Time, t)
+}
+
+// Values returns the value of every hourly field populated at position i, keyed by its JSON name
+func (h *HourlyData) Values(i int) map[string]float64 {
+	return seriesValues(reflect.ValueOf(h).Elem(), i)
+}
+
+// Value returns the value of the hourly field with the given JSON name at position i
+//
+// ok is false if there's no such field or it isn't populated at i.
+func (h *HourlyData) Value(name string, i int) (float64, bool) {
+	return seriesValue(reflect.ValueOf(h).Elem(), name, i)
+}
+
+// SetValues sets the hourly field with the given JSON name to values, in which nil is a missing value
+//
+// Missing values are NaN in float fields; integer fields can't hold one and end at the first.
+// ok is false if there's no such field.
+func (h *HourlyData) SetValues(name string, values []*float64) (ok bool) {
+	return setSeries(reflect.ValueOf(h).Elem(), name, values)
+}
+
+// Index returns the position of the Unix timestamp t in the daily time axis, or -1 if it isn't on it
+func (d *DailyData) Index(t int64) int {
+	return slices.Index(d.Time, t)
+}
+
+// Values returns the value of every daily field populated at position i, keyed by its JSON name
+func (d *DailyData) Values(i int) map[string]float64 {
+	return seriesValues(reflect.ValueOf(d).Elem(), i)
+}
+
+// Append extends the hourly data with o, whose time axis must follow on from h's
+//
+// Fields populated on one side only are dropped, so that every field stays aligned with the time axis.
+func (h *HourlyData) Append(o *HourlyData) {
+	appendSeries(reflect.ValueOf(h).Elem(), reflect.ValueOf(o).Elem())
+}
+
+// Append extends the daily data with o, whose time axis must follow on from d's
+//
+// Fields populated on one side only are dropped, so that every field stays aligned with the time axis.
+func (d *DailyData) Append(o *DailyData) {
+	appendSeries(reflect.ValueOf(d).Elem(), reflect.ValueOf(o).Elem())
+}
+
+// HourlyFields lists the JSON names of the hourly fields, excluding the time axis
+func HourlyFields() []string {
+	return seriesFields(reflect.TypeOf(HourlyData{}))
+}
+
+// DailyFields lists the JSON names of the daily fields, excluding the time axis
+func DailyFields() []string {
+	return seriesFields(reflect.TypeOf(DailyData{}))
+}
+
+func seriesFields(typ reflect.Type) []string {
+	var names []string
+	for f := 0; f < typ.NumField(); f++ {
+		if field := typ.Field(f); field.Name != "Time" && field.Type.Kind() == reflect.Slice {
+			names = append(names, jsonName(field))
+		}
+	}
+	return names
+}
+
+// appendSeries appends every slice field of src, a HourlyData or DailyData value, to those of dst
+func appendSeries(dst, src reflect.Value) {
+	dstLen, srcLen := dst.FieldByName("Time").Len(), src.FieldByName("Time").Len()
+	typ := dst.Type()
+	for f := 0; f < typ.NumField(); f++ {
+		if typ.Field(f).Type.Kind() != reflect.Slice {
+			continue
+		}
+		d, s := dst.Field(f), src.Field(f)
+		switch {
+		case s.Len() != srcLen || (d.Len() != dstLen && dstLen > 0):
+			d.SetZero()
+		case dstLen == 0:
+			d.Set(reflect.AppendSlice(reflect.MakeSlice(s.Type(), 0, s.Len()), s))
+		default:
+			d.Set(reflect.AppendSlice(d, s))
+		}
+	}
+}
+
+// pickSeries sets every slice field of dst to the values at the given positions of the same field of src,
+// both of the same series type; fields src doesn't populate along its whole time axis are left out
+func pickSeries(dst, src reflect.Value, positions []int) {
+	n := src.FieldByName("Time").Len()
+	for f := 0; f < src.NumField(); f++ {
+		s := src.Field(f)
+		if s.Kind() != reflect.Slice || s.Len() != n {
+			continue
+		}
+		picked := reflect.MakeSlice(s.Type(), len(positions), len(positions))
+		for i, p := range positions {
+			picked.Index(i).Set(s.Index(p))
+		}
+		dst.Field(f).Set(picked)
+	}
+}
+
+// seriesValues reads position i of every numeric slice field of a HourlyData or DailyData value, except the time axis
+func seriesValues(v reflect.Value, i int) map[string]float64 {
+	out := make(map[string]float64)
+	typ := v.Type()
+	for f := 0; f < typ.NumField(); f++ {
+		field := typ.Field(f)
+		if field.Name == "Time" || field.Type.Kind() != reflect.Slice || i < 0 || i >= v.Field(f).Len() {
+			continue
+		}
+		out[jsonName(field)] = toFloat(v.Field(f).Index(i))
+	}
+	return out
+}
+
+// seriesValue reads position i of the slice field with the given JSON name
+func seriesValue(v reflect.Value, name string, i int) (float64, bool) {
+	typ := v.Type()
+	for f := 0; f < typ.NumField(); f++ {
+		field := typ.Field(f)
+		if jsonName(field) != name || field.Type.Kind() != reflect.Slice {
+			continue
+		}
+		if i < 0 || i >= v.Field(f).Len() {
+			return 0, false
+		}
+		return toFloat(v.Field(f).Index(i)), true
+	}
+	return 0, false
+}
+
+// setSeries sets the slice field with the given JSON name, other than the time axis, to values with nil as missing
+func setSeries(v reflect.Value, name string, values []*float64) bool {
+	typ := v.Type()
+	for f := 0; f < typ.NumField(); f++ {
+		field := typ.Field(f)
+		if field.Name == "Time" || jsonName(field) != name || field.Type.Kind() != reflect.Slice {
+			continue
+		}
+		n := len(values)
+		if k := field.Type.Elem().Kind(); k != reflect.Float32 && k != reflect.Float64 {
+			if i := slices.Index(values, nil); i >= 0 {
+				n = i
+			}
+		}
+		s := reflect.MakeSlice(field.Type, n, n)
+		for i := range n {
+			x := math.NaN()
+			if values[i] != nil {
+				x = *values[i]
+			}
+			setFloat(s.Index(i), x)
+		}
+		v.Field(f).Set(s)
+		return true
+	}
+	return false
+}
+
+// nullSeries returns the fields of a HourlyData or DailyData value keyed by their JSON names, with the NaNs
+// of float fields, the missing values, as nil
+func nullSeries(v reflect.Value) map[string]any {
+	out := make(map[string]any, v.NumField())
+	typ := v.Type()
+	for f := 0; f < typ.NumField(); f++ {
+		s := v.Field(f)
+		if s.Kind() != reflect.Slice || s.IsNil() || (s.Type().Elem().Kind() != reflect.Float32 && s.Type().Elem().Kind() != reflect.Float64) {
+			out[jsonName(typ.Field(f))] = s.Interface()
+			continue
+		}
+		values := make([]*float64, s.Len())
+		for i := range values {
+			if x := s.Index(i).Float(); !math.IsNaN(x) {
+				values[i] = &x
+			}
+		}
+		out[jsonName(typ.Field(f))] = values
+	}
+	return out
+}
+
+// At returns s[i], or the zero value if the series is missing or too short, e.g. a variable left out of a response
+func At[T any](s []T, i int) T {
+	var zero T
+	if i < 0 || i >= len(s) {
+		return zero
+	}
+	return s[i]
+}
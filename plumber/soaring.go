@@ -0,0 +1,249 @@
+package plumber
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrNoBoundaryLayer is returned when the hourly data lacks the boundary layer height that thermals are estimated from
+var ErrNoBoundaryLayer = errors.New("hourly data has no boundary layer height")
+
+// SoaringLimits are the weather limits of the aircraft and pilot the flyability score is computed for
+type SoaringLimits struct {
+	MaxWindSpeed   float64 // Highest acceptable mean wind at launch, 10 m above ground, in km/h
+	MaxGusts       float64 // Highest acceptable gusts at launch in km/h
+	MaxCeilingWind float64 // Highest acceptable wind at the thermal ceiling in km/h
+	MaxWindShear   float64 // Highest acceptable wind shear between launch and ceiling in km/h per km
+	MinThermal     float64 // Weakest thermal strength worth soaring in m/s
+	MaxRain        float64 // Highest acceptable precipitation in mm per hour
+}
+
+// ParaglidingLimits are conservative limits for an intermediate paraglider pilot
+var ParaglidingLimits = SoaringLimits{
+	MaxWindSpeed:   25,
+	MaxGusts:       30,
+	MaxCeilingWind: 35,
+	MaxWindShear:   15,
+	MinThermal:     1,
+	MaxRain:        0.1,
+}
+
+// Soaring is the flyability forecast for free flight in a single hour
+type Soaring struct {
+	Time int64 `json:"time"` // Unix timestamp
+
+	Score   float64  `json:"score"`            // Flyability from 0 (stay home) to 10 (epic)
+	Flyable bool     `json:"flyable"`          // Whether the hour is within the limits at all
+	Limits  []string `json:"limits,omitempty"` // Factors that make the hour unflyable or degrade the score
+
+	ThermalStrength     float64  `json:"thermal_strength"`     // Estimated updraft velocity of thermals in m/s
+	ThermalCeiling      float64  `json:"thermal_ceiling"`      // Usable top of the thermals above mean sea level in m
+	CumulusBase         *float64 `json:"cumulus_base"`         // Cumulus cloud base above mean sea level in m, nil on blue days
+	WindSpeed           float64  `json:"wind_speed"`           // Mean wind at launch, 10 m above ground, in km/h
+	WindGusts           float64  `json:"wind_gusts"`           // Gusts at launch in km/h
+	CeilingWindSpeed    float64  `json:"ceiling_wind_speed"`   // Wind at the thermal ceiling in km/h
+	WindShear           float64  `json:"wind_shear"`           // Vector wind difference between launch and ceiling in km/h per km
+	OverdevelopmentRisk float64  `json:"overdevelopment_risk"` // Risk of cumulus growing into showers or thunderstorms from 0 to 1
+}
+
+// Soaring computes the hourly flyability forecast for free flight at the location of the data
+//
+// The thermal strength is the Deardorff convective velocity scale w* of the boundary layer, with the
+// surface heating estimated from the sunshine or, failing that, the cloud cover; it's the strength of
+// the updraft, not the climb rate of an aircraft. When the data carries a pressure level profile, the
+// ceiling is capped by the cumulus base and the overdevelopment risk also considers the K-index.
+func (bd *BaseData) Soaring(limits SoaringLimits) ([]Soaring, error) {
+	h := &bd.Hourly
+	if len(h.Time) == 0 {
+		return nil, errors.New("no hourly data")
+	}
+	if len(h.BoundaryLayerHeight) < len(h.Time) {
+		return nil, ErrNoBoundaryLayer
+	}
+
+	out := make([]Soaring, len(h.Time))
+	for i := range h.Time {
+		var sounding *Sounding
+		var convection *Convection
+		if s, err := h.Sounding(i, bd.Elevation); err == nil {
+			c := s.Convection()
+			sounding, convection = s, &c
+		}
+		out[i] = soaringHour(bd, i, sounding, convection, limits)
+	}
+	return out, nil
+}
+
+// soaringHour assembles the forecast for the i-th hour; sounding and convection are nil without a profile
+func soaringHour(bd *BaseData, i int, sounding *Sounding, convection *Convection, limits SoaringLimits) Soaring {
+	h := &bd.Hourly
+	s := Soaring{
+		Time:      h.Time[i],
+		WindSpeed: At(h.WindSpeed10M, i),
+		WindGusts: At(h.WindGusts10M, i),
+	}
+
+	// The boundary layer is where thermals live, the cumulus base caps how high they can be used
+	s.ThermalCeiling = bd.Elevation + At(h.BoundaryLayerHeight, i)
+	if convection != nil && convection.CumulusBase != nil {
+		s.CumulusBase = convection.CumulusBase
+		s.ThermalCeiling = math.Min(s.ThermalCeiling, *convection.CumulusBase)
+	}
+	depth := math.Max(s.ThermalCeiling-bd.Elevation, 0)
+
+	isDay := At(h.IsDay, i) == 1 || (len(h.IsDay) == 0 && At(h.SunshineDuration, i) > 0)
+	if isDay {
+		s.ThermalStrength = convectiveVelocity(depth, solarHeating(h, i))
+	}
+
+	ceilingSpeed, ceilingDirection := windAt(h, i, sounding, bd.Elevation, s.ThermalCeiling)
+	s.CeilingWindSpeed = ceilingSpeed
+	if depth > 0 {
+		du := windComponent(ceilingSpeed, ceilingDirection, math.Sin) - windComponent(s.WindSpeed, float64(At(h.WindDirection10M, i)), math.Sin)
+		dv := windComponent(ceilingSpeed, ceilingDirection, math.Cos) - windComponent(s.WindSpeed, float64(At(h.WindDirection10M, i)), math.Cos)
+		s.WindShear = math.Hypot(du, dv) / (depth / 1000)
+	}
+
+	s.OverdevelopmentRisk = overdevelopmentRisk(h, i, convection)
+
+	s.Flyable = true
+	reject := func(reason string) {
+		s.Flyable = false
+		s.Limits = append(s.Limits, reason)
+	}
+	if !isDay {
+		reject("night")
+	}
+	if s.WindSpeed > limits.MaxWindSpeed {
+		reject("wind")
+	}
+	if s.WindGusts > limits.MaxGusts {
+		reject("gusts")
+	}
+	if s.CeilingWindSpeed > limits.MaxCeilingWind {
+		reject("ceiling_wind")
+	}
+	if At(h.Precipitation, i) > limits.MaxRain {
+		reject("rain")
+	}
+	if s.OverdevelopmentRisk >= 0.8 {
+		reject("overdevelopment")
+	}
+
+	// The score rewards strong and high thermals and is eroded by wind, shear, gusts and storm risk
+	thermals := clamp(s.ThermalStrength/3, 0, 1) * clamp(depth/2000, 0, 1)
+	// Wind and shear only start to hurt at half their limit, gusts once they exceed the mean wind by 5 km/h
+	wind := 1 - clamp((s.WindSpeed-limits.MaxWindSpeed/2)/(limits.MaxWindSpeed/2), 0, 1)*0.5
+	gusts := 1 - clamp((s.WindGusts-s.WindSpeed-5)/15, 0, 1)*0.5
+	shear := 1 - clamp((s.WindShear-limits.MaxWindShear/2)/(limits.MaxWindShear/2), 0, 1)*0.5
+	storms := 1 - s.OverdevelopmentRisk*0.8
+	if s.ThermalStrength < limits.MinThermal {
+		s.Limits = append(s.Limits, "weak_thermals")
+	}
+	if s.WindShear > limits.MaxWindShear {
+		s.Limits = append(s.Limits, "wind_shear")
+	}
+	if s.Flyable {
+		s.Score = math.Round(100*thermals*wind*gusts*shear*storms) / 10
+	}
+	return s
+}
+
+// solarHeating estimates the share, 0 to 1, of full sunshine heating the ground in the i-th hour
+func solarHeating(h *HourlyData, i int) float64 {
+	if len(h.SunshineDuration) > i {
+		return clamp(h.SunshineDuration[i]/3600, 0, 1)
+	}
+	// Low clouds block most of the sun, high ones hardly any
+	cover := 0.75*float64(At(h.CloudCoverLow, i)) + 0.5*float64(At(h.CloudCoverMid, i)) + 0.2*float64(At(h.CloudCoverHigh, i))
+	if len(h.CloudCoverLow) == 0 {
+		cover = 0.75 * float64(At(h.CloudCover, i))
+	}
+	return 1 - clamp(cover/100, 0, 1)
+}
+
+// convectiveVelocity returns the Deardorff convective velocity scale in m/s for a boundary layer of the given depth in m
+//
+// The surface sensible heat flux is taken as 250 W/m² under full sun, scaled down by heating.
+func convectiveVelocity(depth, heating float64) float64 {
+	const (
+		maxHeatFlux = 250.0 // W/m²
+		theta       = 300.0 // Typical boundary layer potential temperature in K
+		rho         = 1.15  // Air density in kg/m³
+	)
+	kinematicFlux := maxHeatFlux * heating / (rho * cpd)
+	return math.Cbrt(9.81 / theta * kinematicFlux * depth)
+}
+
+// windAt returns the wind speed in km/h and direction in degrees at height z in m above mean sea level
+// of a location at the given elevation
+//
+// It interpolates the pressure level profile when there's one, otherwise the 10-180 m winds,
+// holding the highest of them above 180 m.
+func windAt(h *HourlyData, i int, sounding *Sounding, elevation, z float64) (float64, float64) {
+	type sample struct{ height, speed, direction float64 }
+	var samples []sample
+	if sounding != nil {
+		for _, l := range sounding.Profile() {
+			samples = append(samples, sample{l.Height, l.WindSpeed, l.WindDirection})
+		}
+	} else {
+		for _, w := range []struct {
+			agl       float64
+			speed     []float64
+			direction []int
+		}{
+			{10, h.WindSpeed10M, h.WindDirection10M},
+			{80, h.WindSpeed80M, h.WindDirection80M},
+			{120, h.WindSpeed120M, h.WindDirection120M},
+			{180, h.WindSpeed180M, h.WindDirection180M},
+		} {
+			if len(w.speed) > i {
+				samples = append(samples, sample{elevation + w.agl, w.speed[i], float64(At(w.direction, i))})
+			}
+		}
+	}
+	if len(samples) == 0 {
+		return 0, 0
+	}
+
+	if z <= samples[0].height {
+		return samples[0].speed, samples[0].direction
+	}
+	for j := 1; j < len(samples); j++ {
+		lo, hi := samples[j-1], samples[j]
+		if z <= hi.height {
+			f := (z - lo.height) / (hi.height - lo.height)
+			u := windComponent(lo.speed, lo.direction, math.Sin)*(1-f) + windComponent(hi.speed, hi.direction, math.Sin)*f
+			v := windComponent(lo.speed, lo.direction, math.Cos)*(1-f) + windComponent(hi.speed, hi.direction, math.Cos)*f
+			return math.Hypot(u, v), math.Mod(math.Atan2(u, v)*180/math.Pi+360, 360)
+		}
+	}
+	last := samples[len(samples)-1]
+	return last.speed, last.direction
+}
+
+// windComponent projects a wind onto an axis; math.Sin gives the east-west and math.Cos the north-south component
+func windComponent(speed, direction float64, axis func(float64) float64) float64 {
+	return speed * axis(direction*math.Pi/180)
+}
+
+// overdevelopmentRisk rates from 0 to 1 how likely cumulus grow into showers or thunderstorms
+func overdevelopmentRisk(h *HourlyData, i int, convection *Convection) float64 {
+	risk := clamp((At(h.Cape, i)-300)/1700, 0, 1)
+	if len(h.LiftedIndex) > i {
+		risk = math.Max(risk, clamp(-h.LiftedIndex[i]/6, 0, 1))
+	}
+	if convection != nil && convection.KIndex != nil {
+		risk = math.Max(risk, clamp((*convection.KIndex-20)/20, 0, 1))
+	}
+	// Without cumulus there's nothing to overdevelop
+	if convection != nil && convection.CumulusBase == nil {
+		risk *= 0.5
+	}
+	return risk
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
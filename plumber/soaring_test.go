@@ -0,0 +1,171 @@
+package plumber
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestConvectiveVelocity(t *testing.T) {
+	// w* = (g/θ · H/(ρ cp) · zi)^(1/3) with H = 250 W/m² under full sun
+	tests := []struct {
+		depth, heating float64
+		want           float64
+	}{
+		{1000, 1, 1.92},
+		{1500, 1, 2.20},
+		{2500, 1, 2.60},
+		{1500, 0.5, 1.74},
+		{1500, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := convectiveVelocity(tt.depth, tt.heating); math.Abs(got-tt.want) > 0.01 {
+			t.Errorf("convectiveVelocity(%v, %v) = %.3f m/s, want %.2f m/s", tt.depth, tt.heating, got, tt.want)
+		}
+	}
+}
+
+// soaringFixture returns a single sunny hour at 500 m with a 1500 m deep boundary layer and a light
+// westerly, without a pressure level profile, after applying mod to it
+func soaringFixture(mod func(h *HourlyData)) *BaseData {
+	bd := &BaseData{Elevation: 500}
+	bd.Hourly = HourlyData{
+		Time:                []int64{1718870400},
+		IsDay:               []int{1},
+		SunshineDuration:    []float64{3600},
+		BoundaryLayerHeight: []float64{1500},
+		WindSpeed10M:        []float64{10},
+		WindDirection10M:    []int{270},
+		WindGusts10M:        []float64{15},
+	}
+	if mod != nil {
+		mod(&bd.Hourly)
+	}
+	return bd
+}
+
+func TestSoaring(t *testing.T) {
+	// Full sun over 1500 m gives w* = 2.20 m/s, worth 2.20/3 · 1500/2000 = 0.55 of the score
+	tests := []struct {
+		name     string
+		mod      func(h *HourlyData)
+		score    float64
+		flyable  bool
+		limits   []string
+		strength float64
+	}{
+		{"sunny and calm", nil, 5.5, true, nil, 2.20},
+		{"night", func(h *HourlyData) { h.IsDay = []int{0} }, 0, false, []string{"night", "weak_thermals"}, 0},
+		{"no day flag, sunshine", func(h *HourlyData) { h.IsDay = nil }, 5.5, true, nil, 2.20},
+		// Overcast with no low clouds known blocks 75% of the sun: w* = 2.20 · 0.25^(1/3)
+		{"cloud cover without sunshine", func(h *HourlyData) { h.SunshineDuration, h.CloudCover = nil, []int{100} }, 3.5, true, nil, 1.38},
+		{"low clouds without sunshine", func(h *HourlyData) {
+			h.SunshineDuration, h.CloudCoverLow, h.CloudCoverMid, h.CloudCoverHigh = nil, []int{100}, []int{0}, []int{0}
+		}, 3.5, true, nil, 1.38},
+		// Wind only erodes the score past half the limit: 1 - (20-12.5)/12.5 · 0.5 = 0.7
+		{"breezy", func(h *HourlyData) { h.WindSpeed10M, h.WindGusts10M = []float64{20}, []float64{20} }, 3.8, true, nil, 2.20},
+		// Gusts 10 km/h beyond the mean wind plus 5: 1 - 10/15 · 0.5
+		{"gusty", func(h *HourlyData) { h.WindGusts10M = []float64{25} }, 3.7, true, nil, 2.20},
+		{"too windy", func(h *HourlyData) { h.WindSpeed10M, h.WindGusts10M = []float64{30}, []float64{32} }, 0, false, []string{"wind", "gusts"}, 2.20},
+		{"rain", func(h *HourlyData) { h.Precipitation = []float64{0.5} }, 0, false, []string{"rain"}, 2.20},
+		// CAPE of 1150 J/kg is a risk of 0.5, which takes 0.4 off the score
+		{"unstable", func(h *HourlyData) { h.Cape = []float64{1150} }, 3.3, true, nil, 2.20},
+		{"lifted index", func(h *HourlyData) { h.LiftedIndex = []float64{-3} }, 3.3, true, nil, 2.20},
+		{"overdevelopment", func(h *HourlyData) { h.Cape = []float64{2000} }, 0, false, []string{"overdevelopment"}, 2.20},
+		{"shallow boundary layer", func(h *HourlyData) { h.BoundaryLayerHeight = []float64{100} }, 0.1, true, []string{"weak_thermals"}, 0.89},
+		// 10 km/h from the east at 180 m against 10 km/h from the west at 10 m: 20 km/h over the 1.5 km deep layer
+		{"shear", func(h *HourlyData) { h.WindSpeed180M, h.WindDirection180M = []float64{10}, []int{90} }, 3.4, true, nil, 2.20},
+		{"strong wind aloft", func(h *HourlyData) { h.WindSpeed180M, h.WindDirection180M = []float64{40}, []int{270} }, 0, false, []string{"ceiling_wind", "wind_shear"}, 2.20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := soaringFixture(tt.mod).Soaring(ParaglidingLimits)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := got[0]
+			if s.Score != tt.score || s.Flyable != tt.flyable {
+				t.Errorf("score %v, flyable %v, want %v, %v", s.Score, s.Flyable, tt.score, tt.flyable)
+			}
+			if !slices.Equal(s.Limits, tt.limits) {
+				t.Errorf("limits %v, want %v", s.Limits, tt.limits)
+			}
+			if math.Abs(s.ThermalStrength-tt.strength) > 0.01 {
+				t.Errorf("thermal strength %.3f m/s, want %.2f m/s", s.ThermalStrength, tt.strength)
+			}
+		})
+	}
+}
+
+func TestSoaringErrors(t *testing.T) {
+	if _, err := (&BaseData{}).Soaring(ParaglidingLimits); err == nil {
+		t.Error("no error without hourly data")
+	}
+	bd := soaringFixture(func(h *HourlyData) { h.BoundaryLayerHeight = nil })
+	if _, err := bd.Soaring(ParaglidingLimits); !errors.Is(err, ErrNoBoundaryLayer) {
+		t.Errorf("err = %v, want ErrNoBoundaryLayer", err)
+	}
+}
+
+func TestSoaringCumulusBase(t *testing.T) {
+	// The cumulus base at 1500 m caps the 2000 m boundary layer top: w* of a 1 km layer is 1.92 m/s
+	bd := soaringFixture(nil)
+	s := soaringHour(bd, 0, nil, &Convection{CumulusBase: ptr(1500)}, ParaglidingLimits)
+	if s.ThermalCeiling != 1500 || s.CumulusBase == nil || *s.CumulusBase != 1500 {
+		t.Errorf("ceiling %v, cumulus base %v, want 1500", s.ThermalCeiling, s.CumulusBase)
+	}
+	if math.Abs(s.ThermalStrength-1.92) > 0.01 || s.Score != 3.2 {
+		t.Errorf("thermal strength %.3f m/s, score %v, want 1.92 m/s, 3.2", s.ThermalStrength, s.Score)
+	}
+}
+
+func TestWindAt(t *testing.T) {
+	tests := []struct {
+		name             string
+		mod              func(h *HourlyData)
+		z                float64
+		speed, direction float64
+	}{
+		{"below the lowest wind", nil, 400, 10, 270},
+		{"above the highest wind", nil, 3000, 10, 270},
+		{"halfway in speed", func(h *HourlyData) { h.WindSpeed180M, h.WindDirection180M = []float64{30}, []int{270} }, 595, 20, 270},
+		{"halfway in direction", func(h *HourlyData) {
+			h.WindDirection10M, h.WindSpeed180M, h.WindDirection180M = []int{0}, []float64{10}, []int{90}
+		}, 595, 7.07, 45},
+		{"through north", func(h *HourlyData) {
+			h.WindDirection10M, h.WindSpeed180M, h.WindDirection180M = []int{350}, []float64{10}, []int{10}
+		}, 595, 9.85, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bd := soaringFixture(tt.mod)
+			speed, direction := windAt(&bd.Hourly, 0, nil, bd.Elevation, tt.z)
+			if math.Abs(speed-tt.speed) > 0.01 || math.Abs(math.Mod(direction-tt.direction+540, 360)-180) > 0.01 {
+				t.Errorf("windAt(%v) = %.2f km/h from %.2f°, want %.2f km/h from %.2f°", tt.z, speed, direction, tt.speed, tt.direction)
+			}
+		})
+	}
+}
+
+func TestOverdevelopmentRisk(t *testing.T) {
+	tests := []struct {
+		name       string
+		cape, li   []float64
+		convection *Convection
+		want       float64
+	}{
+		{"stable", []float64{100}, nil, nil, 0},
+		{"CAPE", []float64{1150}, nil, nil, 0.5},
+		{"lifted index beyond CAPE", []float64{1150}, []float64{-4.5}, nil, 0.75},
+		{"K-index", nil, nil, &Convection{KIndex: ptr(35), CumulusBase: ptr(1500)}, 0.75},
+		{"blue thermals halve it", nil, nil, &Convection{KIndex: ptr(35)}, 0.375},
+		{"capped at 1", []float64{5000}, nil, nil, 1},
+	}
+	for _, tt := range tests {
+		h := &HourlyData{Time: []int64{0}, Cape: tt.cape, LiftedIndex: tt.li}
+		if got := overdevelopmentRisk(h, 0, tt.convection); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: risk %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package plumber
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// PressureLevels lists the isobaric levels, in hPa, that HourlyData carries upper air data for
+var PressureLevels = []int{1000, 975, 950, 925, 900, 850, 800, 700, 600, 500, 400}
+
+var (
+	// ErrNoProfile is returned when the hourly data carries no pressure level data to build a sounding from
+	ErrNoProfile = errors.New("hourly data has no pressure level profile")
+	// ErrNoSurface is returned when the hourly data lacks the 2 m temperature or humidity a sounding starts from
+	ErrNoSurface = errors.New("hourly data has no 2 m temperature and humidity")
+)
+
+// Level is a single point of a vertical profile of the atmosphere
+type Level struct {
+	Pressure         float64 `json:"pressure"`          // in hPa
+	Height           float64 `json:"height"`            // Geopotential height above mean sea level in m
+	Temperature      float64 `json:"temperature"`       // in °C
+	DewPoint         float64 `json:"dew_point"`         // in °C
+	RelativeHumidity float64 `json:"relative_humidity"` // in %
+	WindSpeed        float64 `json:"wind_speed"`        // in km/h
+	WindDirection    float64 `json:"wind_direction"`    // in degrees
+}
+
+// Sounding is the vertical profile of the atmosphere above a location at a given time, like a radiosonde ascent
+type Sounding struct {
+	Time      int64   `json:"time"`      // Unix timestamp
+	Elevation float64 `json:"elevation"` // Ground elevation above mean sea level in m
+	Surface   Level   `json:"surface"`   // Conditions 2 m above ground
+	Levels    []Level `json:"levels"`    // Pressure levels above ground, from the bottom up
+
+	// isobaric holds every pressure level, including those below ground, for indices defined on fixed levels
+	isobaric []Level
+}
+
+// Soundings builds a sounding for every hour of the hourly data
+//
+// elevation is the ground elevation of the location, normally BaseData.Elevation.
+func (h *HourlyData) Soundings(elevation float64) ([]Sounding, error) {
+	soundings := make([]Sounding, 0, len(h.Time))
+	for i := range h.Time {
+		s, err := h.Sounding(i, elevation)
+		if err != nil {
+			return nil, err
+		}
+		soundings = append(soundings, *s)
+	}
+	return soundings, nil
+}
+
+// Sounding builds the sounding for the i-th hour of the hourly data
+//
+// Pressure levels that lie below ground, i.e. at a higher pressure than the surface or below the ground
+// elevation, are left out as the models extrapolate them. So are levels missing their temperature, height or
+// humidity, rather than guessing a dew point; the surface can't be left out, so without its temperature and
+// either its dew point or humidity the error wraps ErrNoSurface.
+func (h *HourlyData) Sounding(i int, elevation float64) (*Sounding, error) {
+	if i < 0 || i >= len(h.Time) {
+		return nil, fmt.Errorf("hour %d out of range [0, %d)", i, len(h.Time))
+	}
+
+	surfacePressure := At(h.SurfacePressure, i)
+	if surfacePressure == 0 {
+		// Fall back to reducing the sea level pressure to the ground with the barometric formula
+		surfacePressure = At(h.PressureMSL, i) * math.Pow(1-0.0065*elevation/288.15, 5.255)
+	}
+
+	if i >= len(h.Temperature2M) || (i >= len(h.DewPoint2M) && i >= len(h.RelativeHumidity2M)) {
+		return nil, fmt.Errorf("%w at hour %d", ErrNoSurface, i)
+	}
+	temperature := h.Temperature2M[i]
+	humidity := float64(At(h.RelativeHumidity2M, i))
+	dewPoint := At(h.DewPoint2M, i)
+	switch {
+	case i >= len(h.DewPoint2M):
+		dewPoint = DewPoint(temperature, humidity)
+	case i >= len(h.RelativeHumidity2M):
+		humidity = RelativeHumidity(temperature, dewPoint)
+	}
+
+	s := &Sounding{
+		Time:      h.Time[i],
+		Elevation: elevation,
+		Surface: Level{
+			Pressure:         surfacePressure,
+			Height:           elevation + 2,
+			Temperature:      temperature,
+			DewPoint:         dewPoint,
+			RelativeHumidity: humidity,
+			WindSpeed:        At(h.WindSpeed10M, i),
+			WindDirection:    float64(At(h.WindDirection10M, i)),
+		},
+	}
+
+	v := reflect.ValueOf(h).Elem()
+	for _, p := range PressureLevels {
+		t, ok := levelValue(v, "Temperature", p, i)
+		if !ok {
+			continue
+		}
+		gh, ok := levelValue(v, "GeopotentialHeight", p, i)
+		if !ok {
+			continue
+		}
+		rh, ok := levelValue(v, "RelativeHumidity", p, i)
+		if !ok {
+			continue
+		}
+
+		ws, _ := levelValue(v, "WindSpeed", p, i)
+		wd, _ := levelValue(v, "WindDirection", p, i)
+		l := Level{
+			Pressure:         float64(p),
+			Height:           gh,
+			Temperature:      t,
+			DewPoint:         DewPoint(t, rh),
+			RelativeHumidity: rh,
+			WindSpeed:        ws,
+			WindDirection:    wd,
+		}
+		s.isobaric = append(s.isobaric, l)
+		if float64(p) < surfacePressure && gh > elevation {
+			s.Levels = append(s.Levels, l)
+		}
+	}
+
+	if len(s.Levels) == 0 {
+		return nil, ErrNoProfile
+	}
+	sort.Slice(s.Levels, func(a, b int) bool { return s.Levels[a].Pressure > s.Levels[b].Pressure })
+	return s, nil
+}
+
+// levelValue reads the i-th value of a pressure level series, e.g. Temperature850hPa, from a HourlyData value
+func levelValue(h reflect.Value, variable string, hPa int, i int) (float64, bool) {
+	f := h.FieldByName(fmt.Sprintf("%s%dhPa", variable, hPa))
+	if !f.IsValid() || i >= f.Len() {
+		return 0, false
+	}
+	return toFloat(f.Index(i)), true
+}
+
+// Profile returns the surface followed by the levels above it, from the bottom up
+func (s *Sounding) Profile() []Level {
+	return append([]Level{s.Surface}, s.Levels...)
+}
+
+// At interpolates the environmental temperature and dew point at pressure p in hPa, linearly in ln(p)
+//
+// ok is false if p lies outside of the sounding.
+func (s *Sounding) At(p float64) (temperature, dewPoint float64, ok bool) {
+	profile := s.Profile()
+	for j := 1; j < len(profile); j++ {
+		lower, upper := profile[j-1], profile[j]
+		if p <= lower.Pressure && p >= upper.Pressure {
+			f := math.Log(lower.Pressure/p) / math.Log(lower.Pressure/upper.Pressure)
+			return lower.Temperature + f*(upper.Temperature-lower.Temperature),
+				lower.DewPoint + f*(upper.DewPoint-lower.DewPoint), true
+		}
+	}
+	return 0, 0, false
+}
+
+// HeightAt interpolates the height above mean sea level of pressure p in hPa, linearly in ln(p)
+func (s *Sounding) HeightAt(p float64) (float64, bool) {
+	profile := s.Profile()
+	for j := 1; j < len(profile); j++ {
+		lower, upper := profile[j-1], profile[j]
+		if p <= lower.Pressure && p >= upper.Pressure {
+			f := math.Log(lower.Pressure/p) / math.Log(lower.Pressure/upper.Pressure)
+			return lower.Height + f*(upper.Height-lower.Height), true
+		}
+	}
+	return 0, false
+}
+
+// TemperatureAtHeight interpolates the environmental temperature in °C at height z in m above mean sea level
+func (s *Sounding) TemperatureAtHeight(z float64) (float64, bool) {
+	profile := s.Profile()
+	for j := 1; j < len(profile); j++ {
+		lower, upper := profile[j-1], profile[j]
+		if z >= lower.Height && z <= upper.Height {
+			f := (z - lower.Height) / (upper.Height - lower.Height)
+			return lower.Temperature + f*(upper.Temperature-lower.Temperature), true
+		}
+	}
+	return 0, false
+}
+
+// level returns the level at exactly p hPa, even if it lies below ground
+func (s *Sounding) level(p float64) (Level, bool) {
+	levels := s.isobaric
+	if levels == nil {
+		levels = s.Levels
+	}
+	for _, l := range levels {
+		if l.Pressure == p {
+			return l, true
+		}
+	}
+	return Level{}, false
+}
+
+const (
+	rd             = 287.04  // Gas constant of dry air in J/(kg K)
+	cpd            = 1005.7  // Specific heat of dry air at constant pressure in J/(kg K)
+	lv             = 2.501e6 // Latent heat of vaporisation in J/kg
+	epsilon        = 0.622   // Ratio of the molar masses of water vapour and dry air
+	kelvin         = 273.15
+	dryLapseRate   = 9.8 // Dry adiabatic lapse rate in °C/km
+	moistStepHPa   = 5   // Pressure step for integrating the moist adiabat
+	capeStepHPa    = 5   // Pressure step for integrating CAPE and CIN
+	espyMetresPerK = 125 // Espy's approximation of the LCL height per degree of dew point depression
+)
+
+// DewPoint approximates the dew point in °C from temperature in °C and relative humidity in % using the Magnus formula
+func DewPoint(temperature, humidity float64) float64 {
+	const a, b = 17.625, 243.04
+	humidity = math.Max(humidity, 1) // Keeps the logarithm finite for bone dry samples
+	gamma := math.Log(humidity/100) + a*temperature/(b+temperature)
+	return b * gamma / (a - gamma)
+}
+
+// RelativeHumidity approximates the relative humidity in % from temperature and dew point in °C, the inverse of DewPoint
+func RelativeHumidity(temperature, dewPoint float64) float64 {
+	const a, b = 17.625, 243.04
+	return math.Min(100*math.Exp(a*dewPoint/(b+dewPoint)-a*temperature/(b+temperature)), 100)
+}
+
+// ApparentTemperature approximates the felt temperature in °C from temperature in °C, relative humidity in %
+// and wind speed in km/h, following the Australian Bureau of Meteorology's formula that open-meteo uses too
+func ApparentTemperature(temperature, humidity, windSpeed float64) float64 {
+	vapourPressure := humidity / 100 * 6.105 * math.Exp(17.27*temperature/(237.7+temperature))
+	return temperature + 0.33*vapourPressure - 0.70*windSpeed/3.6 - 4.00
+}
+
+// saturationVapourPressure in hPa over water at temperature t in °C (Bolton, 1980)
+func saturationVapourPressure(t float64) float64 {
+	return 6.112 * math.Exp(17.67*t/(t+243.5))
+}
+
+// saturationMixingRatio in kg/kg at pressure p in hPa and temperature t in °C
+func saturationMixingRatio(p, t float64) float64 {
+	es := saturationVapourPressure(t)
+	return epsilon * es / (p - es)
+}
+
+// lcl returns the pressure in hPa and temperature in °C of the lifting condensation level of a parcel (Bolton, 1980)
+func lcl(p, t, td float64) (float64, float64) {
+	tk, tdk := t+kelvin, td+kelvin
+	tlcl := 1/(1/(tdk-56)+math.Log(tk/tdk)/800) + 56
+	plcl := p * math.Pow(tlcl/tk, cpd/rd)
+	return plcl, tlcl - kelvin
+}
+
+// dryAdiabat returns the temperature in °C of a parcel lifted dry adiabatically from (p0, t0) to p
+func dryAdiabat(p0, t0, p float64) float64 {
+	return (t0+kelvin)*math.Pow(p/p0, rd/cpd) - kelvin
+}
+
+// moistAdiabat returns the temperature in °C of a saturated parcel lifted pseudo-adiabatically from (p0, t0) to p
+func moistAdiabat(p0, t0, p float64) float64 {
+	dTdp := func(p, t float64) float64 {
+		tk := t + kelvin
+		rs := saturationMixingRatio(p, t)
+		return (rd*tk + lv*rs) / (cpd + lv*lv*rs*epsilon/(rd*tk*tk)) / p
+	}
+
+	t := t0
+	for p0 > p {
+		step := math.Min(moistStepHPa, p0-p)
+		// Second order Runge-Kutta keeps the error well below what the profile resolution warrants
+		k1 := dTdp(p0, t)
+		k2 := dTdp(p0-step, t-step*k1)
+		t -= step * (k1 + k2) / 2
+		p0 -= step
+	}
+	return t
+}
+
+// parcel returns the temperature in °C at pressure p of a parcel lifted from (p0, t0, td0):
+// dry adiabatically up to its LCL and moist adiabatically above it
+func parcel(p0, t0, td0, p float64) float64 {
+	plcl, tlcl := lcl(p0, t0, td0)
+	if p >= plcl {
+		return dryAdiabat(p0, t0, p)
+	}
+	return moistAdiabat(plcl, tlcl, p)
+}
@@ -0,0 +1,115 @@
+package plumber
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+// The reference values of the LCL come from lifting the parcel dry adiabatically until its saturation
+// mixing ratio drops to that of its dew point, those of the moist adiabat from holding Bolton's (1980)
+// equivalent potential temperature, eq. 43, constant: both independent of the formulations under test. The
+// moist adiabat, holding the latent heat and specific heat constant, runs up to 0.8 K colder by 400 hPa.
+
+func TestLCL(t *testing.T) {
+	tests := []struct {
+		p, t, td   float64
+		plcl, tlcl float64
+	}{
+		{1000, 30, 20, 864.64, 17.67},
+		{1000, 20, 20, 1000, 20},
+		{950, 25, 5, 706.34, 0.82},
+		{850, 10, -10, 624.57, -13.84},
+	}
+	for _, tt := range tests {
+		plcl, tlcl := lcl(tt.p, tt.t, tt.td)
+		if math.Abs(plcl-tt.plcl) > 1 || math.Abs(tlcl-tt.tlcl) > 0.1 {
+			t.Errorf("lcl(%v, %v, %v) = %.2f hPa, %.2f °C, want %.2f hPa, %.2f °C", tt.p, tt.t, tt.td, plcl, tlcl, tt.plcl, tt.tlcl)
+		}
+	}
+}
+
+func TestMoistAdiabat(t *testing.T) {
+	tests := []struct {
+		p0, t0, p float64
+		want      float64
+	}{
+		{1000, 20, 850, 14.00},
+		{1000, 20, 700, 6.46},
+		{1000, 20, 500, -8.05},
+		{1000, 20, 400, -19.13},
+		{850, 10, 500, -14.18},
+		{900, 0, 400, -47.12},
+	}
+	for _, tt := range tests {
+		if got := moistAdiabat(tt.p0, tt.t0, tt.p); math.Abs(got-tt.want) > 1 {
+			t.Errorf("moistAdiabat(%v, %v, %v) = %.2f °C, want %.2f °C", tt.p0, tt.t0, tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestDryAdiabat(t *testing.T) {
+	// 30 °C at 1000 hPa is a potential temperature of 303.15 K, which is 16.26 °C at 850 hPa
+	if got := dryAdiabat(1000, 30, 850); math.Abs(got-16.26) > 0.01 {
+		t.Errorf("dryAdiabat(1000, 30, 850) = %.2f °C, want 16.26 °C", got)
+	}
+}
+
+func TestSoundingMissingValues(t *testing.T) {
+	// profile has two hours of a surface and levels at 850 and 700 hPa; mod takes values away from it
+	profile := func(mod func(h *HourlyData)) *HourlyData {
+		h := &HourlyData{
+			Time:                     []int64{0, 3600},
+			Temperature2M:            []float64{20, 21},
+			RelativeHumidity2M:       []int{50, 50},
+			SurfacePressure:          []float64{950, 950},
+			Temperature850hPa:        []float64{12, 13},
+			GeopotentialHeight850hPa: []float64{1500, 1500},
+			RelativeHumidity850hPa:   []int{60, 60},
+			Temperature700hPa:        []float64{2, 3},
+			GeopotentialHeight700hPa: []float64{3100, 3100},
+			RelativeHumidity700hPa:   []int{40, 40},
+		}
+		mod(h)
+		return h
+	}
+
+	tests := []struct {
+		name     string
+		mod      func(h *HourlyData)
+		levels   []float64
+		dewPoint float64 // of the surface
+		err      error
+	}{
+		{"complete", func(h *HourlyData) {}, []float64{850, 700}, DewPoint(21, 50), nil},
+		{"level without humidity", func(h *HourlyData) { h.RelativeHumidity850hPa = nil }, []float64{700}, DewPoint(21, 50), nil},
+		{"level with humidity ending early", func(h *HourlyData) { h.RelativeHumidity700hPa = h.RelativeHumidity700hPa[:1] }, []float64{850}, DewPoint(21, 50), nil},
+		{"surface dew point", func(h *HourlyData) { h.RelativeHumidity2M, h.DewPoint2M = nil, []float64{5, 6} }, []float64{850, 700}, 6, nil},
+		{"no surface temperature", func(h *HourlyData) { h.Temperature2M = nil }, nil, 0, ErrNoSurface},
+		{"surface temperature ending early", func(h *HourlyData) { h.Temperature2M = h.Temperature2M[:1] }, nil, 0, ErrNoSurface},
+		{"no surface humidity", func(h *HourlyData) { h.RelativeHumidity2M = nil }, nil, 0, ErrNoSurface},
+		{"no level with humidity", func(h *HourlyData) { h.RelativeHumidity850hPa, h.RelativeHumidity700hPa = nil, nil }, nil, 0, ErrNoProfile},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := profile(tt.mod).Sounding(1, 500)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			var levels []float64
+			for _, l := range s.Levels {
+				levels = append(levels, l.Pressure)
+			}
+			if !slices.Equal(levels, tt.levels) {
+				t.Errorf("levels = %v hPa, want %v hPa", levels, tt.levels)
+			}
+			if math.Abs(s.Surface.DewPoint-tt.dewPoint) > 1e-9 || s.Surface.RelativeHumidity <= 0 {
+				t.Errorf("surface dew point %v °C at %v %%, want %v °C", s.Surface.DewPoint, s.Surface.RelativeHumidity, tt.dewPoint)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/tinkershack/meteomunch/aviation"
+	"github.com/tinkershack/meteomunch/config"
+	e "github.com/tinkershack/meteomunch/errors"
+	"github.com/tinkershack/meteomunch/http/rest"
+	"github.com/tinkershack/meteomunch/logger"
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+const metarProviderName = "metar"
+
+// maxStationDistance is how far, in km, the nearest station may be from the requested coordinates
+const maxStationDistance = 50.0
+
+// METAR serves the latest METAR and TAF of the aviation station nearest to the requested coordinates:
+// the METAR as the current conditions and the TAF as the hourly forecast
+//
+// The provider's APIPath is requested once per report kind with {kind} set to "metar" and "taf",
+// e.g. "api/data/{kind}" on https://aviationweather.gov/, and must answer with raw report text.
+//
+// METAR is safe for concurrent use, every FetchData call builds its own requests.
+type METAR struct {
+	client   rest.HTTPClient
+	config   config.MeteoProvider
+	stations *aviation.Stations
+	logLevel string
+	logger   *slog.Logger
+}
+
+func newMETAR(cfg *config.Config) (*METAR, error) {
+	if cfg == nil {
+		return nil, errors.New("configuration cannot be nil")
+	}
+
+	var meteoConfig config.MeteoProvider
+	found := false
+
+	for _, provider := range cfg.MeteoProviders {
+		if provider.Name == metarProviderName {
+			meteoConfig = provider
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrProviderNotConfigured, metarProviderName)
+	}
+
+	if err := configOptions(meteoConfig).Validate(providerLimits[metarProviderName]); err != nil {
+		return nil, fmt.Errorf("%s config: %w", metarProviderName, err)
+	}
+
+	stations := aviation.DefaultStations()
+	if cfg.Munch.AviationStations != "" {
+		if err := stations.LoadFile(cfg.Munch.AviationStations); err != nil {
+			return nil, fmt.Errorf("couldn't load aviation stations: %w", err)
+		}
+	}
+
+	client := rest.NewClient().SetDefaults().SetBaseURL(meteoConfig.BaseURI)
+	if cfg.Munch.LogLevel == "debug" {
+		client.SetDebug()
+		client.EnableTrace()
+	}
+
+	provider := METAR{
+		client:   client,
+		config:   meteoConfig,
+		stations: stations,
+		logLevel: cfg.Munch.LogLevel,
+		logger:   logger.NewTag("providers:metar"),
+	}
+	return &provider, nil
+}
+
+// FetchData fetches the reports of the station nearest to coords and maps them onto plumber.BaseData
+//
+// The location of the data is that of the station. A station without a TAF only gets current conditions.
+// Reports can't be tuned, so Options in the Request are rejected.
+func (p *METAR) FetchData(ctx context.Context, coords *plumber.Coordinates, r Request) (*plumber.BaseData, error) {
+	if err := r.Options.Validate(providerLimits[metarProviderName]); err != nil {
+		return nil, err
+	}
+	icao, station, _, err := p.stations.Nearest(coords, maxStationDistance)
+	if err != nil {
+		return nil, err
+	}
+
+	reports, err := p.fetchReports(ctx, "metar", coords)
+	if err != nil {
+		return nil, err
+	}
+	if len(reports) == 0 {
+		return nil, fmt.Errorf("%w: %s", aviation.ErrNoReport, icao)
+	}
+	now := time.Now()
+	metar, err := aviation.ParseMETAR(reports[0], now)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &plumber.BaseData{
+		Latitude:             station.Coordinates.Latitude,
+		Longitude:            station.Coordinates.Longitude,
+		Timezone:             "GMT",
+		TimezoneAbbreviation: "GMT",
+		Elevation:            station.Elevation,
+		Current:              metar.CurrentData(),
+	}
+
+	reports, err = p.fetchReports(ctx, "taf", coords)
+	switch {
+	case err != nil && ctx.Err() != nil:
+		return nil, ctx.Err()
+	case err != nil:
+		p.logger.Error(e.FAIL, "err", err, "description", "Couldn't fetch TAF, serving the METAR alone", "station", icao)
+	case len(reports) == 0:
+		p.logger.Debug("Station has no TAF", "station", icao)
+	default:
+		taf, err := aviation.ParseTAF(reports[0], now)
+		if err != nil {
+			p.logger.Error(e.FAIL, "err", err, "description", "Couldn't parse TAF, serving the METAR alone", "station", icao)
+			break
+		}
+		data.Hourly = taf.Hourly()
+	}
+	return data, nil
+}
+
+// fetchReports requests the raw reports of the given kind for the station nearest to coords
+func (p *METAR) fetchReports(ctx context.Context, kind string, coords *plumber.Coordinates) ([]string, error) {
+	resp, err := p.client.NewRequest().
+		SetPathParams(map[string]string{"kind": kind}).
+		SetQueryParams(p.QueryParams(coords)).
+		Get(ctx, p.config.APIPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.logLevel == "debug" {
+		p.logger.Debug("Response", "kind", kind, "status:", resp.Status())
+		p.logger.Debug("Response", "kind", kind, "body:", string(resp.Body()))
+	}
+	return aviation.SplitReports(string(resp.Body())), nil
+}
+
+// QueryParams returns the query parameters requesting the raw reports of the station nearest to coords
+func (p *METAR) QueryParams(coords *plumber.Coordinates) map[string]string {
+	icao, _, _, _ := p.stations.Nearest(coords, maxStationDistance)
+	return map[string]string{
+		"ids":    icao,
+		"format": "raw",
+	}
+}
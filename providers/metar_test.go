@@ -0,0 +1,112 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tinkershack/meteomunch/aviation"
+	"github.com/tinkershack/meteomunch/config"
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+// metarServer serves the given raw reports by kind and records the stations they were asked for
+func metarServer(t *testing.T, reports map[string]string) (*METAR, *[]string) {
+	t.Helper()
+	var mu sync.Mutex
+	var ids []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		ids = append(ids, r.URL.Query().Get("ids"))
+		mu.Unlock()
+		if q := r.URL.Query().Get("format"); q != "raw" {
+			t.Errorf("format = %q, want raw", q)
+		}
+		report, ok := reports[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, report)
+	}))
+	t.Cleanup(srv.Close)
+
+	p, err := newMETAR(&config.Config{MeteoProviders: []config.MeteoProvider{
+		{Name: metarProviderName, BaseURI: srv.URL, APIPath: "api/data/{kind}"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p, &ids
+}
+
+func TestMETARFetchData(t *testing.T) {
+	now := time.Now().UTC()
+	issued := now.Truncate(time.Hour)
+	metar := fmt.Sprintf("METAR LSZH %s 24012KT 9999 FEW030 M02/M05 Q1023 NOSIG=", issued.Format("021504Z"))
+	taf := fmt.Sprintf("TAF LSZH %s %s/%s 24010KT 9999 FEW030\n  TEMPO %s/%s 4000 -SHRA BKN015=",
+		issued.Format("021504Z"), issued.Format("0215"), issued.Add(24*time.Hour).Format("0215"),
+		issued.Add(2*time.Hour).Format("0215"), issued.Add(4*time.Hour).Format("0215"))
+	zurich := plumber.NewCoordinates(47.45, 8.56)
+
+	tests := []struct {
+		name    string
+		reports map[string]string
+		coords  *plumber.Coordinates
+		hours   int
+		err     error
+	}{
+		{"METAR and TAF", map[string]string{"/api/data/metar": metar, "/api/data/taf": taf}, zurich, 24, nil},
+		{"METAR alone", map[string]string{"/api/data/metar": metar, "/api/data/taf": ""}, zurich, 0, nil},
+		{"TAF unavailable", map[string]string{"/api/data/metar": metar}, zurich, 0, nil},
+		{"no METAR", map[string]string{"/api/data/metar": "", "/api/data/taf": taf}, zurich, 0, aviation.ErrNoReport},
+		{"NIL METAR", map[string]string{"/api/data/metar": "METAR LSZH " + issued.Format("021504Z") + " NIL="}, zurich, 0, aviation.ErrNoReport},
+		{"no station nearby", map[string]string{"/api/data/metar": metar}, plumber.NewCoordinates(0, -30), 0, aviation.ErrUnknownStation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, ids := metarServer(t, tt.reports)
+			data, err := p.FetchData(context.Background(), tt.coords, Request{})
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("err = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, id := range *ids {
+				if id != "LSZH" {
+					t.Errorf("requested station %q, want LSZH", id)
+				}
+			}
+			// The data is located at the station, not the requested coordinates
+			if data.Latitude != 47.4647 || data.Longitude != 8.5492 || data.Elevation != 432 {
+				t.Errorf("location %v, %v, %v, want LSZH's", data.Latitude, data.Longitude, data.Elevation)
+			}
+			if c := data.Current; c.Time != issued.Unix() || c.Temperature2M != -2 || c.PressureMSL != 1023 || c.WindDirection10M != 240 {
+				t.Errorf("current = %+v", c)
+			}
+			if len(data.Hourly.Time) != tt.hours {
+				t.Errorf("got %d hours, want %d", len(data.Hourly.Time), tt.hours)
+			}
+		})
+	}
+}
+
+func TestMETARRejectsOptions(t *testing.T) {
+	p, ids := metarServer(t, nil)
+	_, err := p.FetchData(context.Background(), plumber.NewCoordinates(47.45, 8.56), Request{Options: Options{ForecastDays: 3}})
+	if !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("err = %v, want ErrInvalidOptions", err)
+	}
+	if len(*ids) != 0 {
+		t.Error("options were rejected after requesting upstream")
+	}
+}
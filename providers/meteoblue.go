@@ -1,9 +1,12 @@
 package providers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"strconv"
 
 	"github.com/tinkershack/meteomunch/config"
 	"github.com/tinkershack/meteomunch/http/rest"
@@ -13,11 +16,19 @@ import (
 
 const meteoBlueProviderName = "meteoblue"
 
+// meteoBlueLimits are the limits of meteoblue's packages API; the packages in APIPath pick the model
+var meteoBlueLimits = Limits{
+	MaxForecastDays: 14,
+	Timezone:        true,
+}
+
+// MeteoBlue is safe for concurrent use, every FetchData call builds its own request
 type MeteoBlue struct {
-	client      rest.HTTPClient
-	config      config.MeteoProvider
-	queryParams map[string]string
-	logLevel    string
+	client   rest.HTTPClient
+	config   config.MeteoProvider
+	options  Options // Configured defaults, which requests may override
+	logLevel string
+	logger   *slog.Logger
 }
 
 func newMeteoBlue(cfg *config.Config) (*MeteoBlue, error) {
@@ -38,7 +49,12 @@ func newMeteoBlue(cfg *config.Config) (*MeteoBlue, error) {
 	}
 
 	if !found {
-		return nil, errors.New("meteoblue provider configuration not found")
+		return nil, fmt.Errorf("%w: %s", ErrProviderNotConfigured, meteoBlueProviderName)
+	}
+
+	options := configOptions(meteoConfig)
+	if err := options.Validate(meteoBlueLimits); err != nil {
+		return nil, fmt.Errorf("%s config: %w", meteoBlueProviderName, err)
 	}
 
 	client := rest.NewClient().SetDefaults().SetBaseURL(meteoConfig.BaseURI)
@@ -50,69 +66,72 @@ func newMeteoBlue(cfg *config.Config) (*MeteoBlue, error) {
 	provider := MeteoBlue{
 		client:   client,
 		config:   meteoConfig,
+		options:  options,
 		logLevel: logLevel,
+		logger:   logger.NewTag("providers:meteoblue"),
 	}
-	// Setting the default location to 0,0
-	provider.SetQueryParams(plumber.NewCoordinates(0, 0))
-
-	// Creating the new request(which will be reused in all FetchData calls) and setting the default queryParams on the client
-	provider.client.NewRequest()
-	provider.client.SetQueryParams(provider.queryParams)
 	return &provider, nil
 }
 
-func (p *MeteoBlue) FetchData(coords *plumber.Coordinates) (*plumber.BaseData, error) {
-	resp, err := p.client.
-		SetQueryParams(map[string]string{
-			"lat": fmt.Sprintf("%f", coords.Latitude),
-			"lon": fmt.Sprintf("%f", coords.Longitude),
-		}).
-		Get(p.config.APIPath)
+// FetchData fetches API data from meteoblue for the given coordinates and maps it onto plumber.BaseData
+//
+// The Options of the Request override the configured ones; meteoblue packages can't be trimmed to its Fields.
+func (p *MeteoBlue) FetchData(ctx context.Context, coords *plumber.Coordinates, r Request) (*plumber.BaseData, error) {
+	o := p.options.Override(r.Options)
+	if err := o.Validate(meteoBlueLimits); err != nil {
+		return nil, err
+	}
+	params := p.queryParams(coords, o)
+	resp, err := p.client.NewRequest().
+		SetQueryParams(params).
+		Get(ctx, p.config.APIPath)
 	if err != nil {
 		return nil, err
 	}
 
-	logger := logger.NewTag("providers:open-meteo")
-
 	if p.logLevel == "debug" {
 		// Refraining from logger.Debug() as it doesn't pretty print the resty response stats and body
 		// And, this is just for debugging purposes so it's okay to use fmt.Println as it's not logged in production
-		logger.Debug("Response", "status:", resp.Status())
-		logger.Debug("Response", "body:", string(resp.Body()))
+		p.logger.Debug("Response", "status:", resp.Status())
+		p.logger.Debug("Response", "body:", string(resp.Body()))
 
 		traceInfo := resp.TraceInfo()
-		logger.Debug("Response", "trace", fmt.Sprintf("%+v", traceInfo))
+		p.logger.Debug("Response", "trace", fmt.Sprintf("%+v", traceInfo))
 	}
 
-	var data struct {
-		Latitude  float64 `json:"lat"`
-		Longitude float64 `json:"lon"`
-		// Add other fields as per the API response
-	}
+	data := new(meteoBlueResponse)
 
-	if err := json.Unmarshal(resp.Body(), &data); err != nil {
+	if err := json.Unmarshal(resp.Body(), data); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
-	// TO-DO: #12
-	// Map the JSON response to plumber.BaseData
-	baseData := &plumber.BaseData{
-		Latitude:  data.Latitude,
-		Longitude: data.Longitude,
-		// Map other fields
-	}
+	return data.toBaseData(params["tz"]), nil
+}
 
-	return baseData, nil
+// QueryParams returns the query parameters of a meteoblue request for the given coordinates
+//
+// The provider's APIPath is expected to request the packages decoded by meteoBlueResponse,
+// e.g. "packages/basic-1h_basic-day_clouds-1h_wind-1h_trend-day".
+func (p *MeteoBlue) QueryParams(coords *plumber.Coordinates) map[string]string {
+	return p.queryParams(coords, p.options)
 }
 
-// Set the QueryParams for the request
-func (p *MeteoBlue) SetQueryParams(coords *plumber.Coordinates) {
-	// Setting the queryParams on the provider
-	p.queryParams = map[string]string{
+// queryParams returns the query parameters for the coordinates with the given options
+func (p *MeteoBlue) queryParams(coords *plumber.Coordinates, o Options) map[string]string {
+	params := map[string]string{
+		"lat":           fmt.Sprintf("%f", coords.Latitude),
+		"lon":           fmt.Sprintf("%f", coords.Longitude),
 		"tz":            "GMT",
 		"format":        "json",
+		"timeformat":    "timestamp_utc",
 		"forecast_days": "1",
 		"apikey":        p.config.APIKey,
 	}
-
+	if o.ForecastDays != 0 {
+		params["forecast_days"] = strconv.Itoa(o.ForecastDays)
+	}
+	if o.Timezone != "" {
+		params["tz"] = o.Timezone
+	}
+	return params
 }
@@ -0,0 +1,313 @@
+package providers
+
+import (
+	"math"
+	"time"
+
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+// meteoBlueResponse is the JSON response of the meteoblue packages API for the
+// basic-1h_basic-day_clouds-1h_wind-1h_trend-day package combination, requested with timeformat=timestamp_utc.
+//
+// Packages sharing a resolution are merged by the API into a single data block, so every hourly
+// variable lands in data_1h and every daily one in data_day.
+type meteoBlueResponse struct {
+	Metadata struct {
+		Latitude             float64 `json:"latitude"`
+		Longitude            float64 `json:"longitude"`
+		Height               float64 `json:"height"`
+		TimezoneAbbreviation string  `json:"timezone_abbrevation"` // sic, that's how meteoblue spells it
+		UTCTimeOffset        float64 `json:"utc_timeoffset"`       // in hours
+	} `json:"metadata"`
+	Units  meteoBlueUnits `json:"units"`
+	Data1H struct {
+		Time                     []int64   `json:"time"`
+		Temperature              []float64 `json:"temperature"`
+		FeltTemperature          []float64 `json:"felttemperature"`
+		RelativeHumidity         []float64 `json:"relativehumidity"`
+		SeaLevelPressure         []float64 `json:"sealevelpressure"`
+		SurfaceAirPressure       []float64 `json:"surfaceairpressure"`
+		Precipitation            []float64 `json:"precipitation"`
+		PrecipitationProbability []float64 `json:"precipitation_probability"`
+		ConvectivePrecipitation  []float64 `json:"convective_precipitation"`
+		SnowFraction             []float64 `json:"snowfraction"`
+		Pictocode                []float64 `json:"pictocode"`
+		IsDaylight               []float64 `json:"isdaylight"`
+		UVIndex                  []float64 `json:"uvindex"`
+		WindSpeed                []float64 `json:"windspeed"`
+		WindDirection            []float64 `json:"winddirection"`
+		WindSpeed80M             []float64 `json:"windspeed_80m"`
+		WindDirection80M         []float64 `json:"winddirection_80m"`
+		Gust                     []float64 `json:"gust"`
+		TotalCloudCover          []float64 `json:"totalcloudcover"`
+		LowClouds                []float64 `json:"lowclouds"`
+		MidClouds                []float64 `json:"midclouds"`
+		HighClouds               []float64 `json:"highclouds"`
+		SunshineTime             []float64 `json:"sunshinetime"` // in minutes
+		Visibility               []float64 `json:"visibility"`
+	} `json:"data_1h"`
+	DataDay struct {
+		Time                     []int64   `json:"time"`
+		Pictocode                []float64 `json:"pictocode"`
+		TemperatureMax           []float64 `json:"temperature_max"`
+		TemperatureMin           []float64 `json:"temperature_min"`
+		FeltTemperatureMax       []float64 `json:"felttemperature_max"`
+		FeltTemperatureMin       []float64 `json:"felttemperature_min"`
+		Precipitation            []float64 `json:"precipitation"`
+		PrecipitationHours       []float64 `json:"precipitation_hours"`
+		PrecipitationProbability []float64 `json:"precipitation_probability"`
+		UVIndex                  []float64 `json:"uvindex"`
+		WindSpeedMax             []float64 `json:"windspeed_max"`
+		WindDirection            []float64 `json:"winddirection"`
+	} `json:"data_day"`
+}
+
+// meteoBlueUnits lists the units meteoblue reports its values in
+type meteoBlueUnits struct {
+	Temperature   string `json:"temperature"`   // "C" or "F"
+	WindSpeed     string `json:"windspeed"`     // "ms-1", "kmh", "mph", "kn" or "bft"
+	Precipitation string `json:"precipitation"` // "mm" or "inch"
+}
+
+// toBaseData maps the meteoblue response onto plumber.BaseData, converting values into plumber.CommonUnits
+//
+// meteoblue only reports the abbreviation of the time zone, so the zone database name is the tz the response
+// was requested with, left empty if it isn't one.
+func (r *meteoBlueResponse) toBaseData(tz string) *plumber.BaseData {
+	u := r.Units
+	h := r.Data1H
+	d := r.DataDay
+
+	hourly := plumber.HourlyData{
+		Time:                     h.Time,
+		Temperature2M:            mapFloats(h.Temperature, u.celsius),
+		RelativeHumidity2M:       roundInts(h.RelativeHumidity),
+		ApparentTemperature:      mapFloats(h.FeltTemperature, u.celsius),
+		PrecipitationProbability: roundInts(h.PrecipitationProbability),
+		Precipitation:            mapFloats(h.Precipitation, u.millimetres),
+		WeatherCode:              mapInts(h.Pictocode, meteoBlueHourlyWMOCode),
+		PressureMSL:              h.SeaLevelPressure,
+		SurfacePressure:          h.SurfaceAirPressure,
+		CloudCover:               roundInts(h.TotalCloudCover),
+		CloudCoverLow:            roundInts(h.LowClouds),
+		CloudCoverMid:            roundInts(h.MidClouds),
+		CloudCoverHigh:           roundInts(h.HighClouds),
+		Visibility:               h.Visibility,
+		WindSpeed10M:             mapFloats(h.WindSpeed, u.kmh),
+		WindSpeed80M:             mapFloats(h.WindSpeed80M, u.kmh),
+		WindDirection10M:         roundInts(h.WindDirection),
+		WindDirection80M:         roundInts(h.WindDirection80M),
+		WindGusts10M:             mapFloats(h.Gust, u.kmh),
+		UVIndex:                  h.UVIndex,
+		IsDay:                    roundInts(h.IsDaylight),
+		SunshineDuration:         mapFloats(h.SunshineTime, func(v float64) float64 { return v * 60 }),
+	}
+
+	// meteoblue doesn't report the dew point in these packages, derive it so that it can be compared with other providers
+	if len(hourly.Temperature2M) == len(hourly.RelativeHumidity2M) && len(hourly.Temperature2M) > 0 {
+		hourly.DewPoint2M = make([]float64, len(hourly.Temperature2M))
+		for i := range hourly.Temperature2M {
+			hourly.DewPoint2M[i] = plumber.DewPoint(hourly.Temperature2M[i], float64(hourly.RelativeHumidity2M[i]))
+		}
+	}
+
+	daily := plumber.DailyData{
+		Time:                        d.Time,
+		WeatherCode:                 mapInts(d.Pictocode, meteoBlueDailyWMOCode),
+		Temperature2MMax:            mapFloats(d.TemperatureMax, u.celsius),
+		Temperature2MMin:            mapFloats(d.TemperatureMin, u.celsius),
+		ApparentTemperatureMax:      mapFloats(d.FeltTemperatureMax, u.celsius),
+		ApparentTemperatureMin:      mapFloats(d.FeltTemperatureMin, u.celsius),
+		UVIndexMax:                  d.UVIndex,
+		PrecipitationSum:            mapFloats(d.Precipitation, u.millimetres),
+		PrecipitationHours:          d.PrecipitationHours,
+		PrecipitationProbabilityMax: roundInts(d.PrecipitationProbability),
+		WindSpeed10MMax:             mapFloats(d.WindSpeedMax, u.kmh),
+		WindDirection10MDominant:    roundInts(d.WindDirection),
+	}
+
+	offset := int(r.Metadata.UTCTimeOffset * 3600)
+	if _, err := time.LoadLocation(tz); err != nil {
+		tz = "" // e.g. auto
+	}
+	return &plumber.BaseData{
+		Latitude:             r.Metadata.Latitude,
+		Longitude:            r.Metadata.Longitude,
+		UTCOffsetSeconds:     offset,
+		Timezone:             tz,
+		TimezoneAbbreviation: r.Metadata.TimezoneAbbreviation,
+		Elevation:            r.Metadata.Height,
+		Current:              r.current(&hourly, time.Now().Unix()),
+		Hourly:               hourly,
+		Daily:                daily,
+	}
+}
+
+// current picks the hourly sample that covers now, falling back to the first one, as the packages don't carry current conditions
+func (r *meteoBlueResponse) current(hourly *plumber.HourlyData, now int64) plumber.CurrentData {
+	if len(hourly.Time) == 0 {
+		return plumber.CurrentData{}
+	}
+
+	i := 0
+	for j, t := range hourly.Time {
+		if t > now {
+			break
+		}
+		i = j
+	}
+
+	precipitation := plumber.At(hourly.Precipitation, i)
+	snowFraction := plumber.At(r.Data1H.SnowFraction, i)
+	showers := r.Units.millimetres(plumber.At(r.Data1H.ConvectivePrecipitation, i))
+	return plumber.CurrentData{
+		Time:                hourly.Time[i],
+		Interval:            3600,
+		Temperature2M:       plumber.At(hourly.Temperature2M, i),
+		RelativeHumidity2M:  plumber.At(hourly.RelativeHumidity2M, i),
+		ApparentTemperature: plumber.At(hourly.ApparentTemperature, i),
+		IsDay:               plumber.At(hourly.IsDay, i),
+		Precipitation:       precipitation,
+		Rain:                math.Max(precipitation*(1-snowFraction)-showers, 0), // Like open-meteo, rain excludes convective showers
+		Showers:             showers,
+		Snowfall:            precipitation * snowFraction * 0.7, // 1 mm of water equivalent makes 0.7 cm of fresh snow
+		WeatherCode:         plumber.At(hourly.WeatherCode, i),
+		CloudCover:          plumber.At(hourly.CloudCover, i),
+		PressureMSL:         plumber.At(hourly.PressureMSL, i),
+		SurfacePressure:     plumber.At(hourly.SurfacePressure, i),
+		WindSpeed10M:        plumber.At(hourly.WindSpeed10M, i),
+		WindDirection10M:    plumber.At(hourly.WindDirection10M, i),
+		WindGusts10M:        plumber.At(hourly.WindGusts10M, i),
+	}
+}
+
+// celsius converts a meteoblue temperature into °C
+func (u meteoBlueUnits) celsius(v float64) float64 {
+	if u.Temperature == "F" {
+		return (v - 32) * 5 / 9
+	}
+	return v
+}
+
+// kmh converts a meteoblue wind speed into km/h
+func (u meteoBlueUnits) kmh(v float64) float64 {
+	switch u.WindSpeed {
+	case "kmh":
+		return v
+	case "mph":
+		return v * 1.609344
+	case "kn":
+		return v * 1.852
+	case "bft":
+		// Beaufort to m/s: v = 0.836 * B^(3/2)
+		return 0.836 * math.Pow(v, 1.5) * 3.6
+	default: // "ms-1", meteoblue's default
+		return v * 3.6
+	}
+}
+
+// millimetres converts a meteoblue precipitation amount into mm
+func (u meteoBlueUnits) millimetres(v float64) float64 {
+	if u.Precipitation == "inch" {
+		return v * 25.4
+	}
+	return v
+}
+
+// meteoBlueHourlyWMOCode maps meteoblue's hourly pictocodes (1-35) onto the WMO weather codes used by plumber
+func meteoBlueHourlyWMOCode(pictocode int) int {
+	switch pictocode {
+	case 1, 13:
+		return 0 // Clear sky
+	case 2, 3, 4, 5, 6, 14, 15:
+		return 1 // Mainly clear
+	case 7, 8, 9, 10, 11, 12:
+		return 2 // Partly cloudy
+	case 19, 20, 21, 22:
+		return 3 // Overcast
+	case 16, 17, 18:
+		return 45 // Fog
+	case 33:
+		return 61 // Slight rain
+	case 23, 35:
+		return 63 // Moderate rain
+	case 25:
+		return 65 // Heavy rain
+	case 34:
+		return 71 // Slight snow fall
+	case 24:
+		return 73 // Moderate snow fall
+	case 26, 29:
+		return 75 // Heavy snow fall
+	case 31:
+		return 80 // Slight rain showers
+	case 32:
+		return 85 // Slight snow showers
+	case 27, 28, 30:
+		return 95 // Thunderstorm
+	default:
+		return 0
+	}
+}
+
+// meteoBlueDailyWMOCode maps meteoblue's daily pictocodes (1-17) onto the WMO weather codes used by plumber
+func meteoBlueDailyWMOCode(pictocode int) int {
+	switch pictocode {
+	case 1:
+		return 0 // Clear sky
+	case 2:
+		return 1 // Mainly clear
+	case 3:
+		return 2 // Partly cloudy
+	case 4:
+		return 3 // Overcast
+	case 5:
+		return 45 // Fog
+	case 12, 14, 16:
+		return 61 // Slight rain
+	case 6, 11:
+		return 63 // Moderate rain
+	case 13, 15, 17:
+		return 71 // Slight snow fall
+	case 9:
+		return 73 // Moderate snow fall
+	case 7:
+		return 80 // Slight rain showers
+	case 10:
+		return 85 // Slight snow showers
+	case 8:
+		return 95 // Thunderstorm
+	default:
+		return 0
+	}
+}
+
+// mapFloats applies fn to every value, returning nil for missing variables
+func mapFloats(s []float64, fn func(float64) float64) []float64 {
+	if s == nil {
+		return nil
+	}
+	out := make([]float64, len(s))
+	for i, v := range s {
+		out[i] = fn(v)
+	}
+	return out
+}
+
+// mapInts rounds every value and applies fn to it, returning nil for missing variables
+func mapInts(s []float64, fn func(int) int) []int {
+	if s == nil {
+		return nil
+	}
+	out := make([]int, len(s))
+	for i, v := range s {
+		out[i] = fn(int(math.Round(v)))
+	}
+	return out
+}
+
+// roundInts rounds every value to the nearest int, returning nil for missing variables
+func roundInts(s []float64) []int {
+	return mapInts(s, func(v int) int { return v })
+}
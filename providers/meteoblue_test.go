@@ -0,0 +1,35 @@
+package providers
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMeteoBlueTimezone(t *testing.T) {
+	body := `{"metadata":{"latitude":47.56,"longitude":7.57,"height":279,"timezone_abbrevation":"CEST","utc_timeoffset":2.0},
+		"units":{"temperature":"C","windspeed":"kmh","precipitation":"mm"},
+		"data_1h":{"time":[1720000800],"temperature":[21.5]}}`
+	var r meteoBlueResponse
+	if err := json.Unmarshal([]byte(body), &r); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		tz   string
+		want string
+	}{
+		{"Europe/Zurich", "Europe/Zurich"},
+		{"GMT", "GMT"},
+		{"auto", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		bd := r.toBaseData(tt.tz)
+		if bd.Timezone != tt.want {
+			t.Errorf("tz %q: timezone = %q, want %q", tt.tz, bd.Timezone, tt.want)
+		}
+		if bd.TimezoneAbbreviation != "CEST" || bd.UTCOffsetSeconds != 7200 {
+			t.Errorf("tz %q: abbreviation %q, offset %d, want CEST, 7200", tt.tz, bd.TimezoneAbbreviation, bd.UTCOffsetSeconds)
+		}
+	}
+}
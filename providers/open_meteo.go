@@ -1,9 +1,13 @@
 package providers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
 
 	"github.com/tinkershack/meteomunch/config"
 	"github.com/tinkershack/meteomunch/http/rest"
@@ -13,11 +17,33 @@ import (
 
 const openMeteoProviderName = "open-meteo"
 
+// openMeteoLimits are the limits of open-meteo's forecast API
+var openMeteoLimits = Limits{
+	MaxForecastDays:  16,
+	MaxForecastHours: 16 * 24,
+	MaxPastDays:      92,
+	Model:            true,
+	CellSelection:    true,
+	Timezone:         true,
+}
+
+// Variables requested from open-meteo per block, unless config.MeteoProvider lists its own
+var (
+	openMeteoCurrent = strings.Split("temperature_2m,relative_humidity_2m,apparent_temperature,is_day,precipitation,rain,showers,snowfall,weather_code,cloud_cover,pressure_msl,surface_pressure,wind_speed_10m,wind_direction_10m,wind_gusts_10m", ",")
+	openMeteoHourly  = strings.Split("temperature_2m,relative_humidity_2m,dew_point_2m,apparent_temperature,precipitation_probability,precipitation,weather_code,pressure_msl,surface_pressure,cloud_cover,cloud_cover_low,cloud_cover_mid,cloud_cover_high,visibility,evapotranspiration,et0_fao_evapotranspiration,vapour_pressure_deficit,wind_speed_10m,wind_speed_80m,wind_speed_120m,wind_speed_180m,wind_direction_10m,wind_direction_80m,wind_direction_120m,wind_direction_180m,wind_gusts_10m,temperature_80m,temperature_120m,temperature_180m,uv_index,uv_index_clear_sky,is_day,sunshine_duration,total_column_integrated_water_vapour,cape,lifted_index,convective_inhibition,freezing_level_height,boundary_layer_height,temperature_1000hPa,temperature_975hPa,temperature_950hPa,temperature_925hPa,temperature_900hPa,temperature_850hPa,temperature_800hPa,temperature_700hPa,temperature_600hPa,temperature_500hPa,temperature_400hPa,relative_humidity_1000hPa,relative_humidity_975hPa,relative_humidity_950hPa,relative_humidity_925hPa,relative_humidity_900hPa,relative_humidity_850hPa,relative_humidity_800hPa,relative_humidity_700hPa,relative_humidity_600hPa,relative_humidity_500hPa,relative_humidity_400hPa,cloud_cover_1000hPa,cloud_cover_975hPa,cloud_cover_950hPa,cloud_cover_925hPa,cloud_cover_900hPa,cloud_cover_850hPa,cloud_cover_800hPa,cloud_cover_700hPa,cloud_cover_600hPa,cloud_cover_500hPa,cloud_cover_400hPa,wind_speed_1000hPa,wind_speed_975hPa,wind_speed_950hPa,wind_speed_925hPa,wind_speed_900hPa,wind_speed_850hPa,wind_speed_800hPa,wind_speed_700hPa,wind_speed_600hPa,wind_speed_500hPa,wind_speed_400hPa,wind_direction_1000hPa,wind_direction_975hPa,wind_direction_950hPa,wind_direction_925hPa,wind_direction_900hPa,wind_direction_850hPa,wind_direction_800hPa,wind_direction_700hPa,wind_direction_600hPa,wind_direction_500hPa,wind_direction_400hPa,geopotential_height_1000hPa,geopotential_height_975hPa,geopotential_height_950hPa,geopotential_height_925hPa,geopotential_height_900hPa,geopotential_height_850hPa,geopotential_height_800hPa,geopotential_height_700hPa,geopotential_height_600hPa,geopotential_height_500hPa,geopotential_height_400hPa", ",")
+	openMeteoDaily   = strings.Split("weather_code,temperature_2m_max,temperature_2m_min,apparent_temperature_max,apparent_temperature_min,sunrise,sunset,daylight_duration,sunshine_duration,uv_index_max,uv_index_clear_sky_max,precipitation_sum,precipitation_hours,precipitation_probability_max,wind_speed_10m_max,wind_gusts_10m_max,wind_direction_10m_dominant,shortwave_radiation_sum,et0_fao_evapotranspiration", ",")
+)
+
+// OpenMeteo is safe for concurrent use, every FetchData call builds its own request
 type OpenMeteo struct {
-	client      rest.HTTPClient
-	config      config.MeteoProvider
-	queryParams map[string]string
-	logLevel    string
+	client   rest.HTTPClient
+	config   config.MeteoProvider
+	options  Options // Configured defaults, which requests may override
+	current  string  // Comma separated variables of the current block
+	hourly   string
+	daily    string
+	logLevel string
+	logger   *slog.Logger
 }
 
 // newOpenMeteo returns a new instance of OpenMeteoProvider
@@ -39,7 +65,24 @@ func newOpenMeteo(cfg *config.Config) (*OpenMeteo, error) {
 	}
 
 	if !found {
-		return nil, errors.New("open-meteo provider configuration not found")
+		return nil, fmt.Errorf("%w: %s", ErrProviderNotConfigured, openMeteoProviderName)
+	}
+
+	options := configOptions(meteoConfig)
+	if err := options.Validate(openMeteoLimits); err != nil {
+		return nil, fmt.Errorf("%s config: %w", openMeteoProviderName, err)
+	}
+	current, err := variables(plumber.BlockCurrent, meteoConfig.Current, openMeteoCurrent)
+	if err != nil {
+		return nil, err
+	}
+	hourly, err := variables(plumber.BlockHourly, meteoConfig.Hourly, openMeteoHourly)
+	if err != nil {
+		return nil, err
+	}
+	daily, err := variables(plumber.BlockDaily, meteoConfig.Daily, openMeteoDaily)
+	if err != nil {
+		return nil, err
 	}
 
 	client := rest.NewClient().SetDefaults().SetBaseURL(meteoConfig.BaseURI)
@@ -51,38 +94,39 @@ func newOpenMeteo(cfg *config.Config) (*OpenMeteo, error) {
 	provider := OpenMeteo{
 		client:   client,
 		config:   meteoConfig,
+		options:  options,
+		current:  current,
+		hourly:   hourly,
+		daily:    daily,
 		logLevel: logLevel,
+		logger:   logger.NewTag("providers:open-meteo"),
 	}
-	// Setting the default location to 0,0
-	provider.SetQueryParams(plumber.NewCoordinates(0, 0))
-	// Creating the new request(which will be reused in all FetchData calls) and setting the default queryParams on the client
-	provider.client.NewRequest()
-	provider.client.SetQueryParams(provider.queryParams)
 	return &provider, nil
 }
 
 // FetchData fetches API data from open-meteo provider for the given query parameters map
-func (p *OpenMeteo) FetchData(coords *plumber.Coordinates) (*plumber.BaseData, error) {
-	resp, err := p.client.
-		SetQueryParams(map[string]string{
-			"latitude":  fmt.Sprintf("%f", coords.Latitude),
-			"longitude": fmt.Sprintf("%f", coords.Longitude),
-		}).
-		Get(p.config.APIPath)
+//
+// Only the variables selected in the Request are requested, and its Options override the configured ones.
+func (p *OpenMeteo) FetchData(ctx context.Context, coords *plumber.Coordinates, r Request) (*plumber.BaseData, error) {
+	o := p.options.Override(r.Options)
+	if err := o.Validate(openMeteoLimits); err != nil {
+		return nil, err
+	}
+	resp, err := p.client.NewRequest().
+		SetQueryParams(selectVariables(p.queryParams(coords, o), r.Fields)).
+		Get(ctx, p.config.APIPath)
 	if err != nil {
 		return nil, err
 	}
 
-	logger := logger.NewTag("providers:open-meteo")
-
 	if p.logLevel == "debug" {
 		// Refraining from logger.Debug() as it doesn't pretty print the resty response stats and body
 		// And, this is just for debugging purposes so it's okay to use fmt.Println as it's not logged in production
-		logger.Debug("Response", "status:", resp.Status())
-		logger.Debug("Response", "body:", string(resp.Body()))
+		p.logger.Debug("Response", "status:", resp.Status())
+		p.logger.Debug("Response", "body:", string(resp.Body()))
 
 		traceInfo := resp.TraceInfo()
-		logger.Debug("Response", "trace", fmt.Sprintf("%+v", traceInfo))
+		p.logger.Debug("Response", "trace", fmt.Sprintf("%+v", traceInfo))
 	}
 
 	data := new(plumber.BaseData) // Fields of the struct will be zero-initialized
@@ -94,12 +138,19 @@ func (p *OpenMeteo) FetchData(coords *plumber.Coordinates) (*plumber.BaseData, e
 	return data, nil
 }
 
-// SetQueryParams forms the query parameters for OpenMeteo API based on given coordinates
-func (p *OpenMeteo) SetQueryParams(coords *plumber.Coordinates) {
-	p.queryParams = map[string]string{
-		"current":        "temperature_2m,relative_humidity_2m,apparent_temperature,is_day,precipitation,rain,showers,snowfall,weather_code,cloud_cover,pressure_msl,surface_pressure,wind_speed_10m,wind_direction_10m,wind_gusts_10m",
-		"hourly":         "temperature_2m,relative_humidity_2m,dew_point_2m,apparent_temperature,precipitation_probability,precipitation,weather_code,pressure_msl,surface_pressure,cloud_cover,cloud_cover_low,cloud_cover_mid,cloud_cover_high,visibility,evapotranspiration,et0_fao_evapotranspiration,vapour_pressure_deficit,wind_speed_10m,wind_speed_80m,wind_speed_120m,wind_speed_180m,wind_direction_10m,wind_direction_80m,wind_direction_120m,wind_direction_180m,wind_gusts_10m,temperature_80m,temperature_120m,temperature_180m,uv_index,uv_index_clear_sky,is_day,sunshine_duration,total_column_integrated_water_vapour,cape,lifted_index,convective_inhibition,freezing_level_height,boundary_layer_height,temperature_1000hPa,temperature_975hPa,temperature_950hPa,temperature_925hPa,temperature_900hPa,temperature_850hPa,temperature_800hPa,temperature_700hPa,temperature_600hPa,temperature_500hPa,temperature_400hPa,relative_humidity_1000hPa,relative_humidity_975hPa,relative_humidity_950hPa,relative_humidity_925hPa,relative_humidity_900hPa,relative_humidity_850hPa,relative_humidity_800hPa,relative_humidity_700hPa,relative_humidity_600hPa,relative_humidity_500hPa,relative_humidity_400hPa,cloud_cover_1000hPa,cloud_cover_975hPa,cloud_cover_950hPa,cloud_cover_925hPa,cloud_cover_900hPa,cloud_cover_850hPa,cloud_cover_800hPa,cloud_cover_700hPa,cloud_cover_600hPa,cloud_cover_500hPa,cloud_cover_400hPa,wind_speed_1000hPa,wind_speed_975hPa,wind_speed_950hPa,wind_speed_925hPa,wind_speed_900hPa,wind_speed_850hPa,wind_speed_800hPa,wind_speed_700hPa,wind_speed_600hPa,wind_speed_500hPa,wind_speed_400hPa,wind_direction_1000hPa,wind_direction_975hPa,wind_direction_950hPa,wind_direction_925hPa,wind_direction_900hPa,wind_direction_850hPa,wind_direction_800hPa,wind_direction_700hPa,wind_direction_600hPa,wind_direction_500hPa,wind_direction_400hPa,geopotential_height_1000hPa,geopotential_height_975hPa,geopotential_height_950hPa,geopotential_height_925hPa,geopotential_height_900hPa,geopotential_height_850hPa,geopotential_height_800hPa,geopotential_height_700hPa,geopotential_height_600hPa,geopotential_height_500hPa,geopotential_height_400hPa",
-		"daily":          "weather_code,temperature_2m_max,temperature_2m_min,apparent_temperature_max,apparent_temperature_min,sunrise,sunset,daylight_duration,sunshine_duration,uv_index_max,uv_index_clear_sky_max,precipitation_sum,precipitation_hours,precipitation_probability_max,wind_speed_10m_max,wind_gusts_10m_max,wind_direction_10m_dominant,shortwave_radiation_sum,et0_fao_evapotranspiration",
+// QueryParams forms the query parameters for OpenMeteo API based on given coordinates
+func (p *OpenMeteo) QueryParams(coords *plumber.Coordinates) map[string]string {
+	return p.queryParams(coords, p.options)
+}
+
+// queryParams forms the query parameters for the coordinates with the given options
+func (p *OpenMeteo) queryParams(coords *plumber.Coordinates, o Options) map[string]string {
+	params := map[string]string{
+		"latitude":       fmt.Sprintf("%f", coords.Latitude),
+		"longitude":      fmt.Sprintf("%f", coords.Longitude),
+		"current":        p.current,
+		"hourly":         p.hourly,
+		"daily":          p.daily,
 		"timeformat":     "unixtime",
 		"timezone":       "GMT",
 		"forecast_days":  "1",
@@ -107,4 +158,70 @@ func (p *OpenMeteo) SetQueryParams(coords *plumber.Coordinates) {
 		"cell_selection": "nearest",
 		"models":         "best_match",
 	}
+	if o.ForecastDays != 0 || o.ForecastHours != 0 {
+		delete(params, "forecast_days")
+		delete(params, "forecast_hours")
+	}
+	if o.ForecastDays != 0 {
+		params["forecast_days"] = strconv.Itoa(o.ForecastDays)
+	}
+	if o.ForecastHours != 0 {
+		params["forecast_hours"] = strconv.Itoa(o.ForecastHours)
+	}
+	if o.PastDays != 0 {
+		params["past_days"] = strconv.Itoa(o.PastDays)
+	}
+	if o.Model != "" {
+		params["models"] = o.Model
+	}
+	if o.CellSelection != "" {
+		params["cell_selection"] = o.CellSelection
+	}
+	if o.Timezone != "" {
+		params["timezone"] = o.Timezone
+	}
+	return params
+}
+
+// variables returns the configured variables of a block, or the defaults if there are none, comma separated
+//
+// Configured variables must be plumber fields of the block, though they may be spelled as upstream spells them,
+// e.g. temperature_850hPa.
+func variables(block string, configured, defaults []string) (string, error) {
+	if len(configured) == 0 {
+		return strings.Join(defaults, ","), nil
+	}
+	for _, v := range configured {
+		if !plumber.IsField(block, strings.ToLower(v)) {
+			return "", fmt.Errorf("%w: %s.%s", plumber.ErrUnknownField, block, v)
+		}
+	}
+	return strings.Join(configured, ","), nil
+}
+
+// selectVariables trims the comma separated variable lists of the current, hourly and daily parameters
+// of an open-meteo style query to the selected fields, dropping the parameters of blocks left empty
+func selectVariables(params map[string]string, f plumber.Fields) map[string]string {
+	if f == nil {
+		return params
+	}
+	for _, block := range []string{plumber.BlockCurrent, plumber.BlockHourly, plumber.BlockDaily} {
+		variables, ok := params[block]
+		if !ok {
+			continue
+		}
+		var selected []string
+		for _, v := range strings.Split(variables, ",") {
+			// plumber's JSON names are lower case, e.g. temperature_850hpa for open-meteo's temperature_850hPa
+			if f.Selects(block, strings.ToLower(v)) {
+				selected = append(selected, v)
+			}
+		}
+		if len(selected) == 0 {
+			delete(params, block)
+			continue
+		}
+		params[block] = strings.Join(selected, ",")
+	}
+	return params
 }
@@ -0,0 +1,211 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/tinkershack/meteomunch/config"
+	"github.com/tinkershack/meteomunch/http/rest"
+	"github.com/tinkershack/meteomunch/logger"
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+const airQualityProviderName = "open-meteo-air-quality"
+
+// airQualityLimits are the limits of open-meteo's air quality API; its models are the CAMS domains,
+// cams_europe and cams_global
+var airQualityLimits = Limits{
+	MaxForecastDays:  7,
+	MaxForecastHours: 7 * 24,
+	MaxPastDays:      92,
+	Model:            true,
+	CellSelection:    true,
+	Timezone:         true,
+}
+
+// Air quality variables requested from open-meteo, unless config.MeteoProvider lists its own under Hourly
+var airQualityHourly = strings.Split("pm2_5,pm10,dust,aerosol_optical_depth,ozone,european_aqi,us_aqi", ",")
+
+// OpenMeteoAirQuality fetches the air quality forecast of the CAMS models from open-meteo
+//
+// FetchData returns the air quality in the AirQuality block of plumber.BaseData, along with the hourly time axis
+// it's aligned with; the weather blocks are left empty. OpenMeteoAirQuality is safe for concurrent use, every
+// call builds its own request.
+type OpenMeteoAirQuality struct {
+	client   rest.HTTPClient
+	config   config.MeteoProvider
+	options  Options // Configured defaults, which requests may override
+	hourly   string  // Comma separated variables
+	logLevel string
+	logger   *slog.Logger
+}
+
+func newOpenMeteoAirQuality(cfg *config.Config) (*OpenMeteoAirQuality, error) {
+	if cfg == nil {
+		return nil, errors.New("configuration cannot be nil")
+	}
+
+	var meteoConfig config.MeteoProvider
+	found := false
+
+	for _, provider := range cfg.MeteoProviders {
+		if provider.Name == airQualityProviderName {
+			meteoConfig = provider
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrProviderNotConfigured, airQualityProviderName)
+	}
+
+	options := configOptions(meteoConfig)
+	if err := options.Validate(airQualityLimits); err != nil {
+		return nil, fmt.Errorf("%s config: %w", airQualityProviderName, err)
+	}
+	if len(meteoConfig.Current) > 0 || len(meteoConfig.Daily) > 0 {
+		return nil, fmt.Errorf("%s config: only Hourly variables can be set", airQualityProviderName)
+	}
+	hourly, err := variables(plumber.BlockAirQuality, meteoConfig.Hourly, airQualityHourly)
+	if err != nil {
+		return nil, err
+	}
+
+	client := rest.NewClient().SetDefaults().SetBaseURL(meteoConfig.BaseURI)
+	if cfg.Munch.LogLevel == "debug" {
+		client.SetDebug()
+		client.EnableTrace()
+	}
+
+	provider := OpenMeteoAirQuality{
+		client:   client,
+		config:   meteoConfig,
+		options:  options,
+		hourly:   hourly,
+		logLevel: cfg.Munch.LogLevel,
+		logger:   logger.NewTag("providers:open-meteo-air-quality"),
+	}
+	return &provider, nil
+}
+
+// FetchData fetches the hourly air quality for coords
+//
+// If the Request selects fields, only the air quality variables among them are requested, and none at all
+// if it selects nothing of the air quality block: the data then only holds the coordinates. Its Options
+// override the configured ones.
+func (p *OpenMeteoAirQuality) FetchData(ctx context.Context, coords *plumber.Coordinates, r Request) (*plumber.BaseData, error) {
+	o := p.options.Override(r.Options)
+	if err := o.Validate(airQualityLimits); err != nil {
+		return nil, err
+	}
+	params := p.queryParams(coords, o)
+	if r.Fields != nil {
+		var selected []string
+		for _, v := range strings.Split(params["hourly"], ",") {
+			if r.Fields.Selects(plumber.BlockAirQuality, v) {
+				selected = append(selected, v)
+			}
+		}
+		if len(selected) == 0 {
+			return &plumber.BaseData{Latitude: coords.Latitude, Longitude: coords.Longitude}, nil
+		}
+		params["hourly"] = strings.Join(selected, ",")
+	}
+
+	resp, err := p.client.NewRequest().SetQueryParams(params).Get(ctx, p.config.APIPath)
+	if err != nil {
+		return nil, err
+	}
+	if p.logLevel == "debug" {
+		p.logger.Debug("Response", "status:", resp.Status())
+		p.logger.Debug("Response", "body:", string(resp.Body()))
+	}
+
+	var doc struct {
+		plumber.BaseData
+		Hourly plumber.AirQualityData `json:"hourly"`
+	}
+	if err := json.Unmarshal(resp.Body(), &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	data := doc.BaseData
+	data.Hourly.Time = doc.Hourly.Time
+	data.SetAirQuality(&doc.Hourly)
+	return &data, nil
+}
+
+// QueryParams returns the query parameters of an air quality request for the given coordinates
+func (p *OpenMeteoAirQuality) QueryParams(coords *plumber.Coordinates) map[string]string {
+	return p.queryParams(coords, p.options)
+}
+
+// queryParams returns the query parameters for the coordinates with the given options
+func (p *OpenMeteoAirQuality) queryParams(coords *plumber.Coordinates, o Options) map[string]string {
+	params := map[string]string{
+		"latitude":       fmt.Sprintf("%f", coords.Latitude),
+		"longitude":      fmt.Sprintf("%f", coords.Longitude),
+		"hourly":         p.hourly,
+		"timeformat":     "unixtime",
+		"timezone":       "GMT",
+		"forecast_days":  "5",
+		"cell_selection": "nearest",
+		"domains":        "auto",
+	}
+	if o.ForecastDays != 0 || o.ForecastHours != 0 {
+		delete(params, "forecast_days")
+	}
+	if o.ForecastDays != 0 {
+		params["forecast_days"] = strconv.Itoa(o.ForecastDays)
+	}
+	if o.ForecastHours != 0 {
+		params["forecast_hours"] = strconv.Itoa(o.ForecastHours)
+	}
+	if o.PastDays != 0 {
+		params["past_days"] = strconv.Itoa(o.PastDays)
+	}
+	if o.Model != "" {
+		params["domains"] = o.Model
+	}
+	if o.CellSelection != "" {
+		params["cell_selection"] = o.CellSelection
+	}
+	if o.Timezone != "" {
+		params["timezone"] = o.Timezone
+	}
+	return params
+}
+
+// AirQualityOptions returns the Options to fetch the air quality for a forecast fetched with o:
+// the same hours, as far as the air quality is forecast
+func AirQualityOptions(o Options) Options {
+	return Options{
+		ForecastDays:  min(o.ForecastDays, airQualityLimits.MaxForecastDays),
+		ForecastHours: min(o.ForecastHours, airQualityLimits.MaxForecastHours),
+		PastDays:      min(o.PastDays, airQualityLimits.MaxPastDays),
+		CellSelection: o.CellSelection,
+		Timezone:      o.Timezone,
+	}
+}
+
+// AirQualityRequest splits a forecast request into the Request for the forecast and the one for the air quality
+// of the same hours, and reports whether the forecast is to carry the air quality: if airQuality is set or r
+// selects from the air quality block
+//
+// A selection in r that leaves the air quality block out gets it added to the forecast's, so that projecting
+// the forecast onto its Fields keeps the block; r itself is left as is.
+func AirQualityRequest(r Request, airQuality bool) (forecast, air Request, ok bool) {
+	if _, selected := r.Fields[plumber.BlockAirQuality]; selected {
+		airQuality = true
+	} else if airQuality && r.Fields != nil {
+		r.Fields = maps.Clone(r.Fields)
+		r.Fields[plumber.BlockAirQuality] = []string{}
+	}
+	return r, Request{Options: AirQualityOptions(r.Options), Fields: r.Fields}, airQuality
+}
@@ -0,0 +1,121 @@
+package providers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/tinkershack/meteomunch/config"
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+// airQualityResponse is an air quality response of two hours
+const airQualityResponse = `{
+	"latitude": 46.7, "longitude": 7.85,
+	"hourly": {"time": [1720000800, 1720004400], "pm10": [12.5, 14], "dust": [3, 40], "european_aqi": [21, 30]}
+}`
+
+func TestAirQualityFetchData(t *testing.T) {
+	var mu sync.Mutex
+	var queries []url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.Query())
+		mu.Unlock()
+		io.WriteString(w, airQualityResponse)
+	}))
+	t.Cleanup(srv.Close)
+	p, err := newOpenMeteoAirQuality(&config.Config{MeteoProviders: []config.MeteoProvider{
+		{Name: airQualityProviderName, BaseURI: srv.URL, APIPath: "v1/air-quality"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	coords := plumber.NewCoordinates(46.68, 7.86)
+
+	tests := []struct {
+		name   string
+		r      Request
+		hourly string // Variables asked of upstream, empty if it isn't called
+	}{
+		{"everything", Request{}, "pm2_5,pm10,dust,aerosol_optical_depth,ozone,european_aqi,us_aqi"},
+		{"whole block", Request{Fields: plumber.Fields{"air_quality": {}, "hourly": {"cape"}}}, "pm2_5,pm10,dust,aerosol_optical_depth,ozone,european_aqi,us_aqi"},
+		{"named fields", Request{Fields: plumber.Fields{"air_quality": {"dust", "pm10"}}}, "pm10,dust"},
+		{"block left out", Request{Fields: plumber.Fields{"hourly": {"cape"}}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu.Lock()
+			queries = nil
+			mu.Unlock()
+			data, err := p.FetchData(context.Background(), coords, tt.r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.hourly == "" {
+				if queries != nil || data.AirQuality != nil || data.Latitude != 46.68 {
+					t.Errorf("fetched %v for %+v, want the coordinates alone without a call upstream", queries, data)
+				}
+				return
+			}
+			if len(queries) != 1 || queries[0].Get("hourly") != tt.hourly {
+				t.Fatalf("upstream queries = %v, want hourly %s", queries, tt.hourly)
+			}
+			aq := data.AirQuality
+			if aq == nil || !reflect.DeepEqual(data.Hourly.Time, aq.Time) || len(aq.Time) != 2 {
+				t.Fatalf("air quality %+v, hourly time %v, want two hours on the hourly time axis", aq, data.Hourly.Time)
+			}
+			if !reflect.DeepEqual(aq.Dust, []float64{3, 40}) || !reflect.DeepEqual(aq.EuropeanAQI, []int{21, 30}) || data.Latitude != 46.7 {
+				t.Errorf("dust %v, european aqi %v, latitude %v", aq.Dust, aq.EuropeanAQI, data.Latitude)
+			}
+		})
+	}
+
+	if _, err := p.FetchData(context.Background(), coords, Request{Options: Options{ForecastDays: 8}}); err == nil {
+		t.Error("fetched beyond the horizon of the air quality")
+	}
+}
+
+func TestAirQualityRequest(t *testing.T) {
+	opts := Options{Model: "icon_seamless", ForecastDays: 14, PastDays: 1, Timezone: "auto"}
+	// The air quality is forecast up to 7 days ahead, from the CAMS domains rather than the weather model
+	airOpts := Options{ForecastDays: 7, PastDays: 1, Timezone: "auto"}
+
+	tests := []struct {
+		name       string
+		fields     plumber.Fields
+		airQuality bool
+		forecast   plumber.Fields
+		ok         bool
+	}{
+		{"not asked for", nil, false, nil, false},
+		{"asked for", nil, true, nil, true},
+		{"asked for with a selection", plumber.Fields{"hourly": {"dust"}}, true, plumber.Fields{"hourly": {"dust"}, "air_quality": {}}, true},
+		{"selected", plumber.Fields{"air_quality": {"pm10"}}, false, plumber.Fields{"air_quality": {"pm10"}}, true},
+		{"neither", plumber.Fields{"hourly": {"cape"}}, false, plumber.Fields{"hourly": {"cape"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Request{Options: opts, Fields: tt.fields}
+			before := r.Fields.String()
+			forecast, air, ok := AirQualityRequest(r, tt.airQuality)
+			if ok != tt.ok {
+				t.Errorf("ok = %v, want %v", ok, tt.ok)
+			}
+			if !reflect.DeepEqual(forecast.Fields, tt.forecast) || forecast.Options != opts {
+				t.Errorf("forecast request = %+v, want fields %v", forecast, tt.forecast)
+			}
+			if !reflect.DeepEqual(air.Fields, forecast.Fields) || air.Options != airOpts {
+				t.Errorf("air quality request = %+v, want options %+v", air, airOpts)
+			}
+			if r.Fields.String() != before {
+				t.Errorf("selection of the request changed from %s to %s", before, r.Fields)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/tinkershack/meteomunch/config"
+	"github.com/tinkershack/meteomunch/http/rest"
+	"github.com/tinkershack/meteomunch/logger"
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+const ensembleProviderName = "open-meteo-ensemble"
+
+// defaultEnsembleModel is fetched unless the config or the request picks another ensemble
+const defaultEnsembleModel = "icon_seamless"
+
+// ensembleLimits are the limits of open-meteo's ensemble API
+var ensembleLimits = Limits{
+	MaxForecastDays:  35,
+	MaxForecastHours: 35 * 24,
+	MaxPastDays:      92,
+	Model:            true,
+	CellSelection:    true,
+	Timezone:         true,
+}
+
+// Hourly variables requested from the ensemble API, unless config.MeteoProvider lists its own
+var ensembleHourly = strings.Split("temperature_2m,relative_humidity_2m,dew_point_2m,apparent_temperature,precipitation,weather_code,pressure_msl,surface_pressure,cloud_cover,visibility,wind_speed_10m,wind_direction_10m,wind_gusts_10m,wind_speed_80m,wind_direction_80m,cape,freezing_level_height,sunshine_duration", ",")
+
+// memberColumn matches the hourly columns of the perturbed members, e.g. temperature_2m_member07
+var memberColumn = regexp.MustCompile(`^(.+)_member(\d+)$`)
+
+// OpenMeteoEnsemble fetches open-meteo's ensemble forecasts, e.g. the 40 members of ICON-EPS or the 31 of GEFS
+//
+// As a Provider it serves the control run; FetchEnsemble returns every member.
+// OpenMeteoEnsemble is safe for concurrent use, every call builds its own request.
+type OpenMeteoEnsemble struct {
+	client   rest.HTTPClient
+	config   config.MeteoProvider
+	options  Options // Configured defaults, which requests may override
+	hourly   string  // Comma separated variables of the hourly block
+	logLevel string
+	logger   *slog.Logger
+}
+
+func newOpenMeteoEnsemble(cfg *config.Config) (*OpenMeteoEnsemble, error) {
+	if cfg == nil {
+		return nil, errors.New("configuration cannot be nil")
+	}
+
+	var meteoConfig config.MeteoProvider
+	found := false
+
+	for _, provider := range cfg.MeteoProviders {
+		if provider.Name == ensembleProviderName {
+			meteoConfig = provider
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrProviderNotConfigured, ensembleProviderName)
+	}
+
+	options := configOptions(meteoConfig)
+	if err := options.Validate(ensembleLimits); err != nil {
+		return nil, fmt.Errorf("%s config: %w", ensembleProviderName, err)
+	}
+	if len(meteoConfig.Current) > 0 || len(meteoConfig.Daily) > 0 {
+		return nil, fmt.Errorf("%s config: only Hourly variables can be set", ensembleProviderName)
+	}
+	hourly, err := variables(plumber.BlockHourly, meteoConfig.Hourly, ensembleHourly)
+	if err != nil {
+		return nil, err
+	}
+
+	client := rest.NewClient().SetDefaults().SetBaseURL(meteoConfig.BaseURI)
+	if cfg.Munch.LogLevel == "debug" {
+		client.SetDebug()
+		client.EnableTrace()
+	}
+
+	provider := OpenMeteoEnsemble{
+		client:   client,
+		config:   meteoConfig,
+		options:  options,
+		hourly:   hourly,
+		logLevel: cfg.Munch.LogLevel,
+		logger:   logger.NewTag("providers:open-meteo-ensemble"),
+	}
+	return &provider, nil
+}
+
+// FetchData fetches the ensemble and returns its control run
+func (p *OpenMeteoEnsemble) FetchData(ctx context.Context, coords *plumber.Coordinates, r Request) (*plumber.BaseData, error) {
+	e, err := p.FetchEnsemble(ctx, coords, r)
+	if err != nil {
+		return nil, err
+	}
+	return &e.BaseData, nil
+}
+
+// FetchEnsemble fetches every member of the ensemble for coords
+//
+// Only the hourly variables selected in the Request are requested, and its Options override the configured ones.
+func (p *OpenMeteoEnsemble) FetchEnsemble(ctx context.Context, coords *plumber.Coordinates, r Request) (*plumber.Ensemble, error) {
+	o := p.options.Override(r.Options)
+	if err := o.Validate(ensembleLimits); err != nil {
+		return nil, err
+	}
+	if o.Model == "" {
+		o.Model = defaultEnsembleModel
+	}
+
+	resp, err := p.client.NewRequest().
+		SetQueryParams(selectVariables(p.queryParams(coords, o), r.Fields)).
+		Get(ctx, p.config.APIPath)
+	if err != nil {
+		return nil, err
+	}
+	if p.logLevel == "debug" {
+		p.logger.Debug("Response", "status:", resp.Status())
+		p.logger.Debug("Response", "body:", string(resp.Body()))
+	}
+
+	e, err := decodeEnsemble(resp.Body())
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	e.Model = o.Model
+	return e, nil
+}
+
+// QueryParams returns the query parameters of an ensemble request for the given coordinates
+func (p *OpenMeteoEnsemble) QueryParams(coords *plumber.Coordinates) map[string]string {
+	o := p.options
+	if o.Model == "" {
+		o.Model = defaultEnsembleModel
+	}
+	return p.queryParams(coords, o)
+}
+
+// queryParams returns the query parameters for the coordinates with the given options, which must name a model
+func (p *OpenMeteoEnsemble) queryParams(coords *plumber.Coordinates, o Options) map[string]string {
+	params := map[string]string{
+		"latitude":       fmt.Sprintf("%f", coords.Latitude),
+		"longitude":      fmt.Sprintf("%f", coords.Longitude),
+		"hourly":         p.hourly,
+		"timeformat":     "unixtime",
+		"timezone":       "GMT",
+		"forecast_days":  "3",
+		"cell_selection": "nearest",
+		"models":         o.Model,
+	}
+	if o.ForecastDays != 0 {
+		params["forecast_days"] = strconv.Itoa(o.ForecastDays)
+	}
+	if o.ForecastHours != 0 {
+		params["forecast_hours"] = strconv.Itoa(o.ForecastHours)
+	}
+	if o.PastDays != 0 {
+		params["past_days"] = strconv.Itoa(o.PastDays)
+	}
+	if o.CellSelection != "" {
+		params["cell_selection"] = o.CellSelection
+	}
+	if o.Timezone != "" {
+		params["timezone"] = o.Timezone
+	}
+	return params
+}
+
+// maxMembers bounds the member numbers of an ensemble response, above the 50 perturbed members of ECMWF's
+// ensemble, the largest open-meteo serves, so that a bad column can't allocate members without end
+const maxMembers = 100
+
+// decodeEnsemble splits the hourly columns of an ensemble response into the control run, the unsuffixed
+// columns, and the members, whose columns end in _member01, _member02 and so on
+//
+// The nulls of the members, hours they have no value for, are carried as NaN; see plumber.HourlyData.SetValues.
+func decodeEnsemble(body []byte) (*plumber.Ensemble, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, err
+	}
+	var columns map[string]json.RawMessage
+	if raw, ok := doc[plumber.BlockHourly]; ok {
+		if err := json.Unmarshal(raw, &columns); err != nil {
+			return nil, fmt.Errorf("%s: %w", plumber.BlockHourly, err)
+		}
+	}
+	var times []int64
+	if raw, ok := columns["time"]; ok {
+		if err := json.Unmarshal(raw, &times); err != nil {
+			return nil, fmt.Errorf("time: %w", err)
+		}
+	}
+
+	control := make(map[string]json.RawMessage)
+	var members []plumber.HourlyData
+	for column, values := range columns {
+		m := memberColumn.FindStringSubmatch(column)
+		if m == nil {
+			if column != "time" {
+				var err error
+				if values, err = trimNulls(values); err != nil {
+					return nil, fmt.Errorf("%s: %w", column, err)
+				}
+			}
+			control[column] = values
+			continue
+		}
+		n, err := strconv.Atoi(m[2])
+		if err != nil || n < 1 || n > maxMembers {
+			return nil, fmt.Errorf("%s: bad member number", column)
+		}
+		var series []*float64
+		if err := json.Unmarshal(values, &series); err != nil {
+			return nil, fmt.Errorf("%s: %w", column, err)
+		}
+		for len(members) < n {
+			members = append(members, plumber.HourlyData{Time: slices.Clone(times)})
+		}
+		members[n-1].SetValues(m[1], series)
+	}
+
+	e := &plumber.Ensemble{Members: members}
+	if e.Members == nil {
+		e.Members = []plumber.HourlyData{}
+	}
+	b, err := json.Marshal(control)
+	if err != nil {
+		return nil, err
+	}
+	doc[plumber.BlockHourly] = b
+	if b, err = json.Marshal(doc); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &e.BaseData); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// trimNulls drops the trailing nulls of a column, the hours beyond the horizon of the model,
+// so that they're missing rather than decoded as zeros
+func trimNulls(column json.RawMessage) (json.RawMessage, error) {
+	var values []*float64
+	if err := json.Unmarshal(column, &values); err != nil {
+		return nil, err
+	}
+	n := len(values)
+	for n > 0 && values[n-1] == nil {
+		n--
+	}
+	if n == len(values) {
+		return column, nil
+	}
+	return json.Marshal(values[:n])
+}
@@ -0,0 +1,67 @@
+package providers
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeEnsemble(t *testing.T) {
+	body := `{
+		"latitude": 46.68, "longitude": 7.86,
+		"hourly": {"time": [0, 3600, 7200],
+			"wind_speed_10m": [10, 12, null],
+			"cloud_cover": [50, 60, 70],
+			"wind_speed_10m_member01": [20, null, 24],
+			"cloud_cover_member01": [80, null, 90],
+			"wind_speed_10m_member03": [null, null, null]}
+	}`
+	ens, err := decodeEnsemble([]byte(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ens.Latitude != 46.68 || !reflect.DeepEqual(ens.Hourly.Time, []int64{0, 3600, 7200}) {
+		t.Errorf("latitude %v, time %v, want those of the response", ens.Latitude, ens.Hourly.Time)
+	}
+	// The control run ends where its nulls begin
+	if want := []float64{10, 12}; !reflect.DeepEqual(ens.Hourly.WindSpeed10M, want) {
+		t.Errorf("control wind speed = %v, want %v", ens.Hourly.WindSpeed10M, want)
+	}
+	if len(ens.Members) != 3 || ens.Size() != 4 {
+		t.Fatalf("%d members, want 3", len(ens.Members))
+	}
+	m := ens.Members[0]
+	if len(m.WindSpeed10M) != 3 || m.WindSpeed10M[0] != 20 || !math.IsNaN(m.WindSpeed10M[1]) || m.WindSpeed10M[2] != 24 {
+		t.Errorf("wind speed of member 1 = %v, want [20 NaN 24]", m.WindSpeed10M)
+	}
+	// Integer fields can't hold NaN and end at the first null
+	if want := []int{80}; !reflect.DeepEqual(m.CloudCover, want) {
+		t.Errorf("cloud cover of member 1 = %v, want %v", m.CloudCover, want)
+	}
+	if len(ens.Members[1].WindSpeed10M) != 0 || !reflect.DeepEqual(ens.Members[1].Time, ens.Hourly.Time) {
+		t.Errorf("member 2 = %+v, want only the time axis", ens.Members[1])
+	}
+	for _, v := range ens.Members[2].WindSpeed10M {
+		if !math.IsNaN(v) {
+			t.Errorf("wind speed of member 3 = %v, want NaN throughout", ens.Members[2].WindSpeed10M)
+			break
+		}
+	}
+
+	p50, err := ens.Percentile("wind_speed_10m", 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p50[1] == nil || *p50[1] != 12 || p50[2] == nil || *p50[2] != 24 {
+		t.Errorf("p50 = %v, want 12 and 24 at the hours with a null", p50)
+	}
+
+	for _, column := range []string{"wind_speed_10m_member00", "wind_speed_10m_member101", "wind_speed_10m_member9999999999"} {
+		if _, err := decodeEnsemble([]byte(`{"hourly": {"time": [0], "` + column + `": [1]}}`)); err == nil {
+			t.Errorf("decoded %s", column)
+		}
+	}
+	if _, err := decodeEnsemble([]byte(`{"hourly": {"time": [0], "wind_speed_10m_member01": ["calm"]}}`)); err == nil {
+		t.Error("decoded a member column that isn't numbers")
+	}
+}
@@ -0,0 +1,227 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/tinkershack/meteomunch/config"
+	"github.com/tinkershack/meteomunch/http/rest"
+	"github.com/tinkershack/meteomunch/logger"
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+const historicalProviderName = "open-meteo-historical"
+
+const (
+	// historicalDelay is how far behind today the reanalyses are published, ERA5 being the slowest at five days
+	historicalDelay = 5 * 24 * time.Hour
+	// historicalDays is the length of the range fetched when none is chosen, ending at the latest published day
+	historicalDays = 7
+	// historicalChunkDays is the longest range fetched in a single upstream call; longer ranges are paged
+	historicalChunkDays = 366
+	// historicalMaxDays is the longest range fetched at all, 30 years or some 263k hours of data
+	historicalMaxDays = 30 * 366
+)
+
+// historicalEarliest is the first day of ERA5, the longest reanalysis open-meteo serves
+var historicalEarliest = time.Date(1940, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// historicalLimits are the limits of open-meteo's historical weather API
+var historicalLimits = Limits{
+	Model:         true,
+	CellSelection: true,
+	Timezone:      true,
+	MaxRangeDays:  historicalMaxDays,
+}
+
+// Variables requested from the historical API per block, unless config.MeteoProvider lists its own;
+// the reanalyses don't cover the forecast-only variables such as visibility, CAPE or the pressure levels
+var (
+	historicalHourly = strings.Split("temperature_2m,relative_humidity_2m,dew_point_2m,apparent_temperature,precipitation,weather_code,pressure_msl,surface_pressure,cloud_cover,cloud_cover_low,cloud_cover_mid,cloud_cover_high,et0_fao_evapotranspiration,vapour_pressure_deficit,wind_speed_10m,wind_direction_10m,wind_gusts_10m,is_day,sunshine_duration,boundary_layer_height", ",")
+	historicalDaily  = strings.Split("weather_code,temperature_2m_max,temperature_2m_min,apparent_temperature_max,apparent_temperature_min,sunrise,sunset,daylight_duration,sunshine_duration,precipitation_sum,precipitation_hours,wind_speed_10m_max,wind_gusts_10m_max,wind_direction_10m_dominant,shortwave_radiation_sum,et0_fao_evapotranspiration", ",")
+)
+
+// OpenMeteoHistorical fetches past weather from open-meteo's historical API, reanalyses such as ERA5 going back to 1940
+//
+// The range is chosen with Options.StartDate and Options.EndDate; without one the last week that's been published
+// is fetched. Ranges longer than a year are fetched a year at a time and joined into a single timeline.
+// OpenMeteoHistorical is safe for concurrent use, every call builds its own requests.
+type OpenMeteoHistorical struct {
+	client   rest.HTTPClient
+	config   config.MeteoProvider
+	options  Options // Configured defaults, which requests may override
+	hourly   string  // Comma separated variables of the hourly block
+	daily    string
+	logLevel string
+	logger   *slog.Logger
+}
+
+func newOpenMeteoHistorical(cfg *config.Config) (*OpenMeteoHistorical, error) {
+	if cfg == nil {
+		return nil, errors.New("configuration cannot be nil")
+	}
+
+	var meteoConfig config.MeteoProvider
+	found := false
+
+	for _, provider := range cfg.MeteoProviders {
+		if provider.Name == historicalProviderName {
+			meteoConfig = provider
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrProviderNotConfigured, historicalProviderName)
+	}
+
+	options := configOptions(meteoConfig)
+	if err := options.Validate(historicalLimits); err != nil {
+		return nil, fmt.Errorf("%s config: %w", historicalProviderName, err)
+	}
+	if len(meteoConfig.Current) > 0 {
+		return nil, fmt.Errorf("%s config: there are no Current variables in the past", historicalProviderName)
+	}
+	hourly, err := variables(plumber.BlockHourly, meteoConfig.Hourly, historicalHourly)
+	if err != nil {
+		return nil, err
+	}
+	daily, err := variables(plumber.BlockDaily, meteoConfig.Daily, historicalDaily)
+	if err != nil {
+		return nil, err
+	}
+
+	client := rest.NewClient().SetDefaults().SetBaseURL(meteoConfig.BaseURI)
+	if cfg.Munch.LogLevel == "debug" {
+		client.SetDebug()
+		client.EnableTrace()
+	}
+
+	provider := OpenMeteoHistorical{
+		client:   client,
+		config:   meteoConfig,
+		options:  options,
+		hourly:   hourly,
+		daily:    daily,
+		logLevel: cfg.Munch.LogLevel,
+		logger:   logger.NewTag("providers:open-meteo-historical"),
+	}
+	return &provider, nil
+}
+
+// FetchData fetches the past weather at coords over the range of days chosen in the Options of the Request
+//
+// The range must lie between 1940 and the latest published day, five days ago. Only the variables selected
+// in the Request are requested.
+func (p *OpenMeteoHistorical) FetchData(ctx context.Context, coords *plumber.Coordinates, r Request) (*plumber.BaseData, error) {
+	o := p.options.Override(r.Options)
+	if err := o.Validate(historicalLimits); err != nil {
+		return nil, err
+	}
+	start, end, ok := o.DateRange()
+	if !ok {
+		end = historicalLatest(time.Now())
+		start = end.AddDate(0, 0, 1-historicalDays)
+	}
+	if start.Before(historicalEarliest) {
+		return nil, fmt.Errorf("%w: historical data starts on %s", ErrInvalidOptions, historicalEarliest.Format(dateLayout))
+	}
+	if latest := historicalLatest(time.Now()); end.After(latest) {
+		return nil, fmt.Errorf("%w: historical data ends on %s", ErrInvalidOptions, latest.Format(dateLayout))
+	}
+
+	var data *plumber.BaseData
+	for from := start; !from.After(end); from = from.AddDate(0, 0, historicalChunkDays) {
+		to := from.AddDate(0, 0, historicalChunkDays-1)
+		if to.After(end) {
+			to = end
+		}
+		chunk, err := p.fetchRange(ctx, coords, o, r.Fields, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("%s to %s: %w", from.Format(dateLayout), to.Format(dateLayout), err)
+		}
+		if data == nil {
+			data = chunk
+			continue
+		}
+		data.Hourly.Append(&chunk.Hourly)
+		data.Daily.Append(&chunk.Daily)
+	}
+	return data, nil
+}
+
+// fetchRange fetches the days from start to end in a single upstream call
+func (p *OpenMeteoHistorical) fetchRange(ctx context.Context, coords *plumber.Coordinates, o Options, f plumber.Fields, start, end time.Time) (*plumber.BaseData, error) {
+	params := p.queryParams(coords, o)
+	params["start_date"] = start.Format(dateLayout)
+	params["end_date"] = end.Format(dateLayout)
+
+	resp, err := p.client.NewRequest().
+		SetQueryParams(selectVariables(params, f)).
+		Get(ctx, p.config.APIPath)
+	if err != nil {
+		return nil, err
+	}
+	if p.logLevel == "debug" {
+		p.logger.Debug("Response", "status:", resp.Status())
+		p.logger.Debug("Response", "body:", string(resp.Body()))
+	}
+
+	data := new(plumber.BaseData)
+	if err := json.Unmarshal(resp.Body(), data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return data, nil
+}
+
+// QueryParams returns the query parameters of a request for the given coordinates, without the range of days
+func (p *OpenMeteoHistorical) QueryParams(coords *plumber.Coordinates) map[string]string {
+	return p.queryParams(coords, p.options)
+}
+
+// queryParams returns the query parameters for the coordinates with the given options, without the range of days
+func (p *OpenMeteoHistorical) queryParams(coords *plumber.Coordinates, o Options) map[string]string {
+	params := map[string]string{
+		"latitude":       fmt.Sprintf("%f", coords.Latitude),
+		"longitude":      fmt.Sprintf("%f", coords.Longitude),
+		"hourly":         p.hourly,
+		"daily":          p.daily,
+		"timeformat":     "unixtime",
+		"timezone":       "GMT",
+		"cell_selection": "nearest",
+		"models":         "best_match",
+	}
+	if o.Model != "" {
+		params["models"] = o.Model
+	}
+	if o.CellSelection != "" {
+		params["cell_selection"] = o.CellSelection
+	}
+	if o.Timezone != "" {
+		params["timezone"] = o.Timezone
+	}
+	return params
+}
+
+// historicalLatest returns the latest day published in the historical API as of now
+func historicalLatest(now time.Time) time.Time {
+	y, m, d := now.UTC().Add(-historicalDelay).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// PastYears returns Options choosing the last n calendar years that have been published in full by the historical API
+//
+// Being whole past years, the range only moves on once a year, so that its data stays cached in the meantime.
+func PastYears(n int) Options {
+	last := historicalLatest(time.Now()).AddDate(0, 0, 1).Year() - 1
+	return Options{
+		StartDate: time.Date(last-n+1, time.January, 1, 0, 0, 0, 0, time.UTC).Format(dateLayout),
+		EndDate:   time.Date(last, time.December, 31, 0, 0, 0, 0, time.UTC).Format(dateLayout),
+	}
+}
@@ -0,0 +1,183 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/tinkershack/meteomunch/config"
+	"github.com/tinkershack/meteomunch/http/rest"
+	"github.com/tinkershack/meteomunch/logger"
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+const marineProviderName = "open-meteo-marine"
+
+// marineLimits are the limits of open-meteo's marine API; its models are the wave models, e.g. ecmwf_wam025
+// or meteofrance_wave
+var marineLimits = Limits{
+	MaxForecastDays:  16,
+	MaxForecastHours: 16 * 24,
+	MaxPastDays:      92,
+	Model:            true,
+	CellSelection:    true,
+	Timezone:         true,
+}
+
+// Marine variables requested from open-meteo, unless config.MeteoProvider lists its own under Hourly
+var marineHourly = strings.Split("wave_height,wave_direction,wave_period,wind_wave_height,wind_wave_direction,wind_wave_period,swell_wave_height,swell_wave_direction,swell_wave_period,sea_surface_temperature", ",")
+
+// OpenMeteoMarine fetches the sea state forecast of the wave models from open-meteo
+//
+// FetchData returns the sea state in the Marine block of plumber.BaseData, along with its hourly time axis;
+// the weather blocks are left empty. The sea grid cell nearest to the coordinates is used unless the options
+// say otherwise, as the wave models have nothing over land. OpenMeteoMarine is safe for concurrent use, every
+// call builds its own request.
+type OpenMeteoMarine struct {
+	client   rest.HTTPClient
+	config   config.MeteoProvider
+	options  Options // Configured defaults, which requests may override
+	hourly   string  // Comma separated variables
+	logLevel string
+	logger   *slog.Logger
+}
+
+func newOpenMeteoMarine(cfg *config.Config) (*OpenMeteoMarine, error) {
+	if cfg == nil {
+		return nil, errors.New("configuration cannot be nil")
+	}
+
+	var meteoConfig config.MeteoProvider
+	found := false
+
+	for _, provider := range cfg.MeteoProviders {
+		if provider.Name == marineProviderName {
+			meteoConfig = provider
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrProviderNotConfigured, marineProviderName)
+	}
+
+	options := configOptions(meteoConfig)
+	if err := options.Validate(marineLimits); err != nil {
+		return nil, fmt.Errorf("%s config: %w", marineProviderName, err)
+	}
+	if len(meteoConfig.Current) > 0 || len(meteoConfig.Daily) > 0 {
+		return nil, fmt.Errorf("%s config: only Hourly variables can be set", marineProviderName)
+	}
+	hourly, err := variables(plumber.BlockMarine, meteoConfig.Hourly, marineHourly)
+	if err != nil {
+		return nil, err
+	}
+
+	client := rest.NewClient().SetDefaults().SetBaseURL(meteoConfig.BaseURI)
+	if cfg.Munch.LogLevel == "debug" {
+		client.SetDebug()
+		client.EnableTrace()
+	}
+
+	provider := OpenMeteoMarine{
+		client:   client,
+		config:   meteoConfig,
+		options:  options,
+		hourly:   hourly,
+		logLevel: cfg.Munch.LogLevel,
+		logger:   logger.NewTag("providers:open-meteo-marine"),
+	}
+	return &provider, nil
+}
+
+// FetchData fetches the hourly sea state for coords
+//
+// If the Request selects fields, only the marine variables among them are requested, and none at all
+// if it selects nothing of the marine block: the data then only holds the coordinates. Its Options
+// override the configured ones.
+func (p *OpenMeteoMarine) FetchData(ctx context.Context, coords *plumber.Coordinates, r Request) (*plumber.BaseData, error) {
+	o := p.options.Override(r.Options)
+	if err := o.Validate(marineLimits); err != nil {
+		return nil, err
+	}
+	params := p.queryParams(coords, o)
+	if r.Fields != nil {
+		var selected []string
+		for _, v := range strings.Split(params["hourly"], ",") {
+			if r.Fields.Selects(plumber.BlockMarine, v) {
+				selected = append(selected, v)
+			}
+		}
+		if len(selected) == 0 {
+			return &plumber.BaseData{Latitude: coords.Latitude, Longitude: coords.Longitude}, nil
+		}
+		params["hourly"] = strings.Join(selected, ",")
+	}
+
+	resp, err := p.client.NewRequest().SetQueryParams(params).Get(ctx, p.config.APIPath)
+	if err != nil {
+		return nil, err
+	}
+	if p.logLevel == "debug" {
+		p.logger.Debug("Response", "status:", resp.Status())
+		p.logger.Debug("Response", "body:", string(resp.Body()))
+	}
+
+	var doc struct {
+		plumber.BaseData
+		Hourly plumber.MarineData `json:"hourly"`
+	}
+	if err := json.Unmarshal(resp.Body(), &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	data := doc.BaseData
+	data.Hourly.Time = doc.Hourly.Time
+	data.Marine = &doc.Hourly
+	return &data, nil
+}
+
+// QueryParams returns the query parameters of a marine request for the given coordinates
+func (p *OpenMeteoMarine) QueryParams(coords *plumber.Coordinates) map[string]string {
+	return p.queryParams(coords, p.options)
+}
+
+// queryParams returns the query parameters for the coordinates with the given options
+func (p *OpenMeteoMarine) queryParams(coords *plumber.Coordinates, o Options) map[string]string {
+	params := map[string]string{
+		"latitude":       fmt.Sprintf("%f", coords.Latitude),
+		"longitude":      fmt.Sprintf("%f", coords.Longitude),
+		"hourly":         p.hourly,
+		"timeformat":     "unixtime",
+		"timezone":       "GMT",
+		"forecast_days":  "7",
+		"cell_selection": "sea",
+		"models":         "best_match",
+	}
+	if o.ForecastDays != 0 || o.ForecastHours != 0 {
+		delete(params, "forecast_days")
+	}
+	if o.ForecastDays != 0 {
+		params["forecast_days"] = strconv.Itoa(o.ForecastDays)
+	}
+	if o.ForecastHours != 0 {
+		params["forecast_hours"] = strconv.Itoa(o.ForecastHours)
+	}
+	if o.PastDays != 0 {
+		params["past_days"] = strconv.Itoa(o.PastDays)
+	}
+	if o.Model != "" {
+		params["models"] = o.Model
+	}
+	if o.CellSelection != "" {
+		params["cell_selection"] = o.CellSelection
+	}
+	if o.Timezone != "" {
+		params["timezone"] = o.Timezone
+	}
+	return params
+}
@@ -0,0 +1,102 @@
+package providers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/tinkershack/meteomunch/config"
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+// marineResponse is a marine response of three hours
+const marineResponse = `{
+	"latitude": 43.5, "longitude": 7.04, "timezone": "GMT",
+	"hourly": {"time": [1720000800, 1720004400, 1720008000],
+		"wave_height": [0.4, 0.5, 0.7], "wave_direction": [200, 210, 215], "sea_surface_temperature": [24.1, 24.2, 24.4]}
+}`
+
+func TestMarineFetchData(t *testing.T) {
+	var mu sync.Mutex
+	var queries []url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.Query())
+		mu.Unlock()
+		io.WriteString(w, marineResponse)
+	}))
+	t.Cleanup(srv.Close)
+	p, err := newOpenMeteoMarine(&config.Config{MeteoProviders: []config.MeteoProvider{
+		{Name: marineProviderName, BaseURI: srv.URL, APIPath: "v1/marine"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	coords := plumber.NewCoordinates(43.52, 7.03)
+
+	tests := []struct {
+		name  string
+		r     Request
+		query map[string]string // Expected upstream parameters, nil if upstream isn't called
+	}{
+		{"configured", Request{}, map[string]string{
+			"hourly":         "wave_height,wave_direction,wave_period,wind_wave_height,wind_wave_direction,wind_wave_period,swell_wave_height,swell_wave_direction,swell_wave_period,sea_surface_temperature",
+			"cell_selection": "sea", "models": "best_match", "forecast_days": "7",
+		}},
+		{"options", Request{Options: Options{ForecastHours: 48, Model: "ecmwf_wam025", CellSelection: "nearest"}}, map[string]string{
+			"cell_selection": "nearest", "models": "ecmwf_wam025", "forecast_days": "", "forecast_hours": "48",
+		}},
+		{"named fields", Request{Fields: plumber.Fields{"marine": {"sea_surface_temperature", "wave_height"}}}, map[string]string{
+			"hourly": "wave_height,sea_surface_temperature",
+		}},
+		{"block left out", Request{Fields: plumber.Fields{"hourly": {"temperature_2m"}}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu.Lock()
+			queries = nil
+			mu.Unlock()
+			data, err := p.FetchData(context.Background(), coords, tt.r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.query == nil {
+				if queries != nil || data.Marine != nil || data.Latitude != 43.52 {
+					t.Errorf("fetched %v for %+v, want the coordinates alone without a call upstream", queries, data)
+				}
+				return
+			}
+			if len(queries) != 1 {
+				t.Fatalf("%d upstream calls, want 1", len(queries))
+			}
+			for k, v := range tt.query {
+				if got := queries[0].Get(k); got != v {
+					t.Errorf("%s = %q, want %q", k, got, v)
+				}
+			}
+
+			// The sea state comes with its own hourly time axis, and nothing else of the weather
+			m := data.Marine
+			if m == nil || !reflect.DeepEqual(data.Hourly.Time, m.Time) || len(m.Time) != 3 || data.Hourly.Temperature2M != nil {
+				t.Fatalf("marine %+v, hourly %+v, want three hours on the hourly time axis", m, data.Hourly)
+			}
+			if !reflect.DeepEqual(m.WaveHeight, []float64{0.4, 0.5, 0.7}) || !reflect.DeepEqual(m.WaveDirection, []int{200, 210, 215}) ||
+				m.SeaSurfaceTemperature[2] != 24.4 || data.Latitude != 43.5 {
+				t.Errorf("wave height %v, direction %v, sea surface temperature %v, latitude %v",
+					m.WaveHeight, m.WaveDirection, m.SeaSurfaceTemperature, data.Latitude)
+			}
+			if i := m.Index(1720004400); i != 1 || data.Hourly.Index(1720004400) != i {
+				t.Errorf("marine index %d, hourly index %d of the second hour, want 1", i, data.Hourly.Index(1720004400))
+			}
+		})
+	}
+
+	if _, err := p.FetchData(context.Background(), coords, Request{Options: Options{ForecastDays: 17}}); err == nil {
+		t.Error("fetched beyond the horizon of the wave models")
+	}
+}
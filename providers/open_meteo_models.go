@@ -0,0 +1,151 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+// FetchModels fetches several models in one call, for which open-meteo suffixes every variable with the model,
+// e.g. temperature_2m_icon_seamless, and decodes the columns of each model into its own plumber.BaseData
+func (p *OpenMeteo) FetchModels(ctx context.Context, coords *plumber.Coordinates, models []string, r Request) (map[string]*plumber.BaseData, error) {
+	if len(models) == 0 {
+		return nil, fmt.Errorf("%w: no models", ErrInvalidOptions)
+	}
+	o := p.options.Override(r.Options)
+	for i, m := range models {
+		if slices.Contains(models[:i], m) {
+			return nil, fmt.Errorf("%w: model %s is listed twice", ErrInvalidOptions, m)
+		}
+		o.Model = m
+		if err := o.Validate(openMeteoLimits); err != nil {
+			return nil, err
+		}
+	}
+
+	params := selectVariables(p.queryParams(coords, o), r.Fields)
+	params["models"] = strings.Join(models, ",")
+	resp, err := p.client.NewRequest().
+		SetQueryParams(params).
+		Get(ctx, p.config.APIPath)
+	if err != nil {
+		return nil, err
+	}
+	if p.logLevel == "debug" {
+		p.logger.Debug("Response", "status:", resp.Status())
+		p.logger.Debug("Response", "body:", string(resp.Body()))
+	}
+
+	data, err := decodeModels(resp.Body(), models)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return data, nil
+}
+
+// decodeModels splits an open-meteo response for several models into one plumber.BaseData per model
+//
+// A column belongs to the model with the longest name it ends in, so that temperature_2m_ncep_gfs_seamless
+// goes to ncep_gfs_seamless rather than gfs_seamless. Unsuffixed columns, such as the time axis, are shared.
+// Open-meteo pads the columns of a model with nulls where it has no data, see modelValues.
+func decodeModels(body []byte, models []string) (map[string]*plumber.BaseData, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, err
+	}
+
+	byLength := slices.Clone(models)
+	slices.SortFunc(byLength, func(a, b string) int { return len(b) - len(a) })
+	owner := func(column string) (model, variable string) {
+		for _, m := range byLength {
+			if v, ok := strings.CutSuffix(column, "_"+m); ok {
+				return m, v
+			}
+		}
+		return "", column
+	}
+
+	perModel := make(map[string]map[string]json.RawMessage, len(models))
+	for _, m := range models {
+		perModel[m] = make(map[string]json.RawMessage, len(doc))
+		for k, v := range doc {
+			perModel[m][k] = v
+		}
+	}
+	for _, block := range []string{plumber.BlockCurrent, plumber.BlockHourly, plumber.BlockDaily} {
+		raw, ok := doc[block]
+		if !ok {
+			continue
+		}
+		var columns map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &columns); err != nil {
+			return nil, fmt.Errorf("%s: %w", block, err)
+		}
+		split := make(map[string]map[string]json.RawMessage, len(models))
+		for _, m := range models {
+			split[m] = make(map[string]json.RawMessage)
+		}
+		for column, values := range columns {
+			m, variable := owner(column)
+			if m != "" {
+				values, err := modelValues(block, values)
+				if err != nil {
+					return nil, fmt.Errorf("%s.%s: %w", block, column, err)
+				}
+				if values != nil {
+					split[m][variable] = values
+				}
+				continue
+			}
+			for _, cols := range split {
+				cols[column] = values
+			}
+		}
+		for _, m := range models {
+			b, err := json.Marshal(split[m])
+			if err != nil {
+				return nil, err
+			}
+			perModel[m][block] = b
+		}
+	}
+
+	out := make(map[string]*plumber.BaseData, len(models))
+	for _, m := range models {
+		b, err := json.Marshal(perModel[m])
+		if err != nil {
+			return nil, err
+		}
+		bd := new(plumber.BaseData)
+		if err := json.Unmarshal(b, bd); err != nil {
+			return nil, fmt.Errorf("%s: %w", m, err)
+		}
+		out[m] = bd
+	}
+	return out, nil
+}
+
+// modelValues drops the trailing nulls of a model's column, the hours beyond its horizon, so that they're missing
+// rather than decoded as zeros; it returns nil for a column or current value that's null throughout, a variable
+// the model doesn't have
+func modelValues(block string, values json.RawMessage) (json.RawMessage, error) {
+	if block == plumber.BlockCurrent {
+		if string(bytes.TrimSpace(values)) == "null" {
+			return nil, nil
+		}
+		return values, nil
+	}
+	values, err := trimNulls(values)
+	if err != nil {
+		return nil, err
+	}
+	if string(values) == "[]" {
+		return nil, nil
+	}
+	return values, nil
+}
@@ -0,0 +1,68 @@
+package providers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeModels(t *testing.T) {
+	body := `{
+		"latitude": 46.68, "longitude": 7.86,
+		"current": {"time": 1720000800, "interval": 900,
+			"temperature_2m_gfs_seamless": 19, "temperature_2m_ncep_gfs_seamless": 20, "temperature_2m_icon_seamless": null},
+		"hourly": {"time": [1720000800, 1720004400, 1720008000],
+			"temperature_2m_gfs_seamless": [19, 21, 23],
+			"temperature_2m_ncep_gfs_seamless": [20, 22, null],
+			"temperature_2m_icon_seamless": [18, null, null],
+			"cape_gfs_seamless": [100, 200, 300],
+			"cape_icon_seamless": [null, null, null]},
+		"daily": {"time": [1719964800], "sunrise_icon_seamless": [1719978000]}
+	}`
+	models := []string{"gfs_seamless", "ncep_gfs_seamless", "icon_seamless"}
+
+	data, err := decodeModels([]byte(body), models)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != len(models) {
+		t.Fatalf("decoded %d models, want %d", len(data), len(models))
+	}
+	hours := []int64{1720000800, 1720004400, 1720008000}
+
+	gfs, ncep, icon := data["gfs_seamless"], data["ncep_gfs_seamless"], data["icon_seamless"]
+	for m, bd := range data {
+		if bd.Latitude != 46.68 || !reflect.DeepEqual(bd.Hourly.Time, hours) || bd.Current.Time != 1720000800 {
+			t.Errorf("%s: latitude %v, hours %v, current time %v, want the shared columns", m, bd.Latitude, bd.Hourly.Time, bd.Current.Time)
+		}
+	}
+	if gfs.Current.Temperature2M != 19 || ncep.Current.Temperature2M != 20 {
+		t.Errorf("current temperature of gfs %v, ncep %v, want 19 and 20", gfs.Current.Temperature2M, ncep.Current.Temperature2M)
+	}
+	if want := []float64{19, 21, 23}; !reflect.DeepEqual(gfs.Hourly.Temperature2M, want) {
+		t.Errorf("gfs temperature = %v, want %v", gfs.Hourly.Temperature2M, want)
+	}
+	if want := []float64{100, 200, 300}; !reflect.DeepEqual(gfs.Hourly.Cape, want) {
+		t.Errorf("gfs cape = %v, want %v", gfs.Hourly.Cape, want)
+	}
+	// ncep_gfs_seamless ends in gfs_seamless too, but its columns are its own
+	if want := []float64{20, 22}; !reflect.DeepEqual(ncep.Hourly.Temperature2M, want) {
+		t.Errorf("ncep temperature = %v, want %v", ncep.Hourly.Temperature2M, want)
+	}
+	if ncep.Hourly.Cape != nil || ncep.Daily.Sunrise != nil {
+		t.Errorf("ncep cape %v, sunrise %v, want none", ncep.Hourly.Cape, ncep.Daily.Sunrise)
+	}
+	// Nulls are missing values rather than zeros
+	if want := []float64{18}; !reflect.DeepEqual(icon.Hourly.Temperature2M, want) {
+		t.Errorf("icon temperature = %v, want %v", icon.Hourly.Temperature2M, want)
+	}
+	if icon.Hourly.Cape != nil {
+		t.Errorf("icon cape = %v, want none for a column of nulls", icon.Hourly.Cape)
+	}
+	if want := []int64{1719978000}; !reflect.DeepEqual(icon.Daily.Sunrise, want) {
+		t.Errorf("icon sunrise = %v, want %v", icon.Daily.Sunrise, want)
+	}
+
+	if _, err := decodeModels([]byte(`{"hourly": {"cape_gfs_seamless": ["high"]}}`), models); err == nil {
+		t.Error("decoded a column that isn't numbers")
+	}
+}
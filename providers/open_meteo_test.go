@@ -0,0 +1,41 @@
+package providers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+func TestSelectVariables(t *testing.T) {
+	params := func() map[string]string {
+		return map[string]string{
+			"latitude": "46.68",
+			"current":  "temperature_2m,wind_speed_10m",
+			"hourly":   "temperature_2m,cape,temperature_850hPa",
+			"daily":    "sunrise,sunset",
+		}
+	}
+
+	tests := []struct {
+		name   string
+		fields plumber.Fields
+		want   map[string]string
+	}{
+		{"no selection", nil, params()},
+		{"named fields", plumber.Fields{"hourly": {"cape", "temperature_850hpa"}}, map[string]string{
+			"latitude": "46.68", "hourly": "cape,temperature_850hPa",
+		}},
+		{"bare block", plumber.Fields{"daily": {}, "current": {"wind_speed_10m"}}, map[string]string{
+			"latitude": "46.68", "current": "wind_speed_10m", "daily": "sunrise,sunset",
+		}},
+		{"nothing of the query", plumber.Fields{"marine": {}}, map[string]string{"latitude": "46.68"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := selectVariables(params(), tt.fields); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("selectVariables() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,279 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tinkershack/meteomunch/config"
+)
+
+// ErrInvalidOptions is returned by Options.Validate for options the provider's upstream API doesn't accept
+var ErrInvalidOptions = errors.New("invalid fetch options")
+
+// Options tune what a provider fetches beyond the coordinates; zero values leave the choice to the provider's config
+//
+// Each config.MeteoProvider sets the defaults of its provider, and a Request may override them.
+type Options struct {
+	Model         string // Weather model, e.g. icon_seamless
+	ForecastDays  int    // Days of forecast, starting today
+	ForecastHours int    // Hours of hourly forecast, starting at the current hour; limits ForecastDays for the hourly block
+	PastDays      int    // Days before today to include
+	CellSelection string // How the grid cell is picked for the coordinates: land, sea or nearest
+	Timezone      string // Time zone database name the days are aligned to, or auto for the location's own
+	// Range of days to fetch instead of a horizon, both inclusive and in the form 2006-01-02, e.g. of historical data
+	StartDate string
+	EndDate   string
+}
+
+// dateLayout is the layout of Options.StartDate and Options.EndDate
+const dateLayout = "2006-01-02"
+
+// Limits are what a provider's upstream API accepts in Options
+//
+// A zero maximum or a false flag means the option can't be set at all.
+type Limits struct {
+	MaxForecastDays  int
+	MaxForecastHours int
+	MaxPastDays      int
+	Model            bool
+	CellSelection    bool
+	Timezone         bool
+	MaxRangeDays     int // Longest range of StartDate to EndDate, both included
+}
+
+// providerLimits lists the Limits of every provider New knows
+var providerLimits = map[string]Limits{
+	openMeteoProviderName:  openMeteoLimits,
+	meteoBlueProviderName:  meteoBlueLimits,
+	metarProviderName:      {},
+	ensembleProviderName:   ensembleLimits,
+	historicalProviderName: historicalLimits,
+	airQualityProviderName: airQualityLimits,
+	marineProviderName:     marineLimits,
+}
+
+// LimitsOf returns the Limits of the named provider
+func LimitsOf(name string) (Limits, error) {
+	l, ok := providerLimits[name]
+	if !ok {
+		return Limits{}, fmt.Errorf("%w: %s", ErrUnknownProvider, name)
+	}
+	return l, nil
+}
+
+// ConfiguredModel returns the model the named provider fetches unless a request picks another: the Model of its entry
+// in cfg.MeteoProviders, or the one its upstream API defaults to. It's empty for providers whose model can't be chosen.
+func ConfiguredModel(name string, cfg *config.Config) string {
+	for _, mp := range cfg.MeteoProviders {
+		if mp.Name == name && mp.Model != "" {
+			return mp.Model
+		}
+	}
+	switch name {
+	case openMeteoProviderName, historicalProviderName, marineProviderName:
+		return "best_match"
+	case ensembleProviderName:
+		return defaultEnsembleModel
+	case airQualityProviderName:
+		return "auto"
+	}
+	return ""
+}
+
+// Forecasts reports whether the named provider forecasts the weather, as opposed to serving past days, the air
+// quality or sea state, or the observations of the nearest airport
+func Forecasts(name string) bool {
+	switch name {
+	case historicalProviderName, airQualityProviderName, marineProviderName, metarProviderName:
+		return false
+	}
+	return true
+}
+
+// Gridded reports whether the named provider serves every point of a model grid cell the same data, so that
+// its fetches can be shared per cell; METARs come from the airport nearest the coordinates, and the marine
+// models pick the sea cell nearest to them, which a cell centre on land would move
+func Gridded(name string) bool {
+	switch name {
+	case metarProviderName, marineProviderName:
+		return false
+	}
+	return true
+}
+
+// Settled reports whether what the named provider fetches with the options never changes, such as a range
+// of past days, so that it can be cached for good
+func Settled(name string, o Options) bool {
+	if name != historicalProviderName {
+		return false
+	}
+	_, end, ok := o.DateRange()
+	return ok && !end.After(historicalLatest(time.Now()))
+}
+
+// SettledYears splits the options of a Settled fetch by the named provider into one per calendar year its range
+// touches, so that a long range can be cached a year at a time, each well within the size limit of a cache entry,
+// and shared with the ranges that overlap it; nil if the fetch isn't settled or lies within a single year
+func SettledYears(name string, o Options) []Options {
+	if !Settled(name, o) {
+		return nil
+	}
+	start, end, _ := o.DateRange()
+	if start.Year() == end.Year() {
+		return nil
+	}
+	var years []Options
+	for from := start; !from.After(end); from = time.Date(from.Year()+1, time.January, 1, 0, 0, 0, 0, time.UTC) {
+		to := time.Date(from.Year(), time.December, 31, 0, 0, 0, 0, time.UTC)
+		if to.After(end) {
+			to = end
+		}
+		year := o
+		year.StartDate, year.EndDate = from.Format(dateLayout), to.Format(dateLayout)
+		years = append(years, year)
+	}
+	return years
+}
+
+// modelName matches the model names of the upstream APIs, e.g. ecmwf_ifs025
+var modelName = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Validate checks the options against the limits of the provider, so that a request the upstream API
+// would reject fails before it's made
+func (o Options) Validate(l Limits) error {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s", ErrInvalidOptions, fmt.Sprintf(format, args...))
+	}
+	switch {
+	case o.ForecastDays < 0 || o.ForecastDays > l.MaxForecastDays:
+		if l.MaxForecastDays == 0 {
+			return invalid("the forecast days can't be chosen")
+		}
+		return invalid("forecast days must be between 1 and %d", l.MaxForecastDays)
+	case o.ForecastHours < 0 || o.ForecastHours > l.MaxForecastHours:
+		if l.MaxForecastHours == 0 {
+			return invalid("the forecast hours can't be chosen")
+		}
+		return invalid("forecast hours must be between 1 and %d", l.MaxForecastHours)
+	case o.PastDays < 0 || o.PastDays > l.MaxPastDays:
+		if l.MaxPastDays == 0 {
+			return invalid("past days can't be included")
+		}
+		return invalid("past days must be between 0 and %d", l.MaxPastDays)
+	case o.Model != "" && !l.Model:
+		return invalid("the model can't be chosen")
+	case o.Model != "" && !modelName.MatchString(o.Model):
+		return invalid("model %q isn't a model name", o.Model)
+	case o.CellSelection != "" && !l.CellSelection:
+		return invalid("the cell selection can't be chosen")
+	case o.CellSelection != "" && o.CellSelection != "land" && o.CellSelection != "sea" && o.CellSelection != "nearest":
+		return invalid("cell selection must be land, sea or nearest")
+	case o.Timezone != "" && !l.Timezone:
+		return invalid("the time zone can't be chosen")
+	case (o.StartDate != "" || o.EndDate != "") && l.MaxRangeDays == 0:
+		return invalid("the date range can't be chosen")
+	case (o.StartDate == "") != (o.EndDate == ""):
+		return invalid("start and end dates must be set together")
+	case o.StartDate != "" && (o.ForecastDays != 0 || o.ForecastHours != 0 || o.PastDays != 0):
+		return invalid("a date range can't be combined with forecast days, hours or past days")
+	}
+	if o.StartDate != "" {
+		start, err := time.Parse(dateLayout, o.StartDate)
+		if err != nil {
+			return invalid("start date %q isn't a date like 2006-01-02", o.StartDate)
+		}
+		end, err := time.Parse(dateLayout, o.EndDate)
+		if err != nil {
+			return invalid("end date %q isn't a date like 2006-01-02", o.EndDate)
+		}
+		if end.Before(start) {
+			return invalid("end date %s is before start date %s", o.EndDate, o.StartDate)
+		}
+		if days := int(end.Sub(start).Hours()/24) + 1; days > l.MaxRangeDays {
+			return invalid("the date range can't be longer than %d days", l.MaxRangeDays)
+		}
+	}
+	if o.Timezone != "" && o.Timezone != "auto" {
+		if _, err := time.LoadLocation(o.Timezone); err != nil {
+			return invalid("unknown time zone %q", o.Timezone)
+		}
+	}
+	return nil
+}
+
+// Override returns o with the options set in r replacing its own
+//
+// Choosing a horizon replaces both ForecastDays and ForecastHours, so that a request for more days isn't cut
+// short by configured hours. A date range replaces the horizon altogether, and the other way round.
+func (o Options) Override(r Options) Options {
+	if r.ForecastDays != 0 || r.ForecastHours != 0 {
+		o.ForecastDays, o.ForecastHours = r.ForecastDays, r.ForecastHours
+		o.StartDate, o.EndDate = "", ""
+	}
+	if r.PastDays != 0 {
+		o.PastDays = r.PastDays
+		o.StartDate, o.EndDate = "", ""
+	}
+	if r.StartDate != "" {
+		o.StartDate, o.EndDate = r.StartDate, r.EndDate
+		o.ForecastDays, o.ForecastHours, o.PastDays = 0, 0, 0
+	}
+	if r.Model != "" {
+		o.Model = r.Model
+	}
+	if r.CellSelection != "" {
+		o.CellSelection = r.CellSelection
+	}
+	if r.Timezone != "" {
+		o.Timezone = r.Timezone
+	}
+	return o
+}
+
+// String returns the options that are set, e.g. days=7,past_days=1; empty for zero Options
+func (o Options) String() string {
+	var s []string
+	add := func(name, value string) {
+		if value != "" && value != "0" {
+			s = append(s, name+"="+value)
+		}
+	}
+	add("model", o.Model)
+	add("days", strconv.Itoa(o.ForecastDays))
+	add("hours", strconv.Itoa(o.ForecastHours))
+	add("past_days", strconv.Itoa(o.PastDays))
+	add("cell_selection", o.CellSelection)
+	add("timezone", o.Timezone)
+	add("start_date", o.StartDate)
+	add("end_date", o.EndDate)
+	return strings.Join(s, ",")
+}
+
+// DateRange returns the first and last day of the range set in valid options; ok is false if there's none
+func (o Options) DateRange() (start, end time.Time, ok bool) {
+	start, err := time.Parse(dateLayout, o.StartDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	end, err = time.Parse(dateLayout, o.EndDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	return start, end, true
+}
+
+// configOptions returns the default Options set in a provider's config entry
+func configOptions(mp config.MeteoProvider) Options {
+	return Options{
+		Model:         mp.Model,
+		ForecastDays:  mp.ForecastDays,
+		ForecastHours: mp.ForecastHours,
+		PastDays:      mp.PastDays,
+		CellSelection: mp.CellSelection,
+		Timezone:      mp.Timezone,
+	}
+}
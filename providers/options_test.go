@@ -0,0 +1,199 @@
+package providers
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestOptionsValidate(t *testing.T) {
+	all := Limits{MaxForecastDays: 16, MaxForecastHours: 384, MaxPastDays: 92, Model: true, CellSelection: true, Timezone: true, MaxRangeDays: 366}
+
+	tests := []struct {
+		name  string
+		o     Options
+		limit Limits
+		ok    bool
+	}{
+		{"zero", Options{}, Limits{}, true},
+		{"all set", Options{Model: "icon_seamless", ForecastDays: 16, ForecastHours: 384, PastDays: 92, CellSelection: "sea", Timezone: "Europe/Zurich"}, all, true},
+		{"auto time zone", Options{Timezone: "auto"}, all, true},
+		{"date range", Options{StartDate: "2024-07-01", EndDate: "2024-07-31"}, all, true},
+		{"a single day", Options{StartDate: "2024-07-01", EndDate: "2024-07-01"}, all, true},
+		{"negative days", Options{ForecastDays: -1}, all, false},
+		{"days beyond the limit", Options{ForecastDays: 17}, all, false},
+		{"days not allowed", Options{ForecastDays: 1}, Limits{}, false},
+		{"hours beyond the limit", Options{ForecastHours: 385}, all, false},
+		{"past days beyond the limit", Options{PastDays: 93}, all, false},
+		{"model not allowed", Options{Model: "icon_seamless"}, Limits{}, false},
+		{"model not a name", Options{Model: "icon&seamless"}, all, false},
+		{"unknown cell selection", Options{CellSelection: "lake"}, all, false},
+		{"cell selection not allowed", Options{CellSelection: "sea"}, Limits{}, false},
+		{"unknown time zone", Options{Timezone: "Mars/Olympus"}, all, false},
+		{"time zone not allowed", Options{Timezone: "auto"}, Limits{}, false},
+		{"date range not allowed", Options{StartDate: "2024-07-01", EndDate: "2024-07-31"}, Limits{}, false},
+		{"start without end", Options{StartDate: "2024-07-01"}, all, false},
+		{"end without start", Options{EndDate: "2024-07-31"}, all, false},
+		{"range and days", Options{StartDate: "2024-07-01", EndDate: "2024-07-31", ForecastDays: 3}, all, false},
+		{"range and past days", Options{StartDate: "2024-07-01", EndDate: "2024-07-31", PastDays: 1}, all, false},
+		{"start not a date", Options{StartDate: "1 July", EndDate: "2024-07-31"}, all, false},
+		{"end not a date", Options{StartDate: "2024-07-01", EndDate: "2024-07-32"}, all, false},
+		{"end before start", Options{StartDate: "2024-07-31", EndDate: "2024-07-01"}, all, false},
+		{"longest range", Options{StartDate: "2024-01-01", EndDate: "2024-12-31"}, all, true},
+		{"range beyond the limit", Options{StartDate: "2024-01-01", EndDate: "2025-01-01"}, all, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.o.Validate(tt.limit)
+			if tt.ok && err != nil {
+				t.Errorf("Validate() = %v, want nil", err)
+			}
+			if !tt.ok && !errors.Is(err, ErrInvalidOptions) {
+				t.Errorf("Validate() = %v, want ErrInvalidOptions", err)
+			}
+		})
+	}
+}
+
+func TestProviderLimits(t *testing.T) {
+	tests := []struct {
+		provider string
+		o        Options
+		ok       bool
+	}{
+		{openMeteoProviderName, Options{ForecastDays: 16, PastDays: 92, Model: "icon_seamless", CellSelection: "land", Timezone: "auto"}, true},
+		{openMeteoProviderName, Options{ForecastDays: 17}, false},
+		{openMeteoProviderName, Options{StartDate: "2024-07-01", EndDate: "2024-07-31"}, false},
+		{meteoBlueProviderName, Options{ForecastDays: 14, Timezone: "auto"}, true},
+		{meteoBlueProviderName, Options{ForecastDays: 15}, false},
+		{meteoBlueProviderName, Options{ForecastHours: 24}, false},
+		{meteoBlueProviderName, Options{PastDays: 1}, false},
+		{meteoBlueProviderName, Options{Model: "nems"}, false},
+		{metarProviderName, Options{}, true},
+		{metarProviderName, Options{ForecastDays: 1}, false},
+		{metarProviderName, Options{Timezone: "auto"}, false},
+		{ensembleProviderName, Options{ForecastDays: 35, ForecastHours: 35 * 24, Model: "gfs025"}, true},
+		{ensembleProviderName, Options{ForecastDays: 36}, false},
+		{historicalProviderName, Options{StartDate: "2024-07-01", EndDate: "2024-07-31", Model: "era5", Timezone: "auto"}, true},
+		{historicalProviderName, Options{StartDate: "1991-01-01", EndDate: "2020-12-31"}, true},
+		{historicalProviderName, Options{StartDate: "1990-01-01", EndDate: "2020-12-31"}, false},
+		{historicalProviderName, Options{ForecastDays: 1}, false},
+		{historicalProviderName, Options{PastDays: 1}, false},
+		{airQualityProviderName, Options{ForecastDays: 7, PastDays: 92, Model: "cams_europe"}, true},
+		{airQualityProviderName, Options{ForecastDays: 8}, false},
+		{airQualityProviderName, Options{ForecastHours: 7*24 + 1}, false},
+		{marineProviderName, Options{ForecastDays: 16, Model: "ecmwf_wam025", CellSelection: "sea"}, true},
+		{marineProviderName, Options{ForecastDays: 17}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.provider+"/"+tt.o.String(), func(t *testing.T) {
+			l, err := LimitsOf(tt.provider)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.o.Validate(l); (err == nil) != tt.ok {
+				t.Errorf("Validate() = %v, want ok %v", err, tt.ok)
+			}
+		})
+	}
+
+	if _, err := LimitsOf("nope"); !errors.Is(err, ErrUnknownProvider) {
+		t.Errorf("LimitsOf(nope) = %v, want ErrUnknownProvider", err)
+	}
+}
+
+func TestOptionsOverride(t *testing.T) {
+	configured := Options{Model: "icon_seamless", ForecastDays: 7, ForecastHours: 48, PastDays: 1, Timezone: "auto"}
+	ranged := Options{Model: "era5", StartDate: "2024-07-01", EndDate: "2024-07-31"}
+
+	tests := []struct {
+		name string
+		o, r Options
+		want Options
+	}{
+		{"nothing", configured, Options{}, configured},
+		{"days replace the hours", configured, Options{ForecastDays: 14},
+			Options{Model: "icon_seamless", ForecastDays: 14, PastDays: 1, Timezone: "auto"}},
+		{"hours replace the days", configured, Options{ForecastHours: 12},
+			Options{Model: "icon_seamless", ForecastHours: 12, PastDays: 1, Timezone: "auto"}},
+		{"model and cell selection", configured, Options{Model: "gfs_seamless", CellSelection: "sea", Timezone: "UTC"},
+			Options{Model: "gfs_seamless", ForecastDays: 7, ForecastHours: 48, PastDays: 1, CellSelection: "sea", Timezone: "UTC"}},
+		{"range clears the horizon", configured, Options{StartDate: "2024-07-01", EndDate: "2024-07-02"},
+			Options{Model: "icon_seamless", Timezone: "auto", StartDate: "2024-07-01", EndDate: "2024-07-02"}},
+		{"days clear the range", ranged, Options{ForecastDays: 3}, Options{Model: "era5", ForecastDays: 3}},
+		{"past days clear the range", ranged, Options{PastDays: 2}, Options{Model: "era5", PastDays: 2}},
+		{"range replaces the range", ranged, Options{StartDate: "2023-01-01", EndDate: "2023-12-31"},
+			Options{Model: "era5", StartDate: "2023-01-01", EndDate: "2023-12-31"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.o.Override(tt.r); got != tt.want {
+				t.Errorf("Override() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOptionsString(t *testing.T) {
+	tests := []struct {
+		o    Options
+		want string
+	}{
+		{Options{}, ""},
+		{Options{ForecastDays: 7, PastDays: 1}, "days=7,past_days=1"},
+		{Options{Model: "icon_seamless", ForecastHours: 48, CellSelection: "land", Timezone: "auto"}, "model=icon_seamless,hours=48,cell_selection=land,timezone=auto"},
+		{Options{StartDate: "2024-07-01", EndDate: "2024-07-31"}, "start_date=2024-07-01,end_date=2024-07-31"},
+	}
+	for _, tt := range tests {
+		if got := tt.o.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.o, got, tt.want)
+		}
+	}
+}
+
+func TestOptionsDateRange(t *testing.T) {
+	start, end, ok := Options{StartDate: "2024-07-01", EndDate: "2024-07-31"}.DateRange()
+	if !ok || !start.Equal(time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC)) || !end.Equal(time.Date(2024, time.July, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("DateRange() = %v, %v, %v, want July 2024", start, end, ok)
+	}
+	for _, o := range []Options{{}, {ForecastDays: 7}, {StartDate: "2024-07-01"}, {StartDate: "2024-07-01", EndDate: "soon"}} {
+		if _, _, ok := o.DateRange(); ok {
+			t.Errorf("%+v has a date range", o)
+		}
+	}
+}
+
+func TestSettledYears(t *testing.T) {
+	last := historicalLatest(time.Now())
+	tests := []struct {
+		name string
+		o    Options
+		want []string // Start and end dates of the years
+	}{
+		{"within a year", Options{StartDate: "2020-03-01", EndDate: "2020-11-30"}, nil},
+		{"not settled", Options{StartDate: "2020-03-01", EndDate: last.AddDate(0, 0, 1).Format(dateLayout)}, nil},
+		{"no range", Options{}, nil},
+		{"across years", Options{Model: "era5", StartDate: "2019-12-01", EndDate: "2021-02-28"},
+			[]string{"2019-12-01", "2019-12-31", "2020-01-01", "2020-12-31", "2021-01-01", "2021-02-28"}},
+		{"whole years", Options{StartDate: "2019-01-01", EndDate: "2020-12-31"},
+			[]string{"2019-01-01", "2019-12-31", "2020-01-01", "2020-12-31"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, y := range SettledYears(historicalProviderName, tt.o) {
+				if y.Model != tt.o.Model {
+					t.Errorf("model %q, want %q kept", y.Model, tt.o.Model)
+				}
+				got = append(got, y.StartDate, y.EndDate)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SettledYears() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got := SettledYears(openMeteoProviderName, Options{StartDate: "2019-01-01", EndDate: "2020-12-31"}); got != nil {
+		t.Errorf("SettledYears(open-meteo) = %v, want nil", got)
+	}
+}
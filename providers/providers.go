@@ -9,23 +9,30 @@
 //
 // The main components of this package are:
 // - Provider interface: Defines the methods that each provider must implement.
-// - NewProvider function: A factory method that returns the appropriate provider based on the name.
+// - New function: A factory method that returns the appropriate provider based on the name.
+// - Registry: Holds one provider per configured name, built once and shared across goroutines.
 //
 // Example usage:
 //
-//	provider, err := providers.NewProvider("open-meteo")
+//	provider, err := providers.New("open-meteo", cfg)
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
-//	data, err := provider.FetchData(queryParams)
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//	data, err := provider.FetchData(ctx, plumber.NewCoordinates(11.0056, 76.9661), providers.Request{})
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
 //
+// Providers call upstream on every fetch; wrap them with the cache package to cache and coalesce fetches.
+//
 // The package initializes a logger with the tag "providers" to facilitate logging within the package.
 package providers
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
 	"github.com/tinkershack/meteomunch/config"
@@ -39,10 +46,41 @@ func init() {
 	l = logger.NewTag("providers")
 }
 
+var (
+	// ErrUnknownProvider is returned by New when the requested provider isn't supported
+	ErrUnknownProvider = errors.New("unknown provider")
+	// ErrProviderNotConfigured is returned by New when the provider has no entry in config.MeteoProviders
+	ErrProviderNotConfigured = errors.New("provider configuration not found")
+)
+
 // Provider interface defines the methods that each provider must implement
+//
+// FetchData fetches what the Request asks for at coords. It must honour ctx: cancelling it or letting its
+// deadline pass aborts the upstream call. Implementations must be safe for concurrent use, so that a single
+// instance can serve every request. QueryParams returns the upstream query parameters of the zero Request
+// for the coordinates without mutating the provider.
 type Provider interface {
-	FetchData(coords *plumber.Coordinates) (*plumber.BaseData, error)
-	SetQueryParams(coords *plumber.Coordinates)
+	FetchData(ctx context.Context, coords *plumber.Coordinates, r Request) (*plumber.BaseData, error)
+	QueryParams(coords *plumber.Coordinates) map[string]string
+}
+
+// Request is what a fetch asks of a provider beyond the coordinates; the zero Request fetches everything as configured
+type Request struct {
+	Options Options        // Override the options in the provider's config entry
+	Fields  plumber.Fields // Variables to fetch, so that the rest can be left out of the upstream call; nil for all
+}
+
+// ModelFetcher is implemented by providers that can fetch several weather models in a single call
+//
+// FetchModels returns the data of every model keyed by its name, fetched as FetchData would fetch the Request
+// apart from the model.
+type ModelFetcher interface {
+	FetchModels(ctx context.Context, coords *plumber.Coordinates, models []string, r Request) (map[string]*plumber.BaseData, error)
+}
+
+// EnsembleFetcher is implemented by providers that can fetch every member of an ensemble forecast
+type EnsembleFetcher interface {
+	FetchEnsemble(ctx context.Context, coords *plumber.Coordinates, r Request) (*plumber.Ensemble, error)
 }
 
 // New returns the appropriate provider based on the name
@@ -60,7 +98,37 @@ func New(name string, cfg *config.Config) (Provider, error) {
 			return nil, err
 		}
 		return p, nil
+	case "metar":
+		p, err := newMETAR(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return p, nil
+	case "open-meteo-ensemble":
+		p, err := newOpenMeteoEnsemble(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return p, nil
+	case "open-meteo-historical":
+		p, err := newOpenMeteoHistorical(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return p, nil
+	case "open-meteo-air-quality":
+		p, err := newOpenMeteoAirQuality(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return p, nil
+	case "open-meteo-marine":
+		p, err := newOpenMeteoMarine(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return p, nil
 	default:
-		return nil, fmt.Errorf("unknown provider: %s", name)
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProvider, name)
 	}
 }
@@ -0,0 +1,140 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/tinkershack/meteomunch/config"
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+// Registry holds a single, shared instance of every provider listed in config.MeteoProviders
+//
+// Providers are safe for concurrent use, so a Registry built once at startup can serve all requests
+// instead of constructing a provider, and its HTTP client, per request. The Registry is too.
+type Registry struct {
+	cfg       *config.Config
+	mu        sync.RWMutex // Guards providers against Wrap
+	providers map[string]Provider
+	upstream  map[string]Provider // As New built them, before Wrap
+}
+
+// NewRegistry builds every provider listed in cfg.MeteoProviders
+func NewRegistry(cfg *config.Config) (*Registry, error) {
+	if cfg == nil {
+		return nil, errors.New("configuration cannot be nil")
+	}
+
+	r := &Registry{
+		cfg:       cfg,
+		providers: make(map[string]Provider, len(cfg.MeteoProviders)),
+		upstream:  make(map[string]Provider, len(cfg.MeteoProviders)),
+	}
+	for _, mp := range cfg.MeteoProviders {
+		if _, ok := r.providers[mp.Name]; ok {
+			continue
+		}
+		p, err := New(mp.Name, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't create provider %s: %w", mp.Name, err)
+		}
+		r.providers[mp.Name] = p
+		r.upstream[mp.Name] = p
+	}
+	return r, nil
+}
+
+// Get returns the shared provider registered under name
+//
+// The error wraps ErrUnknownProvider if munch doesn't support the provider at all,
+// and ErrProviderNotConfigured if it does but there's no config entry for it.
+func (r *Registry) Get(name string) (Provider, error) {
+	r.mu.RLock()
+	p, ok := r.providers[name]
+	r.mu.RUnlock()
+	if ok {
+		return p, nil
+	}
+	if _, err := New(name, r.cfg); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("%w: %s", ErrProviderNotConfigured, name)
+}
+
+// Upstream returns the provider registered under name as New built it, without whatever Wrap put in front of it,
+// e.g. to reach methods beyond the Provider interface such as ModelFetcher
+//
+// The error is that of Get.
+func (r *Registry) Upstream(name string) (Provider, error) {
+	if _, err := r.Get(name); err != nil {
+		return nil, err
+	}
+	return r.upstream[name], nil
+}
+
+// Wrap replaces every provider in the registry with the one wrap returns for it, e.g. to put a cache in front of them
+//
+// Callers already holding a provider keep fetching from it unwrapped, so call Wrap before sharing the registry.
+// wrap must not call back into the registry.
+func (r *Registry) Wrap(wrap func(name string, p Provider) Provider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for name, p := range r.providers {
+		r.providers[name] = wrap(name, p)
+	}
+}
+
+// Names lists the providers in the registry
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.names()
+}
+
+func (r *Registry) names() []string {
+	names := make([]string, 0, len(r.providers))
+	for _, mp := range r.cfg.MeteoProviders {
+		if _, ok := r.providers[mp.Name]; ok && !slices.Contains(names, mp.Name) {
+			names = append(names, mp.Name)
+		}
+	}
+	return names
+}
+
+// Sources returns every provider in the registry as a plumber.Source fetching req, weighted as configured,
+// ready for a plumber.Merger
+//
+// Providers of anything but a weather forecast are left out, see Forecasts, and so is the ensemble, whose control
+// run repeats a model open-meteo serves.
+func (r *Registry) Sources(req Request) []plumber.Source {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var sources []plumber.Source
+	for _, name := range r.names() {
+		if !Forecasts(name) || name == ensembleProviderName {
+			continue
+		}
+		weight := 1.0
+		for _, mp := range r.cfg.MeteoProviders {
+			if mp.Name == name && mp.Weight > 0 {
+				weight = mp.Weight
+				break
+			}
+		}
+		sources = append(sources, plumber.Source{Name: name, Fetcher: fetcher{r.providers[name], req}, Weight: weight})
+	}
+	return sources
+}
+
+// fetcher is a plumber.Fetcher fetching a Request from a provider
+type fetcher struct {
+	p Provider
+	r Request
+}
+
+func (f fetcher) FetchData(ctx context.Context, coords *plumber.Coordinates) (*plumber.BaseData, error) {
+	return f.p.FetchData(ctx, coords, f.r)
+}
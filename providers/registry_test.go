@@ -0,0 +1,122 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/tinkershack/meteomunch/config"
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+// countingProvider counts the fetches that reach it before passing them on
+type countingProvider struct {
+	Provider
+	fetches *atomic.Int32
+}
+
+func (c countingProvider) FetchData(ctx context.Context, coords *plumber.Coordinates, r Request) (*plumber.BaseData, error) {
+	c.fetches.Add(1)
+	return c.Provider.FetchData(ctx, coords, r)
+}
+
+func TestRegistryConcurrentUse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"latitude":46.68,"longitude":7.86,"hourly":{"time":[1720000800],"temperature_2m":[21.5]}}`))
+	}))
+	defer srv.Close()
+	cfg := &config.Config{MeteoProviders: []config.MeteoProvider{
+		{Name: "open-meteo", BaseURI: srv.URL, APIPath: "v1/forecast"},
+		{Name: "open-meteo-historical", BaseURI: srv.URL, APIPath: "v1/archive"},
+	}}
+	r, err := NewRegistry(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fetches atomic.Int32
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		r.Wrap(func(_ string, p Provider) Provider { return countingProvider{p, &fetches} })
+	}()
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for _, name := range r.Names() {
+				if _, err := r.Get(name); err != nil {
+					t.Error(err)
+				}
+			}
+			if _, err := r.Upstream("open-meteo"); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			sources := r.Sources(Request{})
+			if len(sources) != 1 || sources[0].Name != "open-meteo" {
+				t.Errorf("sources = %+v, want open-meteo alone", sources)
+				return
+			}
+			data, err := sources[0].Fetcher.FetchData(context.Background(), plumber.NewCoordinates(46.68, 7.86))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if len(data.Hourly.Temperature2M) != 1 {
+				t.Errorf("hourly temperature = %v", data.Hourly.Temperature2M)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Once wrapped, every fetch passes the wrapper
+	before := fetches.Load()
+	p, _ := r.Get("open-meteo")
+	if _, err := p.FetchData(context.Background(), plumber.NewCoordinates(46.68, 7.86), Request{}); err != nil {
+		t.Fatal(err)
+	}
+	if fetches.Load() != before+1 {
+		t.Error("fetch after Wrap didn't pass the wrapper")
+	}
+}
+
+func TestRegistrySources(t *testing.T) {
+	cfg := &config.Config{MeteoProviders: []config.MeteoProvider{
+		{Name: "open-meteo", Weight: 2},
+		{Name: "meteoblue"},
+		{Name: "metar"},
+		{Name: "open-meteo-ensemble"},
+		{Name: "open-meteo-historical"},
+		{Name: "open-meteo-air-quality"},
+		{Name: "open-meteo-marine"},
+		{Name: "open-meteo", Weight: 5}, // Duplicate entries count once, as the first
+	}}
+	r, err := NewRegistry(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := r.Names(); len(names) != 7 {
+		t.Errorf("names = %v, want every provider once", names)
+	}
+
+	sources := r.Sources(Request{})
+	want := []struct {
+		name   string
+		weight float64
+	}{{"open-meteo", 2}, {"meteoblue", 1}}
+	if len(sources) != len(want) {
+		t.Fatalf("sources = %+v, want the forecast providers alone", sources)
+	}
+	for i, w := range want {
+		if sources[i].Name != w.name || sources[i].Weight != w.weight || sources[i].Fetcher == nil {
+			t.Errorf("sources[%d] = %+v, want %s weighing %v", i, sources[i], w.name, w.weight)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package pws
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	kmhPerMPH   = 1.609344
+	hPaPerInHg  = 33.8639
+	mmPerInch   = 25.4
+	missingWU   = -9999 // Value WU protocol clients send for sensors that are missing or broken
+	dateUTCForm = "2006-01-02 15:04:05"
+
+	// maxClockSkew is how far ahead of the server's clock the time of an upload may be
+	maxClockSkew = 5 * time.Minute
+)
+
+// Credentials identify the station an upload comes from
+type Credentials struct {
+	ID  string // Empty for Ecowitt uploads, which only carry the PASSKEY
+	Key string
+}
+
+// ParseWU parses an upload in the Weather Underground protocol, the query of a GET updateweatherstation.php
+//
+// Readings stamped "now" are taken at now.
+func ParseWU(q url.Values, now time.Time) (Credentials, *Reading, error) {
+	creds := Credentials{ID: q.Get("ID"), Key: q.Get("PASSWORD")}
+	if creds.ID == "" {
+		return creds, nil, fmt.Errorf("%w: no station ID", ErrInvalidUpload)
+	}
+	t, err := uploadTime(q.Get("dateutc"), now)
+	if err != nil {
+		return creds, nil, err
+	}
+
+	r := &Reading{Station: creds.ID, Time: t}
+	p := uploadParser{q: q}
+	r.Temperature = p.float("tempf", fahrenheit)
+	r.Humidity = p.float("humidity", nil)
+	r.DewPoint = p.float("dewptf", fahrenheit)
+	r.PressureMSL = p.float("baromin", scale(hPaPerInHg))
+	r.WindSpeed = p.float("windspeedmph", scale(kmhPerMPH))
+	r.WindDirection = p.float("winddir", nil)
+	r.WindGusts = p.float("windgustmph", scale(kmhPerMPH))
+	r.Precipitation = p.float("rainin", scale(mmPerInch)) // Accumulated over the past hour
+	r.DailyRain = p.float("dailyrainin", scale(mmPerInch))
+	r.SolarRadiation = p.float("solarradiation", nil)
+	r.UVIndex = p.float("UV", nil)
+	if p.err != nil {
+		return creds, nil, p.err
+	}
+	r.complete()
+	return creds, r, nil
+}
+
+// ParseEcowitt parses an upload in Ecowitt's custom server protocol, the form of a POST
+//
+// The station is identified by its PASSKEY alone; the caller names the reading after the configured station.
+func ParseEcowitt(form url.Values, now time.Time) (Credentials, *Reading, error) {
+	creds := Credentials{Key: form.Get("PASSKEY")}
+	if creds.Key == "" {
+		return creds, nil, fmt.Errorf("%w: no PASSKEY", ErrInvalidUpload)
+	}
+	t, err := uploadTime(form.Get("dateutc"), now)
+	if err != nil {
+		return creds, nil, err
+	}
+
+	r := &Reading{Time: t}
+	p := uploadParser{q: form}
+	r.Temperature = p.float("tempf", fahrenheit)
+	r.Humidity = p.float("humidity", nil)
+	r.PressureMSL = p.float("baromrelin", scale(hPaPerInHg))
+	r.SurfacePressure = p.float("baromabsin", scale(hPaPerInHg))
+	r.WindSpeed = p.float("windspeedmph", scale(kmhPerMPH))
+	r.WindDirection = p.float("winddir", nil)
+	r.WindGusts = p.float("windgustmph", scale(kmhPerMPH))
+	r.Precipitation = p.float("hourlyrainin", scale(mmPerInch))
+	r.RainRate = p.float("rainratein", scale(mmPerInch))
+	r.DailyRain = p.float("dailyrainin", scale(mmPerInch))
+	r.SolarRadiation = p.float("solarradiation", nil)
+	r.UVIndex = p.float("uv", nil)
+	if p.err != nil {
+		return creds, nil, p.err
+	}
+	r.complete()
+	return creds, r, nil
+}
+
+// uploadTime parses the dateutc of an upload, "now" or missing meaning now
+//
+// Times more than maxClockSkew ahead of now are rejected; a station with its clock off by a year would otherwise
+// have its reading served as the latest one for a year.
+func uploadTime(s string, now time.Time) (time.Time, error) {
+	if s == "" || strings.EqualFold(s, "now") {
+		return now.UTC().Truncate(time.Second), nil
+	}
+	t, err := time.Parse(dateUTCForm, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: dateutc: %w", ErrInvalidUpload, err)
+	}
+	if t.After(now.Add(maxClockSkew)) {
+		return time.Time{}, fmt.Errorf("%w: dateutc %s is ahead of the server's clock", ErrInvalidUpload, s)
+	}
+	return t, nil
+}
+
+// uploadParser reads numeric upload parameters, remembering the first malformed one
+type uploadParser struct {
+	q   url.Values
+	err error
+}
+
+// float returns the named parameter converted by conv, nil if it's missing
+func (p *uploadParser) float(name string, conv func(float64) float64) *float64 {
+	s := p.q.Get(name)
+	if s == "" {
+		return nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		if p.err == nil {
+			p.err = fmt.Errorf("%w: %s isn't a number: %q", ErrInvalidUpload, name, s)
+		}
+		return nil
+	}
+	if v <= missingWU {
+		return nil
+	}
+	if conv != nil {
+		v = conv(v)
+	}
+	v = math.Round(v*100) / 100
+	return &v
+}
+
+func fahrenheit(f float64) float64 {
+	return (f - 32) * 5 / 9
+}
+
+func scale(factor float64) func(float64) float64 {
+	return func(v float64) float64 { return v * factor }
+}
@@ -0,0 +1,148 @@
+package pws
+
+import (
+	"errors"
+	"math"
+	"net/url"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2024, 10, 1, 6, 0, 30, 0, time.UTC)
+
+// values are the readings expected of an upload, by JSON name
+type values map[string]float64
+
+// checkReading compares the values of a reading to those expected, to within the rounding of the parsers;
+// fields that aren't expected must be missing
+func checkReading(t *testing.T, r *Reading, want values) {
+	t.Helper()
+	got := map[string]*float64{
+		"temperature_2m":       r.Temperature,
+		"relative_humidity_2m": r.Humidity,
+		"dew_point_2m":         r.DewPoint,
+		"pressure_msl":         r.PressureMSL,
+		"surface_pressure":     r.SurfacePressure,
+		"wind_speed_10m":       r.WindSpeed,
+		"wind_direction_10m":   r.WindDirection,
+		"wind_gusts_10m":       r.WindGusts,
+		"precipitation":        r.Precipitation,
+		"rain_rate":            r.RainRate,
+		"daily_rain":           r.DailyRain,
+		"solar_radiation":      r.SolarRadiation,
+		"uv_index":             r.UVIndex,
+	}
+	for name, v := range got {
+		w, ok := want[name]
+		switch {
+		case !ok && v != nil:
+			t.Errorf("%s = %v, want none", name, *v)
+		case ok && v == nil:
+			t.Errorf("%s missing, want %v", name, w)
+		case ok && math.Abs(*v-w) > 0.005:
+			t.Errorf("%s = %v, want %v", name, *v, w)
+		}
+	}
+}
+
+func TestParseWU(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		creds Credentials
+		time  time.Time
+		want  values // nil for an error
+	}{
+		{"upload", "ID=KCASANFR5&PASSWORD=secret&dateutc=2024-10-01+06%3A00%3A00&winddir=230&windspeedmph=12&windgustmph=12" +
+			"&tempf=70&rainin=0.1&dailyrainin=0.5&baromin=29.1&dewptf=68.2&humidity=90&solarradiation=320.5&UV=3&softwaretype=vws%20versionxx&action=updateraw",
+			Credentials{ID: "KCASANFR5", Key: "secret"}, time.Date(2024, 10, 1, 6, 0, 0, 0, time.UTC), values{
+				"temperature_2m": 21.11, "relative_humidity_2m": 90, "dew_point_2m": 20.11, "pressure_msl": 985.44,
+				"wind_speed_10m": 19.31, "wind_direction_10m": 230, "wind_gusts_10m": 19.31, "precipitation": 2.54,
+				"daily_rain": 12.7, "solar_radiation": 320.5, "uv_index": 3,
+			}},
+		// The dew point is derived from the temperature and humidity, and -9999 marks a broken sensor
+		{"now", "ID=KCASANFR5&PASSWORD=secret&dateutc=now&tempf=32&humidity=100&windspeedmph=-9999",
+			Credentials{ID: "KCASANFR5", Key: "secret"}, now.Truncate(time.Second), values{
+				"temperature_2m": 0, "relative_humidity_2m": 100, "dew_point_2m": 0,
+			}},
+		{"no time", "ID=KCASANFR5&PASSWORD=secret&tempf=50", Credentials{ID: "KCASANFR5", Key: "secret"}, now.Truncate(time.Second), values{"temperature_2m": 10}},
+		{"slightly ahead", "ID=KCASANFR5&PASSWORD=secret&dateutc=2024-10-01+06%3A04%3A00", Credentials{ID: "KCASANFR5", Key: "secret"}, time.Date(2024, 10, 1, 6, 4, 0, 0, time.UTC), values{}},
+		{"no ID", "PASSWORD=secret&tempf=70", Credentials{Key: "secret"}, time.Time{}, nil},
+		{"bad time", "ID=KCASANFR5&PASSWORD=secret&dateutc=yesterday", Credentials{ID: "KCASANFR5", Key: "secret"}, time.Time{}, nil},
+		{"ahead of the clock", "ID=KCASANFR5&PASSWORD=secret&dateutc=2025-10-01+06%3A00%3A00", Credentials{ID: "KCASANFR5", Key: "secret"}, time.Time{}, nil},
+		{"not a number", "ID=KCASANFR5&PASSWORD=secret&tempf=warm", Credentials{ID: "KCASANFR5", Key: "secret"}, time.Time{}, nil},
+		{"not a finite number", "ID=KCASANFR5&PASSWORD=secret&baromin=NaN", Credentials{ID: "KCASANFR5", Key: "secret"}, time.Time{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			creds, r, err := ParseWU(q, now)
+			if creds != tt.creds {
+				t.Errorf("credentials = %+v, want %+v", creds, tt.creds)
+			}
+			if tt.want == nil {
+				if !errors.Is(err, ErrInvalidUpload) {
+					t.Errorf("err = %v, want ErrInvalidUpload", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.Station != tt.creds.ID || !r.Time.Equal(tt.time) {
+				t.Errorf("reading of %q at %v, want %q at %v", r.Station, r.Time, tt.creds.ID, tt.time)
+			}
+			checkReading(t, r, tt.want)
+		})
+	}
+}
+
+func TestParseEcowitt(t *testing.T) {
+	const upload = "PASSKEY=0123456789ABCDEF&stationtype=GW1000A_V1.6.8&dateutc=2024-10-01+06:00:00&tempinf=72.3&humidityin=45" +
+		"&baromrelin=29.921&baromabsin=28.486&tempf=59.9&humidity=82&winddir=262&windspeedmph=2.46&windgustmph=4.47" +
+		"&maxdailygust=10.29&rainratein=0.039&eventrainin=0.000&hourlyrainin=0.020&dailyrainin=0.110&weeklyrainin=0.110" +
+		"&monthlyrainin=0.441&totalrainin=0.441&solarradiation=0.00&uv=0&wh65batt=0&freq=868M&model=GW1000_Pro"
+
+	tests := []struct {
+		name string
+		form string
+		want values // nil for an error
+	}{
+		{"upload", upload, values{
+			"temperature_2m": 15.5, "relative_humidity_2m": 82, "dew_point_2m": 12.4, "pressure_msl": 1013.24,
+			"surface_pressure": 964.65, "wind_speed_10m": 3.96, "wind_direction_10m": 262, "wind_gusts_10m": 7.19,
+			"precipitation": 0.51, "rain_rate": 0.99, "daily_rain": 2.79, "solar_radiation": 0, "uv_index": 0,
+		}},
+		{"no PASSKEY", "tempf=59.9&dateutc=now", nil},
+		{"ahead of the clock", "PASSKEY=0123456789ABCDEF&dateutc=2024-10-01+07:00:00", nil},
+		{"not a number", "PASSKEY=0123456789ABCDEF&humidity=high", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form, err := url.ParseQuery(tt.form)
+			if err != nil {
+				t.Fatal(err)
+			}
+			creds, r, err := ParseEcowitt(form, now)
+			if creds.ID != "" || creds.Key != form.Get("PASSKEY") {
+				t.Errorf("credentials = %+v, want the PASSKEY alone", creds)
+			}
+			if tt.want == nil {
+				if !errors.Is(err, ErrInvalidUpload) {
+					t.Errorf("err = %v, want ErrInvalidUpload", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := time.Date(2024, 10, 1, 6, 0, 0, 0, time.UTC); r.Station != "" || !r.Time.Equal(want) {
+				t.Errorf("reading of %q at %v, want an unnamed one at %v", r.Station, r.Time, want)
+			}
+			checkReading(t, r, tt.want)
+		})
+	}
+}
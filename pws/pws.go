@@ -0,0 +1,156 @@
+// Package pws ingests the readings of personal weather stations, like those set up at launch sites.
+//
+// Stations upload with either the Weather Underground protocol (updateweatherstation.php) or Ecowitt's
+// custom server protocol; ParseWU and ParseEcowitt turn an upload into a Reading in plumber.CommonUnits.
+// Stations keeps the recent readings of the configured stations and serves the latest one as
+// plumber.CurrentData, or any of them as a verify.Observation.
+package pws
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/tinkershack/meteomunch/plumber"
+	"github.com/tinkershack/meteomunch/verify"
+)
+
+var (
+	// ErrUnknownStation is returned for stations that aren't configured
+	ErrUnknownStation = errors.New("unknown weather station")
+	// ErrUnauthorized is returned when an upload's credentials don't match a configured station
+	ErrUnauthorized = errors.New("weather station credentials don't match")
+	// ErrNoReading is returned when a station hasn't uploaded anything recently
+	ErrNoReading = errors.New("no recent reading")
+	// ErrInvalidUpload is returned when an upload can't be parsed
+	ErrInvalidUpload = errors.New("invalid upload")
+)
+
+// Reading is what a station measured at a given time, in plumber.CommonUnits; nil values weren't reported
+type Reading struct {
+	Station         string    `json:"station"`
+	Time            time.Time `json:"time"`
+	Temperature     *float64  `json:"temperature_2m,omitempty"`       // in °C
+	Humidity        *float64  `json:"relative_humidity_2m,omitempty"` // in %
+	DewPoint        *float64  `json:"dew_point_2m,omitempty"`         // in °C
+	PressureMSL     *float64  `json:"pressure_msl,omitempty"`         // Relative pressure, reduced to mean sea level, in hPa
+	SurfacePressure *float64  `json:"surface_pressure,omitempty"`     // Absolute pressure at the station in hPa
+	WindSpeed       *float64  `json:"wind_speed_10m,omitempty"`       // in km/h
+	WindDirection   *float64  `json:"wind_direction_10m,omitempty"`   // in degrees
+	WindGusts       *float64  `json:"wind_gusts_10m,omitempty"`       // in km/h
+	Precipitation   *float64  `json:"precipitation,omitempty"`        // Over the past hour in mm
+	RainRate        *float64  `json:"rain_rate,omitempty"`            // in mm/h
+	DailyRain       *float64  `json:"daily_rain,omitempty"`           // Since local midnight in mm
+	SolarRadiation  *float64  `json:"solar_radiation,omitempty"`      // in W/m²
+	UVIndex         *float64  `json:"uv_index,omitempty"`
+}
+
+// CurrentData maps the reading onto plumber.CurrentData
+//
+// Stations don't report clouds or snow, so those stay zero; the weather code only reflects rain.
+// The dew point or humidity is derived from the other when only one of them is reported.
+func (r *Reading) CurrentData() plumber.CurrentData {
+	cd := plumber.CurrentData{Time: r.Time.Unix()}
+	if r.Temperature != nil {
+		cd.Temperature2M = *r.Temperature
+		cd.ApparentTemperature = *r.Temperature
+	}
+	if rh, ok := r.humidity(); ok {
+		cd.RelativeHumidity2M = int(math.Round(rh))
+		if r.Temperature != nil {
+			cd.ApparentTemperature = round1(plumber.ApparentTemperature(*r.Temperature, rh, value(r.WindSpeed)))
+		}
+	}
+	if r.SolarRadiation != nil && *r.SolarRadiation > 0 {
+		cd.IsDay = 1
+	}
+	if r.Precipitation != nil {
+		cd.Precipitation = *r.Precipitation
+		cd.Rain = *r.Precipitation
+	}
+	cd.WeatherCode = rainCode(r.RainRate, r.Precipitation)
+	cd.PressureMSL = value(r.PressureMSL)
+	cd.SurfacePressure = value(r.SurfacePressure)
+	cd.WindSpeed10M = value(r.WindSpeed)
+	cd.WindDirection10M = int(math.Round(value(r.WindDirection)))
+	cd.WindGusts10M = math.Max(value(r.WindGusts), cd.WindSpeed10M)
+	return cd
+}
+
+// Observation maps the reading onto a verify.Observation made at the station's location
+func (r *Reading) Observation(station plumber.Location) verify.Observation {
+	o := verify.Observation{
+		Station:   r.Station,
+		Latitude:  station.Coordinates.Latitude,
+		Longitude: station.Coordinates.Longitude,
+		Time:      r.Time,
+		Values:    make(map[string]float64),
+	}
+	set := func(name string, v *float64) {
+		if v != nil {
+			o.Values[name] = *v
+		}
+	}
+	set("temperature_2m", r.Temperature)
+	set("dew_point_2m", r.DewPoint)
+	if rh, ok := r.humidity(); ok {
+		o.Values["relative_humidity_2m"] = math.Round(rh)
+	}
+	set("pressure_msl", r.PressureMSL)
+	set("surface_pressure", r.SurfacePressure)
+	set("wind_speed_10m", r.WindSpeed)
+	set("wind_direction_10m", r.WindDirection)
+	set("wind_gusts_10m", r.WindGusts)
+	set("precipitation", r.Precipitation)
+	set("uv_index", r.UVIndex)
+	return o
+}
+
+// humidity returns the reported relative humidity, or derives it from the temperature and dew point
+func (r *Reading) humidity() (float64, bool) {
+	switch {
+	case r.Humidity != nil:
+		return *r.Humidity, true
+	case r.Temperature != nil && r.DewPoint != nil:
+		return plumber.RelativeHumidity(*r.Temperature, *r.DewPoint), true
+	}
+	return 0, false
+}
+
+// complete fills in the dew point from the temperature and humidity when the station didn't report it
+func (r *Reading) complete() {
+	if r.DewPoint == nil && r.Temperature != nil && r.Humidity != nil {
+		td := round1(plumber.DewPoint(*r.Temperature, *r.Humidity))
+		r.DewPoint = &td
+	}
+}
+
+// rainCode returns the WMO weather code of rain falling at the given rate, or the past hour's amount
+// if the rate isn't reported, following the usual light, moderate and heavy thresholds of 2.5 and 7.6 mm/h
+func rainCode(rate, hourly *float64) int {
+	mmh := value(rate)
+	if rate == nil {
+		mmh = value(hourly)
+	}
+	switch {
+	case mmh <= 0:
+		return 0
+	case mmh < 2.5:
+		return 61 // Slight rain
+	case mmh < 7.6:
+		return 63 // Moderate rain
+	default:
+		return 65 // Heavy rain
+	}
+}
+
+func value(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
@@ -0,0 +1,161 @@
+package pws
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tinkershack/meteomunch/config"
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+// DefaultRetention is how long Stations keeps readings
+const DefaultRetention = 24 * time.Hour
+
+// Stations holds the configured weather stations and their recent readings in memory
+//
+// Stations is safe for concurrent use.
+type Stations struct {
+	stations  []config.WeatherStation
+	retention time.Duration
+
+	mu       sync.RWMutex
+	readings map[string][]Reading // By station ID, oldest first
+}
+
+// NewStations returns an empty store for the readings of the given stations, kept for retention,
+// DefaultRetention if zero
+func NewStations(stations []config.WeatherStation, retention time.Duration) *Stations {
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	return &Stations{
+		stations:  slices.Clone(stations),
+		retention: retention,
+		readings:  make(map[string][]Reading, len(stations)),
+	}
+}
+
+// Authenticate returns the ID of the station the credentials belong to
+//
+// WU protocol uploads must match both ID and Key, Ecowitt uploads, which carry no ID, the Key alone.
+func (s *Stations) Authenticate(c Credentials) (string, error) {
+	for _, ws := range s.stations {
+		if c.ID != "" && c.ID != ws.ID {
+			continue
+		}
+		if ws.Key != "" && subtle.ConstantTimeCompare([]byte(c.Key), []byte(ws.Key)) == 1 {
+			return ws.ID, nil
+		}
+	}
+	return "", ErrUnauthorized
+}
+
+// Location returns the location of a station
+func (s *Stations) Location(id string) (plumber.Location, error) {
+	ws, err := s.station(id)
+	if err != nil {
+		return plumber.Location{}, err
+	}
+	return plumber.Location{
+		Name:        ws.Name,
+		Coordinates: *plumber.NewCoordinates(ws.Latitude, ws.Longitude),
+		Elevation:   ws.Elevation,
+		Timezone:    ws.Timezone,
+	}, nil
+}
+
+// IDs lists the configured stations
+func (s *Stations) IDs() []string {
+	ids := make([]string, len(s.stations))
+	for i, ws := range s.stations {
+		ids[i] = ws.ID
+	}
+	return ids
+}
+
+// Record stores a reading of a configured station and drops the station's readings that fell out of retention,
+// counted back from now
+//
+// A reading replaces one of the same station and time, so retried uploads aren't counted twice. A reading already
+// out of retention, e.g. one a station had buffered while offline, is dropped along with them.
+func (s *Stations) Record(r Reading, now time.Time) error {
+	if _, err := s.station(r.Station); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	readings := s.readings[r.Station]
+	i := sort.Search(len(readings), func(i int) bool { return !readings[i].Time.Before(r.Time) })
+	if i < len(readings) && readings[i].Time.Equal(r.Time) {
+		readings[i] = r
+	} else {
+		readings = slices.Insert(readings, i, r)
+	}
+
+	cutoff := now.Add(-s.retention)
+	keep := sort.Search(len(readings), func(i int) bool { return readings[i].Time.After(cutoff) })
+	s.readings[r.Station] = slices.Clone(readings[keep:])
+	return nil
+}
+
+// Latest returns the most recent reading of a station
+func (s *Stations) Latest(id string) (*Reading, error) {
+	if _, err := s.station(id); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	readings := s.readings[id]
+	if len(readings) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoReading, id)
+	}
+	r := readings[len(readings)-1]
+	return &r, nil
+}
+
+// Readings returns the readings of a station made within [from, to), oldest first; zero times don't filter
+func (s *Stations) Readings(id string, from, to time.Time) ([]Reading, error) {
+	if _, err := s.station(id); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var out []Reading
+	for _, r := range s.readings[id] {
+		if (!from.IsZero() && r.Time.Before(from)) || (!to.IsZero() && !r.Time.Before(to)) {
+			continue
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+// Current returns the station's location and its latest reading as plumber.CurrentData
+func (s *Stations) Current(id string) (plumber.Location, *plumber.CurrentData, error) {
+	loc, err := s.Location(id)
+	if err != nil {
+		return plumber.Location{}, nil, err
+	}
+	r, err := s.Latest(id)
+	if err != nil {
+		return loc, nil, err
+	}
+	cd := r.CurrentData()
+	return loc, &cd, nil
+}
+
+func (s *Stations) station(id string) (config.WeatherStation, error) {
+	for _, ws := range s.stations {
+		if ws.ID == id {
+			return ws, nil
+		}
+	}
+	return config.WeatherStation{}, fmt.Errorf("%w: %s", ErrUnknownStation, id)
+}
@@ -0,0 +1,172 @@
+package pws
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/tinkershack/meteomunch/config"
+)
+
+var testStations = []config.WeatherStation{
+	{ID: "launch", Key: "secret", Name: "Launch", Latitude: 46.68, Longitude: 7.86, Elevation: 1360},
+	{ID: "landing", Key: "other", Name: "Landing", Latitude: 46.69, Longitude: 7.85, Elevation: 570},
+	{ID: "keyless"},
+}
+
+func TestAuthenticate(t *testing.T) {
+	s := NewStations(testStations, 0)
+	tests := []struct {
+		name  string
+		creds Credentials
+		id    string // Empty if unauthorized
+	}{
+		{"WU", Credentials{ID: "launch", Key: "secret"}, "launch"},
+		{"Ecowitt", Credentials{Key: "other"}, "landing"},
+		{"wrong key", Credentials{ID: "launch", Key: "other"}, ""},
+		{"unknown station", Credentials{ID: "summit", Key: "secret"}, ""},
+		{"no key", Credentials{ID: "keyless"}, ""},
+		{"nothing", Credentials{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := s.Authenticate(tt.creds)
+			if tt.id == "" {
+				if !errors.Is(err, ErrUnauthorized) {
+					t.Errorf("authenticated as %q, err = %v, want ErrUnauthorized", id, err)
+				}
+				return
+			}
+			if err != nil || id != tt.id {
+				t.Errorf("authenticated as %q, err = %v, want %q", id, err, tt.id)
+			}
+		})
+	}
+}
+
+// reading returns a reading of the station at the given minutes after now with the temperature t
+func reading(station string, minutes int, t float64) Reading {
+	return Reading{Station: station, Time: now.Add(time.Duration(minutes) * time.Minute), Temperature: &t}
+}
+
+// times lists the minutes after now of the readings
+func times(readings []Reading) []int {
+	out := make([]int, len(readings))
+	for i, r := range readings {
+		out[i] = int(r.Time.Sub(now) / time.Minute)
+	}
+	return out
+}
+
+func TestRecord(t *testing.T) {
+	s := NewStations(testStations, time.Hour)
+	for _, r := range []Reading{
+		reading("launch", -90, 1), // Out of retention as it arrives
+		reading("launch", -30, 2),
+		reading("launch", -50, 3), // Out of order
+		reading("launch", -10, 4),
+		reading("launch", -30, 5), // Retried
+	} {
+		if err := s.Record(r, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Record(reading("summit", 0, 0), now); !errors.Is(err, ErrUnknownStation) {
+		t.Errorf("recorded a reading of an unknown station, err = %v", err)
+	}
+
+	readings, err := s.Readings("launch", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := times(readings); !slices.Equal(got, []int{-50, -30, -10}) {
+		t.Errorf("readings at %v minutes, want -50, -30, -10", got)
+	}
+	if *readings[1].Temperature != 5 {
+		t.Errorf("retried reading = %v °C, want the latest upload's 5 °C", *readings[1].Temperature)
+	}
+
+	// Retention is counted from now, not from the station's latest reading
+	if err := s.Record(reading("landing", 0, 6), now.Add(45*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Record(reading("launch", -10, 7), now.Add(45*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	readings, _ = s.Readings("launch", time.Time{}, time.Time{})
+	if got := times(readings); !slices.Equal(got, []int{-10}) {
+		t.Errorf("readings at %v minutes 45 minutes later, want -10", got)
+	}
+	if r, err := s.Latest("landing"); err != nil || !r.Time.Equal(now) {
+		t.Errorf("latest landing reading = %+v, err = %v", r, err)
+	}
+}
+
+func TestReadings(t *testing.T) {
+	s := NewStations(testStations, 0)
+	for _, m := range []int{-60, -40, -20, 0} {
+		if err := s.Record(reading("launch", m, float64(m)), now); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		from, to int // Minutes after now, or 1 for a zero time
+		want     []int
+	}{
+		{"all", 1, 1, []int{-60, -40, -20, 0}},
+		{"from", -40, 1, []int{-40, -20, 0}},
+		{"to excluded", 1, -20, []int{-60, -40}},
+		{"window", -50, -10, []int{-40, -20}},
+		{"empty", -30, -30, []int{}},
+	}
+	at := func(m int) time.Time {
+		if m == 1 {
+			return time.Time{}
+		}
+		return now.Add(time.Duration(m) * time.Minute)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readings, err := s.Readings("launch", at(tt.from), at(tt.to))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := times(readings); !slices.Equal(got, tt.want) {
+				t.Errorf("readings at %v minutes, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := s.Readings("summit", time.Time{}, time.Time{}); !errors.Is(err, ErrUnknownStation) {
+		t.Errorf("err = %v, want ErrUnknownStation", err)
+	}
+}
+
+func TestCurrent(t *testing.T) {
+	s := NewStations(testStations, 0)
+	if _, _, err := s.Current("landing"); !errors.Is(err, ErrNoReading) {
+		t.Errorf("err = %v before any upload, want ErrNoReading", err)
+	}
+	if _, _, err := s.Current("summit"); !errors.Is(err, ErrUnknownStation) {
+		t.Errorf("err = %v, want ErrUnknownStation", err)
+	}
+
+	for _, m := range []int{-20, -5} {
+		if err := s.Record(reading("landing", m, float64(20+m)), now); err != nil {
+			t.Fatal(err)
+		}
+	}
+	loc, cd, err := s.Current("landing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loc.Name != "Landing" || loc.Elevation != 570 || loc.Coordinates.Latitude != 46.69 {
+		t.Errorf("location = %+v", loc)
+	}
+	if cd.Time != now.Add(-5*time.Minute).Unix() || cd.Temperature2M != 15 {
+		t.Errorf("current = %+v, want the latest reading, 15 °C", cd)
+	}
+}
@@ -0,0 +1,206 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/tinkershack/meteomunch/archive"
+	e "github.com/tinkershack/meteomunch/errors"
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+// archivedForecast is the body of GET /v1/archive/forecast
+type archivedForecast struct {
+	Provider string    `json:"provider"`
+	Model    string    `json:"model"`
+	IssuedAt time.Time `json:"issued_at"`
+	Data     any       `json:"data"` // *plumber.BaseData, or its projection onto the selected fields
+}
+
+// maxArchiveIssues caps the issues listed by GET /v1/archive/issues, the most recent ones being kept
+const maxArchiveIssues = 500
+
+// archivedIssue is an entry of the body of GET /v1/archive/issues
+type archivedIssue struct {
+	Provider  string    `json:"provider"`
+	Model     string    `json:"model"`
+	Latitude  float64   `json:"latitude"`
+	Longitude float64   `json:"longitude"`
+	IssuedAt  time.Time `json:"issued_at"`
+}
+
+// archiveHandler serves the forecast archive, which only exists with Munch.ForecastStore set
+//
+// Times are taken as RFC 3339 or Unix timestamps.
+type archiveHandler struct {
+	archive *archive.Archive
+	logger  *slog.Logger
+}
+
+// forecast serves GET /v1/archive/forecast?lat=&lon=&provider=&model=&at=&units=&fields=, the forecast as issued at a time
+func (h *archiveHandler) forecast(w http.ResponseWriter, r *http.Request) {
+	coords, err := parseCoordinates(r)
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+	q := r.URL.Query()
+	at, err := parseTime(q, "at", time.Now())
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+	system, err := unitsParam(q)
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+	fields, err := plumber.ParseFields(q.Get("fields"))
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rec, err := h.archive.AsIssued(r.Context(), providerParam(q), q.Get("model"), coords, at)
+	if err != nil {
+		h.writeArchiveError(w, err)
+		return
+	}
+	system.Convert(rec.Data)
+	var data any = rec.Data
+	if fields != nil {
+		if data, err = fields.Project(rec.Data); err != nil {
+			h.logger.Error(e.FAIL, "err", err, "description", "Couldn't project forecast onto fields")
+			writeError(w, h.logger, http.StatusInternalServerError, "couldn't select fields")
+			return
+		}
+	}
+	writeJSON(w, h.logger, http.StatusOK, archivedForecast{
+		Provider: rec.Provider,
+		Model:    rec.Model,
+		IssuedAt: rec.FetchedAt,
+		Data:     data,
+	})
+}
+
+// issues serves GET /v1/archive/issues?lat=&lon=&provider=&model=&from=&to=&limit=, the forecasts issued in a time
+// range, the latest limit of them, maxArchiveIssues at most
+func (h *archiveHandler) issues(w http.ResponseWriter, r *http.Request) {
+	coords, err := parseCoordinates(r)
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+	q := r.URL.Query()
+	from, err := parseTime(q, "from", time.Time{})
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+	to, err := parseTime(q, "to", time.Time{})
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		writeError(w, h.logger, http.StatusBadRequest, "to must not be before from")
+		return
+	}
+	limit := maxArchiveIssues
+	if q.Get("limit") != "" {
+		if limit, err = strconv.Atoi(q.Get("limit")); err != nil || limit < 1 || limit > maxArchiveIssues {
+			writeError(w, h.logger, http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxArchiveIssues))
+			return
+		}
+	}
+
+	keys, err := h.archive.Issues(r.Context(), providerParam(q), q.Get("model"), coords, from, to, limit)
+	if err != nil {
+		h.writeArchiveError(w, err)
+		return
+	}
+	issues := make([]archivedIssue, len(keys))
+	for i, k := range keys {
+		issues[i] = archivedIssue{
+			Provider:  k.Provider,
+			Model:     k.Model,
+			Latitude:  k.Latitude,
+			Longitude: k.Longitude,
+			IssuedAt:  k.FetchedAt,
+		}
+	}
+	writeJSON(w, h.logger, http.StatusOK, map[string][]archivedIssue{"issues": issues})
+}
+
+// diff serves GET /v1/archive/diff?lat=&lon=&provider=&model=&older=&newer=&target=,
+// how the forecast for the target hour changed between the issues current at the older and newer times
+func (h *archiveHandler) diff(w http.ResponseWriter, r *http.Request) {
+	coords, err := parseCoordinates(r)
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+	q := r.URL.Query()
+	var older, newer, target time.Time
+	for _, p := range []struct {
+		name string
+		dst  *time.Time
+	}{{"older", &older}, {"newer", &newer}, {"target", &target}} {
+		if q.Get(p.name) == "" {
+			writeError(w, h.logger, http.StatusBadRequest, p.name+" query parameter is required")
+			return
+		}
+		if *p.dst, err = parseTime(q, p.name, time.Time{}); err != nil {
+			writeError(w, h.logger, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	d, err := h.archive.Diff(r.Context(), providerParam(q), q.Get("model"), coords, older, newer, target)
+	if err != nil {
+		h.writeArchiveError(w, err)
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, d)
+}
+
+func (h *archiveHandler) writeArchiveError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, archive.ErrNoIssue):
+		writeError(w, h.logger, http.StatusNotFound, err.Error())
+	case errors.Is(err, context.Canceled):
+		h.logger.Debug("Archive request cancelled")
+	default:
+		h.logger.Error(e.FAIL, "err", err, "description", "Couldn't read forecast archive")
+		writeError(w, h.logger, http.StatusInternalServerError, "couldn't read forecast archive")
+	}
+}
+
+// providerParam returns the provider query parameter, defaulting to defaultProvider
+func providerParam(q url.Values) string {
+	if name := q.Get("provider"); name != "" {
+		return name
+	}
+	return defaultProvider
+}
+
+// parseTime reads the named query parameter as an RFC 3339 time or Unix timestamp, returning def if it's absent
+func parseTime(q url.Values, name string, def time.Time) (time.Time, error) {
+	v := q.Get(name)
+	if v == "" {
+		return def, nil
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	if unix, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return time.Unix(unix, 0), nil
+	}
+	return time.Time{}, fmt.Errorf("%s must be an RFC 3339 time or Unix timestamp", name)
+}
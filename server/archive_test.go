@@ -0,0 +1,68 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tinkershack/meteomunch/config"
+	"github.com/tinkershack/meteomunch/plumber"
+	"github.com/tinkershack/meteomunch/providers"
+)
+
+func TestArchiveIssues(t *testing.T) {
+	u := &upstream{handler: serveForecast}
+	srv := newUpstreamServer(t, u)
+	cfg := &config.Config{MeteoProviders: []config.MeteoProvider{
+		{Name: "open-meteo", BaseURI: srv, APIPath: "v1/forecast"},
+		{Name: "open-meteo-marine", BaseURI: srv, APIPath: "v1/marine"},
+	}}
+	cfg.Munch.ForecastStore = "bolt"
+	cfg.Munch.ForecastStorePath = filepath.Join(t.TempDir(), "munch.db")
+	f, err := newForecaster(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := newMux(f, nil, discard)
+
+	// Only the forecast is archived, the sea state isn't
+	coords := plumber.NewCoordinates(46.68, 7.86)
+	for _, name := range []string{"open-meteo", "open-meteo-marine"} {
+		if _, err := f.fetch(context.Background(), name, coords, providers.Request{}); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+	}
+	issues := func(query string) (int, []any) {
+		t.Helper()
+		status, body := get(t, mux, "/v1/archive/issues?lat=46.68&lon=7.86&"+query)
+		list, _ := body["issues"].([]any)
+		return status, list
+	}
+	if _, list := issues("provider=open-meteo"); len(list) != 1 {
+		t.Errorf("open-meteo issues = %v, want 1", list)
+	}
+	if _, list := issues("provider=open-meteo-marine"); len(list) != 0 {
+		t.Errorf("open-meteo-marine issues = %v, want none", list)
+	}
+
+	issued := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 3 {
+		if err := f.archive.Record(context.Background(), "open-meteo", "best_match", issued.Add(time.Duration(i)*time.Hour), &plumber.BaseData{Latitude: 46.68, Longitude: 7.86}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	status, list := issues("provider=open-meteo&to=2024-10-02T00:00:00Z&limit=2")
+	if status != http.StatusOK || len(list) != 2 {
+		t.Fatalf("status %d, issues %v, want the latest 2", status, list)
+	}
+	if at := list[0].(map[string]any)["issued_at"]; at != "2024-10-01T02:00:00Z" {
+		t.Errorf("latest issue at %v, want 2024-10-01T02:00:00Z", at)
+	}
+	for _, limit := range []string{"0", "501", "all"} {
+		if status, _ := issues("limit=" + limit); status != http.StatusBadRequest {
+			t.Errorf("limit=%s: status %d, want 400", limit, status)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	e "github.com/tinkershack/meteomunch/errors"
+	"github.com/tinkershack/meteomunch/plumber"
+	"github.com/tinkershack/meteomunch/providers"
+)
+
+const (
+	// defaultClimateProvider is used when the climatology request doesn't name a provider
+	defaultClimateProvider = "open-meteo-historical"
+	// defaultClimateYears is how many past years the climatology covers unless the request picks a range
+	defaultClimateYears = 10
+	// maxClimateYears bounds the years of a climatology, some 260k hours of data
+	maxClimateYears = 30
+)
+
+// climatologyResponse is the body of GET /v1/climatology
+type climatologyResponse struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Elevation float64 `json:"elevation"`
+	Timezone  string  `json:"timezone"`
+	Provider  string  `json:"provider"`
+	StartDate string  `json:"start_date"`
+	EndDate   string  `json:"end_date"`
+	*plumber.Climatology
+}
+
+// climatologyHandler serves GET /v1/climatology?lat=&lon=&provider=&years=&fields=, the climate of the site
+// over past years: monthly and hourly means, percentile bands, flyable days, wind roses and thermal tops
+//
+// The climatology covers the last 10 complete years unless years or start_date and end_date say otherwise.
+// fields selects the hourly fields averaged, plumber.DefaultClimateFields if absent. Months and hours are local
+// to the site unless timezone says otherwise; model and cell_selection are passed on to the provider.
+// Flyability is judged against plumber.ParaglidingLimits, and values are in plumber.CommonUnits.
+type climatologyHandler struct {
+	forecaster *forecaster
+	logger     *slog.Logger
+}
+
+func (h *climatologyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	coords, err := parseCoordinates(r)
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	q := r.URL.Query()
+	name := q.Get("provider")
+	if name == "" {
+		name = defaultClimateProvider
+	}
+	opts, err := optionsParam(q, name)
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+	years := defaultClimateYears
+	if s := q.Get("years"); s != "" {
+		if opts.StartDate != "" {
+			writeError(w, h.logger, http.StatusBadRequest, "years can't be combined with start_date and end_date")
+			return
+		}
+		if years, err = strconv.Atoi(s); err != nil || years < 1 || years > maxClimateYears {
+			writeError(w, h.logger, http.StatusBadRequest, "years must be a whole number between 1 and "+strconv.Itoa(maxClimateYears))
+			return
+		}
+	}
+	if start, end, ok := opts.DateRange(); ok && end.After(start.AddDate(maxClimateYears, 0, -1)) {
+		writeError(w, h.logger, http.StatusBadRequest, "the range can't be longer than "+strconv.Itoa(maxClimateYears)+" years")
+		return
+	}
+	if opts.StartDate == "" {
+		past := providers.PastYears(years)
+		opts.StartDate, opts.EndDate = past.StartDate, past.EndDate
+	}
+	if opts.Timezone == "" {
+		opts.Timezone = "auto"
+	}
+	fields, err := hourlyFieldsParam(q)
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// The flyability needs more than the fields averaged, so the provider is asked for everything
+	bd, err := h.forecaster.fetch(r.Context(), name, coords, providers.Request{Options: opts})
+	if err != nil {
+		writeFetchError(w, h.logger, name, err)
+		return
+	}
+
+	hourly, _ := fields.Block(plumber.BlockHourly)
+	c, err := bd.Climatology(hourly, plumber.ParaglidingLimits)
+	if err != nil {
+		h.logger.Error(e.FAIL, "err", err, "description", "Couldn't compute climatology", "provider", name)
+		writeError(w, h.logger, http.StatusInternalServerError, "couldn't compute climatology")
+		return
+	}
+
+	writeJSON(w, h.logger, http.StatusOK, climatologyResponse{
+		Latitude:    bd.Latitude,
+		Longitude:   bd.Longitude,
+		Elevation:   bd.Elevation,
+		Timezone:    bd.Timezone,
+		Provider:    name,
+		StartDate:   opts.StartDate,
+		EndDate:     opts.EndDate,
+		Climatology: c,
+	})
+}
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestClimatologyHandlerBadRequests(t *testing.T) {
+	mux, u := newTestMux(t, serveForecast)
+	for _, query := range []string{
+		"lat=46.68",
+		"lat=46.68&lon=7.86&years=0",
+		"lat=46.68&lon=7.86&years=31",
+		"lat=46.68&lon=7.86&years=5&start_date=2000-01-01&end_date=2000-12-31",
+		"lat=46.68&lon=7.86&start_date=1991-01-01&end_date=2021-01-01",
+		"lat=46.68&lon=7.86&start_date=1960-01-01&end_date=2020-12-31",
+		"lat=46.68&lon=7.86&fields=daily.sunrise",
+	} {
+		if status, body := get(t, mux, "/v1/climatology?"+query); status != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400: %v", query, status, body)
+		}
+	}
+	if len(u.queries) != 0 {
+		t.Error("bad request reached upstream")
+	}
+
+	// Thirty years are fine, and fail on the provider missing from the config
+	if status, body := get(t, mux, "/v1/climatology?lat=46.68&lon=7.86&start_date=1991-01-01&end_date=2020-12-31"); status != http.StatusNotFound {
+		t.Errorf("status %d, want 404: %v", status, body)
+	}
+}
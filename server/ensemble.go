@@ -0,0 +1,163 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	e "github.com/tinkershack/meteomunch/errors"
+	"github.com/tinkershack/meteomunch/plumber"
+	"github.com/tinkershack/meteomunch/providers"
+)
+
+// defaultEnsembleProvider is used when the ensemble request doesn't name a provider
+const defaultEnsembleProvider = "open-meteo-ensemble"
+
+// errNoEnsemble marks providers that don't forecast ensembles
+var errNoEnsemble = errors.New("provider has no ensemble forecast")
+
+// ensembleResponse is the body of GET /v1/forecast/ensemble
+type ensembleResponse struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Elevation float64 `json:"elevation"`
+	Provider  string  `json:"provider"`
+	Model     string  `json:"model"`
+	*plumber.EnsembleStats
+	Ensemble *plumber.Ensemble `json:"ensemble,omitempty"` // Every member, if asked for
+}
+
+// ensemble fetches every member of the named provider's ensemble for coords
+//
+// Ensembles go straight to upstream, bypassing the cache and archive, which hold single forecasts.
+// The error wraps errNoEnsemble if the provider isn't a providers.EnsembleFetcher, or what fetch's errors wrap.
+func (f *forecaster) ensemble(ctx context.Context, name string, coords *plumber.Coordinates, req providers.Request) (*plumber.Ensemble, error) {
+	p, err := f.providers.Upstream(name)
+	if err != nil {
+		return nil, err
+	}
+	ef, ok := p.(providers.EnsembleFetcher)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errNoEnsemble, name)
+	}
+
+	if timeout := f.timeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	ens, err := ef.FetchEnsemble(ctx, coords, req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", errUpstream, name, err)
+	}
+	return ens, nil
+}
+
+// ensembleHandler serves GET /v1/forecast/ensemble?lat=&lon=&provider=&fields=&percentiles=10,50,90&exceed=wind_speed_10m:25&members=,
+// the percentiles of the hourly fields across the ensemble members and the probabilities of exceeding the thresholds
+//
+// fields selects the hourly fields, plumber.DefaultEnsembleFields if absent, and exceed the thresholds in plumber.CommonUnits,
+// plumber.DefaultThresholds if absent, whose fields are fetched along with the selected ones. members=true adds every member to the response. model, days, hours, past_days,
+// cell_selection and timezone override the provider's configured options as for /v1/forecast.
+type ensembleHandler struct {
+	forecaster *forecaster
+	logger     *slog.Logger
+}
+
+func (h *ensembleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	coords, err := parseCoordinates(r)
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	q := r.URL.Query()
+	name := q.Get("provider")
+	if name == "" {
+		name = defaultEnsembleProvider
+	}
+	opts, err := optionsParam(q, name)
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	fields, err := hourlyFieldsParam(q)
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+	hourly, _ := fields.Block(plumber.BlockHourly)
+
+	var percentiles []float64
+	if s := q.Get("percentiles"); s != "" {
+		for _, item := range strings.Split(s, ",") {
+			p, err := strconv.ParseFloat(strings.TrimSpace(item), 64)
+			if err != nil || p < 0 || p > 100 {
+				writeError(w, h.logger, http.StatusBadRequest, "percentiles must be numbers between 0 and 100")
+				return
+			}
+			percentiles = append(percentiles, p)
+		}
+	}
+
+	var thresholds []plumber.Threshold
+	if s := q.Get("exceed"); s != "" {
+		thresholds = []plumber.Threshold{}
+		for _, item := range strings.Split(s, ",") {
+			t, err := plumber.ParseThreshold(strings.TrimSpace(item))
+			if err != nil {
+				writeError(w, h.logger, http.StatusBadRequest, err.Error())
+				return
+			}
+			thresholds = append(thresholds, t)
+		}
+	}
+	if thresholds == nil {
+		thresholds = plumber.DefaultThresholds
+	}
+	if fields != nil {
+		// Thresholds can only be checked on fields that are fetched, the default ones too
+		for _, t := range thresholds {
+			if !slices.Contains(fields[plumber.BlockHourly], t.Field) {
+				fields[plumber.BlockHourly] = append(fields[plumber.BlockHourly], t.Field)
+			}
+		}
+	}
+
+	ens, err := h.forecaster.ensemble(r.Context(), name, coords, providers.Request{Options: opts, Fields: fields})
+	if errors.Is(err, errNoEnsemble) {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		writeFetchError(w, h.logger, name, err)
+		return
+	}
+
+	stats, err := ens.Stats(hourly, percentiles, thresholds)
+	if err != nil {
+		h.logger.Error(e.FAIL, "err", err, "description", "Couldn't compute ensemble statistics", "provider", name)
+		writeError(w, h.logger, http.StatusInternalServerError, "couldn't compute ensemble statistics")
+		return
+	}
+
+	resp := ensembleResponse{
+		Latitude:      ens.Latitude,
+		Longitude:     ens.Longitude,
+		Elevation:     ens.Elevation,
+		Provider:      name,
+		Model:         ens.Model,
+		EnsembleStats: stats,
+	}
+	if members, _ := strconv.ParseBool(q.Get("members")); members {
+		resp.Ensemble = ens
+	}
+	writeJSON(w, h.logger, http.StatusOK, resp)
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/tinkershack/meteomunch/config"
+)
+
+// openMeteoEnsemble is an ensemble response of the control run and two members over two hours
+const openMeteoEnsemble = `{
+	"latitude": 46.68, "longitude": 7.86, "elevation": 560,
+	"hourly": {"time": [1720000800, 1720004400],
+		"temperature_2m": [20, 22], "temperature_2m_member01": [21, null], "temperature_2m_member02": [19, 23],
+		"wind_speed_10m": [10, 30], "wind_speed_10m_member01": [20, 40], "wind_speed_10m_member02": [30, null]}
+}`
+
+func TestEnsembleHandler(t *testing.T) {
+	u := &upstream{handler: func(w http.ResponseWriter, r *http.Request) { io.WriteString(w, openMeteoEnsemble) }}
+	cfg := &config.Config{MeteoProviders: []config.MeteoProvider{
+		{Name: "open-meteo-ensemble", BaseURI: newUpstreamServer(t, u), APIPath: "v1/ensemble"},
+	}}
+	f, err := newForecaster(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := newMux(f, nil, discard)
+
+	tests := []struct {
+		name       string
+		query      string
+		hourly     []string // Variables asked of upstream
+		thresholds []string // Fields of the probabilities
+	}{
+		{"default thresholds", "fields=hourly.temperature_2m", []string{"temperature_2m", "wind_speed_10m", "wind_gusts_10m", "precipitation"},
+			[]string{"wind_speed_10m"}},
+		{"thresholds asked for", "fields=hourly.temperature_2m&exceed=wind_speed_10m:25", []string{"temperature_2m", "wind_speed_10m"},
+			[]string{"wind_speed_10m"}},
+		{"threshold on a selected field", "fields=hourly.temperature_2m&exceed=temperature_2m:21", []string{"temperature_2m"},
+			[]string{"temperature_2m"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := get(t, mux, "/v1/forecast/ensemble?lat=46.68&lon=7.86&"+tt.query)
+			if status != http.StatusOK {
+				t.Fatalf("status %d: %v", status, body)
+			}
+			if q := u.lastQuery().Get("hourly"); !sameSet(strings.Split(q, ","), tt.hourly) {
+				t.Errorf("upstream asked for %q, want %v", q, tt.hourly)
+			}
+			var fields []string
+			for _, p := range body["probabilities"].([]any) {
+				fields = append(fields, p.(map[string]any)["field"].(string))
+			}
+			if !sameSet(fields, tt.thresholds) {
+				t.Errorf("probabilities of %v, want %v", fields, tt.thresholds)
+			}
+			percentiles := body["percentiles"].(map[string]any)
+			if _, ok := percentiles["temperature_2m"]; !ok || len(percentiles) != 1 {
+				t.Errorf("percentiles of %v, want temperature_2m alone", percentiles)
+			}
+		})
+	}
+
+	status, body := get(t, mux, "/v1/forecast/ensemble?lat=46.68&lon=7.86&members=true")
+	if status != http.StatusOK {
+		t.Fatalf("status %d: %v", status, body)
+	}
+	members := body["ensemble"].(map[string]any)["members"].([]any)
+	if wind := members[1].(map[string]any)["wind_speed_10m"].([]any); len(wind) != 2 || wind[1] != nil {
+		t.Errorf("wind speed of member 2 = %v, want null at the second hour", wind)
+	}
+}
+
+// sameSet reports whether a and b hold the same strings, in any order
+func sameSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := make(map[string]int)
+	for _, s := range a {
+		seen[s]++
+	}
+	for _, s := range b {
+		if seen[s]--; seen[s] < 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,392 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/tinkershack/meteomunch/archive"
+	"github.com/tinkershack/meteomunch/cache"
+	"github.com/tinkershack/meteomunch/config"
+	"github.com/tinkershack/meteomunch/dlm"
+	e "github.com/tinkershack/meteomunch/errors"
+	"github.com/tinkershack/meteomunch/plumber"
+	"github.com/tinkershack/meteomunch/providers"
+	"github.com/tinkershack/meteomunch/store"
+	"github.com/tinkershack/meteomunch/units"
+)
+
+// defaultProvider is used when the forecast request doesn't name a provider
+const defaultProvider = "open-meteo"
+
+// airQualityProvider provides the air quality block of forecasts
+const airQualityProvider = "open-meteo-air-quality"
+
+// errUpstream marks failures of the provider's upstream API, as opposed to bad requests
+var errUpstream = errors.New("upstream provider failed")
+
+// forecaster fetches forecasts from the providers shared by the HTTP and gRPC transports
+type forecaster struct {
+	providers *providers.Registry
+	timeout   time.Duration    // Munch.Server.FetchTimeout
+	archive   *archive.Archive // nil unless Munch.ForecastStore is set
+}
+
+// newForecaster builds the providers once so that every request reuses them
+//
+// With Munch.ForecastStore set, every upstream fetch of a forecast provider, see providers.Forecasts, is archived
+// in that store.
+// With Munch.DocumentStore set, the providers are put behind a cache in that store,
+// and with Munch.LockManager set as well, replicas take turns filling it. Munch.MemoryCache
+// puts an in-process cache in front of everything else.
+func newForecaster(ctx context.Context, cfg *config.Config) (*forecaster, error) {
+	reg, err := providers.NewRegistry(cfg)
+	if err != nil {
+		return nil, err
+	}
+	f := &forecaster{providers: reg, timeout: cfg.Munch.Server.FetchTimeout}
+
+	fs, err := store.FromConfig(ctx, cfg)
+	switch {
+	case errors.Is(err, store.ErrNotConfigured):
+	case err != nil:
+		return nil, err
+	default:
+		f.archive = archive.New(fs)
+		reg.Wrap(func(name string, p providers.Provider) providers.Provider {
+			if !providers.Forecasts(name) {
+				return p
+			}
+			return archive.NewProvider(name, providers.ConfiguredModel(name, cfg), p, f.archive)
+		})
+	}
+
+	switch cfg.Munch.DocumentStore {
+	case "":
+	case "mongo":
+		shared, err := cache.NewMongoStore(ctx, cfg.Mongo)
+		if err != nil {
+			return nil, err
+		}
+
+		var locks *dlm.Manager
+		switch cfg.Munch.LockManager {
+		case "":
+		case "redis":
+			locks, err = dlm.NewFromConfig(ctx, cfg.DLMRedis)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unsupported lock manager: %s", cfg.Munch.LockManager)
+		}
+
+		reg.Wrap(func(name string, p providers.Provider) providers.Provider {
+			opts := cache.OptionsFor(name, cfg)
+			opts.Locks = locks
+			return cache.NewProvider(name, p, shared, opts)
+		})
+	default:
+		return nil, fmt.Errorf("unsupported document store: %s", cfg.Munch.DocumentStore)
+	}
+
+	if size := cfg.Munch.MemoryCache; size > 0 {
+		mem := cache.NewMemoryStore(size)
+		reg.Wrap(func(name string, p providers.Provider) providers.Provider {
+			return cache.NewProvider(name, p, mem, cache.OptionsFor(name, cfg))
+		})
+	}
+
+	return f, nil
+}
+
+// fetch fetches the forecast for coords from the named provider as asked in the request
+//
+// Errors wrap providers.ErrUnknownProvider, providers.ErrProviderNotConfigured, providers.ErrInvalidOptions
+// or errUpstream so that each transport can map them to its own status codes.
+// The upstream call is bound to ctx, further limited by Munch.Server.FetchTimeout.
+func (f *forecaster) fetch(ctx context.Context, name string, coords *plumber.Coordinates, req providers.Request) (*plumber.BaseData, error) {
+	if name == "" {
+		name = defaultProvider
+	}
+
+	p, err := f.providers.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if timeout := f.timeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	bd, err := p.FetchData(ctx, coords, req)
+	if errors.Is(err, providers.ErrInvalidOptions) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", errUpstream, name, err)
+	}
+	return bd, nil
+}
+
+// forecast fetches the forecast as fetch does, along with the air quality of the same hours from
+// open-meteo-air-quality if airQuality is set or req selects from the air quality block
+//
+// It returns the selection to project the forecast onto, which keeps the air quality block, see
+// providers.AirQualityRequest. Errors are those of fetch.
+func (f *forecaster) forecast(ctx context.Context, name string, coords *plumber.Coordinates, req providers.Request, airQuality bool) (*plumber.BaseData, plumber.Fields, error) {
+	req, air, withAirQuality := providers.AirQualityRequest(req, airQuality)
+	bd, err := f.fetch(ctx, name, coords, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if withAirQuality {
+		aq, err := f.fetch(ctx, airQualityProvider, coords, air)
+		if err != nil {
+			return nil, nil, err
+		}
+		bd.SetAirQuality(aq.AirQuality)
+	}
+	return bd, req.Fields, nil
+}
+
+// consensus fetches coords from every configured provider as asked in the request and merges them with the given method
+//
+// Failures of individual providers are reported within the consensus; the error wraps errUpstream only when all of them failed.
+func (f *forecaster) consensus(ctx context.Context, method plumber.MergeMethod, coords *plumber.Coordinates, req providers.Request) (*plumber.Consensus, error) {
+	m, err := plumber.NewMerger(method, f.providers.Sources(req)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if timeout := f.timeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	c, err := m.Merge(ctx, coords)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errUpstream, err)
+	}
+	return c, nil
+}
+
+// isNotFound reports whether err was caused by a provider that munch can't serve
+func isNotFound(err error) bool {
+	return errors.Is(err, providers.ErrUnknownProvider) || errors.Is(err, providers.ErrProviderNotConfigured)
+}
+
+// forecastHandler serves GET /v1/forecast?lat=&lon=&provider=&units=&fields=&air_quality=
+//
+// air_quality=true, or fields selecting from the air_quality block, adds the air quality of the same hours.
+// model, days, hours, past_days, cell_selection and timezone override the provider's configured options;
+// start_date and end_date pick a range of days instead, e.g. of open-meteo-historical.
+type forecastHandler struct {
+	forecaster *forecaster
+	logger     *slog.Logger
+}
+
+func (h *forecastHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	coords, err := parseCoordinates(r)
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	system, err := unitsParam(r.URL.Query())
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+	fields, err := plumber.ParseFields(r.URL.Query().Get("fields"))
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	name := r.URL.Query().Get("provider")
+	opts, err := optionsParam(r.URL.Query(), name)
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	airQuality, _ := strconv.ParseBool(r.URL.Query().Get("air_quality"))
+	bd, fields, err := h.forecaster.forecast(r.Context(), name, coords, providers.Request{Options: opts, Fields: fields}, airQuality)
+	if err != nil {
+		writeFetchError(w, h.logger, name, err)
+		return
+	}
+
+	system.Convert(bd)
+	writeProjection(w, h.logger, fields, bd)
+	h.logger.Debug("API Data fetched", "data", bd, "provider", name)
+}
+
+// writeFetchError maps an error returned by forecaster.fetch or forecaster.consensus to the HTTP response
+//
+// name is the provider the error came from, or "consensus", for the logs.
+func writeFetchError(w http.ResponseWriter, logger *slog.Logger, name string, err error) {
+	switch {
+	case isNotFound(err):
+		writeError(w, logger, http.StatusNotFound, err.Error())
+	case errors.Is(err, context.Canceled):
+		// The client has gone away, there's no one left to respond to
+		logger.Debug("Forecast request cancelled", "provider", name)
+	case errors.Is(err, context.DeadlineExceeded):
+		logger.Error(e.FAIL, "err", err, "description", "Provider timed out", "provider", name)
+		writeError(w, logger, http.StatusGatewayTimeout, "upstream provider timed out")
+	case errors.Is(err, providers.ErrInvalidOptions):
+		// Options the provider could only check against its own config or the date, e.g. a range of days not yet published
+		writeError(w, logger, http.StatusBadRequest, err.Error())
+	case errors.Is(err, errUpstream):
+		logger.Error(e.FAIL, "err", err, "description", "Couldn't fetch data from provider", "provider", name)
+		writeError(w, logger, http.StatusBadGateway, "upstream provider failed")
+	default:
+		logger.Error(e.FAIL, "err", err, "description", "Couldn't get forecast", "provider", name)
+		writeError(w, logger, http.StatusInternalServerError, "couldn't get forecast")
+	}
+}
+
+// writeProjection writes v, a forecast or consensus, as JSON with only the selected fields if there's a selection
+func writeProjection(w http.ResponseWriter, logger *slog.Logger, fields plumber.Fields, v any) {
+	if fields == nil {
+		writeJSON(w, logger, http.StatusOK, v)
+		return
+	}
+	out, err := fields.Project(v)
+	if err != nil {
+		logger.Error(e.FAIL, "err", err, "description", "Couldn't project forecast onto fields")
+		writeError(w, logger, http.StatusInternalServerError, "couldn't select fields")
+		return
+	}
+	writeJSON(w, logger, http.StatusOK, out)
+}
+
+// optionsParam returns the fetch options in the query, validated against the limits of the named provider
+func optionsParam(q url.Values, provider string) (providers.Options, error) {
+	var o providers.Options
+	for _, p := range []struct {
+		name string
+		dst  *int
+	}{{"days", &o.ForecastDays}, {"hours", &o.ForecastHours}, {"past_days", &o.PastDays}} {
+		if q.Get(p.name) == "" {
+			continue
+		}
+		v, err := strconv.Atoi(q.Get(p.name))
+		if err != nil {
+			return o, fmt.Errorf("%s must be a whole number", p.name)
+		}
+		*p.dst = v
+	}
+	o.Model = q.Get("model")
+	o.CellSelection = q.Get("cell_selection")
+	o.Timezone = q.Get("timezone")
+	o.StartDate = q.Get("start_date")
+	o.EndDate = q.Get("end_date")
+
+	if provider == "" {
+		provider = defaultProvider
+	}
+	limits, err := providers.LimitsOf(provider)
+	if err != nil {
+		return o, nil // fetch reports the unknown provider
+	}
+	return o, o.Validate(limits)
+}
+
+// hourlyFieldsParam returns the selection in the fields query parameter, which may only name hourly fields
+func hourlyFieldsParam(q url.Values) (plumber.Fields, error) {
+	fields, err := plumber.ParseFields(q.Get("fields"))
+	if err != nil {
+		return nil, err
+	}
+	hourly, all := fields.Block(plumber.BlockHourly)
+	if fields != nil && (all || len(hourly) == 0 || len(fields) > 1) {
+		return nil, errors.New("fields must name hourly fields, e.g. hourly.wind_speed_10m")
+	}
+	return fields, nil
+}
+
+// unitsParam returns the unit system named by the units query parameter, metric if it's absent
+func unitsParam(q url.Values) (units.System, error) {
+	s, err := units.Lookup(q.Get("units"))
+	if err != nil {
+		return units.System{}, errors.New("units must be metric, imperial, si or aviation")
+	}
+	return s, nil
+}
+
+// parseCoordinates reads and validates the lat and lon query parameters
+func parseCoordinates(r *http.Request) (*plumber.Coordinates, error) {
+	q := r.URL.Query()
+	if q.Get("lat") == "" || q.Get("lon") == "" {
+		return nil, errors.New("lat and lon query parameters are required")
+	}
+
+	lat, err := strconv.ParseFloat(q.Get("lat"), 64)
+	if err != nil {
+		return nil, errors.New("lat must be a decimal number")
+	}
+	lon, err := strconv.ParseFloat(q.Get("lon"), 64)
+	if err != nil {
+		return nil, errors.New("lon must be a decimal number")
+	}
+
+	coords := plumber.NewCoordinates(lat, lon)
+	if err := coords.Validate(); err != nil {
+		return nil, err
+	}
+	return coords, nil
+}
+
+// consensusHandler serves GET /v1/forecast/consensus?lat=&lon=&method=&units=&fields=
+type consensusHandler struct {
+	forecaster *forecaster
+	logger     *slog.Logger
+}
+
+func (h *consensusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	coords, err := parseCoordinates(r)
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	method := plumber.MergeWeighted
+	if m := r.URL.Query().Get("method"); m != "" {
+		method = plumber.MergeMethod(m)
+	}
+	if err := method.Validate(); err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+	system, err := unitsParam(r.URL.Query())
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+	fields, err := plumber.ParseFields(r.URL.Query().Get("fields"))
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c, err := h.forecaster.consensus(r.Context(), method, coords, providers.Request{Fields: fields})
+	if err != nil {
+		writeFetchError(w, h.logger, "consensus", err)
+		return
+	}
+
+	system.ConvertConsensus(c)
+	writeProjection(w, h.logger, fields, c)
+	h.logger.Debug("Consensus computed", "providers", c.Providers, "method", c.Method)
+}
@@ -0,0 +1,242 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tinkershack/meteomunch/config"
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+// openMeteoForecast is an open-meteo response of two hours
+const openMeteoForecast = `{
+	"latitude": 46.68, "longitude": 7.86, "elevation": 560,
+	"current": {"time": 1720000800, "interval": 900, "temperature_2m": 20, "wind_speed_10m": 10},
+	"hourly": {"time": [1720000800, 1720004400], "temperature_2m": [20, 22], "wind_speed_10m": [10, 20]}
+}`
+
+// upstream stands in for open-meteo, answering every request with handler and recording its query
+type upstream struct {
+	mu      sync.Mutex
+	queries []url.Values
+	handler http.HandlerFunc
+}
+
+func (u *upstream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u.mu.Lock()
+	u.queries = append(u.queries, r.URL.Query())
+	u.mu.Unlock()
+	u.handler(w, r)
+}
+
+// lastQuery returns the query of the latest upstream request, nil if there was none
+func (u *upstream) lastQuery() url.Values {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if len(u.queries) == 0 {
+		return nil
+	}
+	return u.queries[len(u.queries)-1]
+}
+
+// newUpstreamServer serves u for the duration of the test and returns its URL
+func newUpstreamServer(t *testing.T, u *upstream) string {
+	t.Helper()
+	srv := httptest.NewServer(u)
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+// newTestForecaster returns a forecaster with open-meteo, the only provider configured, answered by handler
+func newTestForecaster(t *testing.T, handler http.HandlerFunc) (*forecaster, *upstream) {
+	t.Helper()
+	u := &upstream{handler: handler}
+	cfg := &config.Config{MeteoProviders: []config.MeteoProvider{
+		{Name: "open-meteo", BaseURI: newUpstreamServer(t, u), APIPath: "v1/forecast"},
+	}}
+	cfg.Munch.Server.FetchTimeout = 200 * time.Millisecond
+	f, err := newForecaster(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f, u
+}
+
+// newTestMux serves the routes of munch with open-meteo, the only provider configured, answered by handler
+func newTestMux(t *testing.T, handler http.HandlerFunc) (*http.ServeMux, *upstream) {
+	t.Helper()
+	f, u := newTestForecaster(t, handler)
+	return newMux(f, nil, discard), u
+}
+
+var discard = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func serveForecast(w http.ResponseWriter, r *http.Request) {
+	io.WriteString(w, openMeteoForecast)
+}
+
+// get serves the request to target and decodes the JSON response
+func get(t *testing.T, mux http.Handler, target string) (int, map[string]any) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("GET %s: %v in %q", target, err, rec.Body.String())
+	}
+	return rec.Code, body
+}
+
+func TestForecastHandler(t *testing.T) {
+	mux, u := newTestMux(t, serveForecast)
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+		check  func(t *testing.T, body map[string]any)
+	}{
+		{"forecast", "lat=46.68&lon=7.86", http.StatusOK, func(t *testing.T, body map[string]any) {
+			if temp := body["current"].(map[string]any)["temperature_2m"]; temp != 20.0 {
+				t.Errorf("temperature = %v, want 20", temp)
+			}
+		}},
+		{"imperial", "lat=46.68&lon=7.86&units=imperial", http.StatusOK, func(t *testing.T, body map[string]any) {
+			if temp := body["current"].(map[string]any)["temperature_2m"]; temp != 68.0 {
+				t.Errorf("temperature = %v, want 68", temp)
+			}
+			if unit := body["units"].(map[string]any)["temperature"]; unit != "°F" {
+				t.Errorf("temperature unit = %v, want °F", unit)
+			}
+		}},
+		{"fields", "lat=46.68&lon=7.86&fields=hourly.temperature_2m", http.StatusOK, func(t *testing.T, body map[string]any) {
+			hourly := body["hourly"].(map[string]any)
+			if _, ok := hourly["wind_speed_10m"]; ok {
+				t.Error("unselected hourly field in the response")
+			}
+			if len(hourly["time"].([]any)) != 2 || len(hourly["temperature_2m"].([]any)) != 2 {
+				t.Errorf("hourly = %v, want time and temperature_2m", hourly)
+			}
+			if _, ok := body["current"]; ok {
+				t.Error("unselected block in the response")
+			}
+			if q := u.lastQuery(); q.Get("hourly") != "temperature_2m" || q.Has("current") {
+				t.Errorf("upstream asked for current %q, hourly %q, want hourly temperature_2m alone", q.Get("current"), q.Get("hourly"))
+			}
+		}},
+		{"options", "lat=46.68&lon=7.86&days=3&model=icon_seamless&timezone=auto", http.StatusOK, func(t *testing.T, body map[string]any) {
+			if q := u.lastQuery(); q.Get("forecast_days") != "3" || q.Has("forecast_hours") || q.Get("models") != "icon_seamless" || q.Get("timezone") != "auto" {
+				t.Errorf("upstream query = %v", q)
+			}
+		}},
+		{"no coordinates", "", http.StatusBadRequest, nil},
+		{"no longitude", "lat=46.68", http.StatusBadRequest, nil},
+		{"latitude not a number", "lat=north&lon=7.86", http.StatusBadRequest, nil},
+		{"latitude out of range", "lat=200&lon=7.86", http.StatusBadRequest, nil},
+		{"longitude out of range", "lat=46.68&lon=-181", http.StatusBadRequest, nil},
+		{"unknown units", "lat=46.68&lon=7.86&units=furlongs", http.StatusBadRequest, nil},
+		{"unknown field", "lat=46.68&lon=7.86&fields=hourly.nope", http.StatusBadRequest, nil},
+		{"days not a number", "lat=46.68&lon=7.86&days=three", http.StatusBadRequest, nil},
+		{"days beyond the limit", "lat=46.68&lon=7.86&days=17", http.StatusBadRequest, nil},
+		{"unknown provider", "lat=46.68&lon=7.86&provider=nope", http.StatusNotFound, nil},
+		{"provider not configured", "lat=46.68&lon=7.86&provider=meteoblue", http.StatusNotFound, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := len(u.queries)
+			status, body := get(t, mux, "/v1/forecast?"+tt.query)
+			if status != tt.status {
+				t.Fatalf("status %d, want %d: %v", status, tt.status, body)
+			}
+			if tt.status != http.StatusOK {
+				if e, ok := body["error"].(map[string]any); !ok || e["code"] != float64(tt.status) || e["message"] == "" {
+					t.Errorf("error body = %v", body)
+				}
+				if len(u.queries) != before {
+					t.Error("bad request reached upstream")
+				}
+				return
+			}
+			tt.check(t, body)
+		})
+	}
+}
+
+func TestForecastHandlerUpstreamErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		upstream http.HandlerFunc
+		status   int
+	}{
+		{"upstream error", func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "bad request", http.StatusBadRequest)
+		}, http.StatusBadGateway},
+		{"malformed response", func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, `{"hourly": {"time": "soon"}}`)
+		}, http.StatusBadGateway},
+		{"timeout", func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}, http.StatusGatewayTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, _ := newTestMux(t, tt.upstream)
+			for _, path := range []string{"/v1/forecast", "/v1/forecast/consensus"} {
+				if status, body := get(t, mux, path+"?lat=46.68&lon=7.86"); status != tt.status {
+					t.Errorf("%s: status %d, want %d: %v", path, status, tt.status, body)
+				}
+			}
+		})
+	}
+}
+
+func TestForecastHandlerCancelled(t *testing.T) {
+	mux, _ := newTestMux(t, func(w http.ResponseWriter, r *http.Request) { <-r.Context().Done() })
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/forecast?lat=46.68&lon=7.86", nil).WithContext(ctx))
+	if rec.Body.Len() != 0 {
+		t.Errorf("responded %d %q to a client that has gone away", rec.Code, rec.Body.String())
+	}
+}
+
+func TestConsensusHandler(t *testing.T) {
+	mux, _ := newTestMux(t, serveForecast)
+
+	status, body := get(t, mux, "/v1/forecast/consensus?lat=46.68&lon=7.86&method=median&fields=hourly.wind_speed_10m")
+	if status != http.StatusOK {
+		t.Fatalf("status %d: %v", status, body)
+	}
+	var c struct {
+		plumber.Consensus
+		Current any `json:"current"`
+	}
+	raw, _ := json.Marshal(body)
+	if err := json.Unmarshal(raw, &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Method != plumber.MergeMedian || len(c.Providers) != 1 || c.Providers[0] != "open-meteo" {
+		t.Errorf("method %s, providers %v, want median of open-meteo", c.Method, c.Providers)
+	}
+	if len(c.Hourly.WindSpeed10M) != 2 || c.Hourly.WindSpeed10M[1] != 20 || len(c.Hourly.Temperature2M) != 0 || c.Current != nil {
+		t.Errorf("hourly = %+v, current = %v, want the wind alone", c.Hourly, c.Current)
+	}
+	if spread := c.Spread.Hourly["wind_speed_10m"]; len(spread) != 2 || spread[0] != 0 {
+		t.Errorf("wind spread = %v, want 0 for a single provider", spread)
+	}
+
+	for _, query := range []string{"lat=46.68", "lat=46.68&lon=7.86&method=mode", "lat=46.68&lon=7.86&units=furlongs", "lat=46.68&lon=7.86&fields=nope"} {
+		if status, body := get(t, mux, "/v1/forecast/consensus?"+query); status != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400: %v", query, status, body)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+
+	e "github.com/tinkershack/meteomunch/errors"
+	"github.com/tinkershack/meteomunch/munchpb"
+	"github.com/tinkershack/meteomunch/plumber"
+	"github.com/tinkershack/meteomunch/providers"
+	"github.com/tinkershack/meteomunch/units"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxStreamLocations caps the number of locations a single StreamForecasts call can ask for
+const maxStreamLocations = 64
+
+// forecastService implements munchpb.ForecastServer
+type forecastService struct {
+	munchpb.UnimplementedForecastServer
+
+	forecaster *forecaster
+	logger     *slog.Logger
+}
+
+// newGRPCServer returns a gRPC server with the Forecast service registered
+func newGRPCServer(f *forecaster, logger *slog.Logger) *grpc.Server {
+	s := grpc.NewServer()
+	munchpb.RegisterForecastServer(s, &forecastService{forecaster: f, logger: logger})
+	return s
+}
+
+func (s *forecastService) GetForecast(ctx context.Context, req *munchpb.ForecastRequest) (*munchpb.ForecastResponse, error) {
+	coords, err := fromProtoCoordinates(req.GetCoordinates())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	q, err := parseQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	return s.forecast(ctx, coords, q)
+}
+
+func (s *forecastService) StreamForecasts(req *munchpb.StreamForecastsRequest, stream grpc.ServerStreamingServer[munchpb.ForecastResponse]) error {
+	if len(req.GetCoordinates()) == 0 {
+		return status.Error(codes.InvalidArgument, "at least one coordinate is required")
+	}
+	if len(req.GetCoordinates()) > maxStreamLocations {
+		return status.Errorf(codes.InvalidArgument, "at most %d coordinates are allowed", maxStreamLocations)
+	}
+
+	coords := make([]*plumber.Coordinates, len(req.GetCoordinates()))
+	for i, c := range req.GetCoordinates() {
+		var err error
+		if coords[i], err = fromProtoCoordinates(c); err != nil {
+			return status.Errorf(codes.InvalidArgument, "coordinates[%d]: %s", i, err)
+		}
+	}
+	q, err := parseQuery(req)
+	if err != nil {
+		return err
+	}
+
+	type result struct {
+		resp *munchpb.ForecastResponse
+		err  error
+	}
+	// Returning early cancels the fetches still in flight
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	results := make(chan result, len(coords))
+	for _, c := range coords {
+		go func() {
+			resp, err := s.forecast(ctx, c, q)
+			results <- result{resp: resp, err: err}
+		}()
+	}
+
+	// Responses are sent in the order the fetches complete; the first failure ends the stream
+	for range coords {
+		select {
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case r := <-results:
+			if r.err != nil {
+				return r.err
+			}
+			if err := stream.Send(r.resp); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// forecastParams are the parameters ForecastRequest and StreamForecastsRequest have in common
+type forecastParams interface {
+	GetProvider() string
+	GetUnits() string
+	GetFields() []string
+	GetOptions() *munchpb.FetchOptions
+	GetAirQuality() bool
+}
+
+// query is a forecast request, parsed and validated as the HTTP handler does its query parameters
+type query struct {
+	provider   string
+	system     units.System
+	req        providers.Request
+	airQuality bool
+}
+
+// parseQuery validates the parameters of a request, returning an InvalidArgument status if they're wrong
+func parseQuery(p forecastParams) (query, error) {
+	q := query{provider: p.GetProvider(), airQuality: p.GetAirQuality()}
+	if q.provider == "" {
+		q.provider = defaultProvider
+	}
+
+	var err error
+	if q.system, err = units.Lookup(p.GetUnits()); err != nil {
+		return q, status.Error(codes.InvalidArgument, "units must be metric, imperial, si or aviation")
+	}
+	if q.req.Fields, err = plumber.ParseFields(strings.Join(p.GetFields(), ",")); err != nil {
+		return q, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	o := p.GetOptions()
+	q.req.Options = providers.Options{
+		Model:         o.GetModel(),
+		ForecastDays:  int(o.GetForecastDays()),
+		ForecastHours: int(o.GetForecastHours()),
+		PastDays:      int(o.GetPastDays()),
+		CellSelection: o.GetCellSelection(),
+		Timezone:      o.GetTimezone(),
+		StartDate:     o.GetStartDate(),
+		EndDate:       o.GetEndDate(),
+	}
+	if limits, err := providers.LimitsOf(q.provider); err == nil { // fetch reports the unknown provider
+		if err := q.req.Options.Validate(limits); err != nil {
+			return q, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	return q, nil
+}
+
+// forecast fetches the forecast along the same path as the HTTP handler and maps failures onto gRPC status codes
+func (s *forecastService) forecast(ctx context.Context, coords *plumber.Coordinates, q query) (*munchpb.ForecastResponse, error) {
+	bd, fields, err := s.forecaster.forecast(ctx, q.provider, coords, q.req, q.airQuality)
+	switch {
+	case err == nil:
+	case isNotFound(err):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return nil, status.FromContextError(err).Err()
+	case errors.Is(err, providers.ErrInvalidOptions):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, errUpstream):
+		s.logger.Error(e.FAIL, "err", err, "description", "Couldn't fetch data from provider", "provider", q.provider)
+		return nil, status.Error(codes.Unavailable, "upstream provider failed")
+	default:
+		s.logger.Error(e.FAIL, "err", err, "description", "Couldn't get forecast", "provider", q.provider)
+		return nil, status.Error(codes.Internal, "couldn't get forecast")
+	}
+
+	q.system.Convert(bd)
+	if bd, err = project(fields, bd); err != nil {
+		s.logger.Error(e.FAIL, "err", err, "description", "Couldn't project forecast onto fields")
+		return nil, status.Error(codes.Internal, "couldn't get forecast")
+	}
+
+	return &munchpb.ForecastResponse{
+		Coordinates: &munchpb.Coordinates{Latitude: coords.Latitude, Longitude: coords.Longitude},
+		Provider:    q.provider,
+		Data:        munchpb.FromBaseData(bd),
+	}, nil
+}
+
+// project returns a copy of bd holding only the selected fields, as the HTTP handler writes it; bd if all are selected
+func project(fields plumber.Fields, bd *plumber.BaseData) (*plumber.BaseData, error) {
+	if fields == nil {
+		return bd, nil
+	}
+	obj, err := fields.Project(bd)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	out := new(plumber.BaseData)
+	return out, json.Unmarshal(b, out)
+}
+
+// fromProtoCoordinates converts and validates the coordinates of a request
+func fromProtoCoordinates(c *munchpb.Coordinates) (*plumber.Coordinates, error) {
+	if c == nil {
+		return nil, errors.New("coordinates are required")
+	}
+	coords := plumber.NewCoordinates(c.GetLatitude(), c.GetLongitude())
+	if err := coords.Validate(); err != nil {
+		return nil, err
+	}
+	return coords, nil
+}
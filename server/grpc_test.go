@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/tinkershack/meteomunch/munchpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var bern = &munchpb.Coordinates{Latitude: 46.68, Longitude: 7.86}
+
+func TestGetForecast(t *testing.T) {
+	f, u := newTestForecaster(t, serveForecast)
+	s := &forecastService{forecaster: f, logger: discard}
+
+	tests := []struct {
+		name  string
+		req   *munchpb.ForecastRequest
+		code  codes.Code
+		check func(t *testing.T, resp *munchpb.ForecastResponse)
+	}{
+		{"forecast", &munchpb.ForecastRequest{Coordinates: bern}, codes.OK, func(t *testing.T, resp *munchpb.ForecastResponse) {
+			if resp.Provider != defaultProvider || resp.Data.Current.Temperature_2M != 20 || len(resp.Data.Hourly.WindSpeed_10M) != 2 {
+				t.Errorf("response = %v", resp)
+			}
+		}},
+		{"imperial", &munchpb.ForecastRequest{Coordinates: bern, Units: "imperial"}, codes.OK, func(t *testing.T, resp *munchpb.ForecastResponse) {
+			if resp.Data.Current.Temperature_2M != 68 || resp.Data.Units["temperature"] != "°F" {
+				t.Errorf("temperature = %v %s, want 68 °F", resp.Data.Current.Temperature_2M, resp.Data.Units["temperature"])
+			}
+		}},
+		{"fields", &munchpb.ForecastRequest{Coordinates: bern, Fields: []string{"hourly.temperature_2m"}}, codes.OK, func(t *testing.T, resp *munchpb.ForecastResponse) {
+			h := resp.Data.Hourly
+			if len(h.Time) != 2 || len(h.Temperature_2M) != 2 || len(h.WindSpeed_10M) != 0 || resp.Data.Current.Time != 0 {
+				t.Errorf("hourly = %v, current = %v, want hourly time and temperature_2m alone", h, resp.Data.Current)
+			}
+			if q := u.lastQuery(); q.Get("hourly") != "temperature_2m" || q.Has("current") {
+				t.Errorf("upstream asked for current %q, hourly %q, want hourly temperature_2m alone", q.Get("current"), q.Get("hourly"))
+			}
+		}},
+		{"options", &munchpb.ForecastRequest{Coordinates: bern, Options: &munchpb.FetchOptions{ForecastDays: 3, Model: "icon_seamless"}}, codes.OK, func(t *testing.T, resp *munchpb.ForecastResponse) {
+			if q := u.lastQuery(); q.Get("forecast_days") != "3" || q.Get("models") != "icon_seamless" {
+				t.Errorf("upstream query = %v", q)
+			}
+		}},
+		{"no coordinates", &munchpb.ForecastRequest{}, codes.InvalidArgument, nil},
+		{"latitude out of range", &munchpb.ForecastRequest{Coordinates: &munchpb.Coordinates{Latitude: 200}}, codes.InvalidArgument, nil},
+		{"unknown units", &munchpb.ForecastRequest{Coordinates: bern, Units: "furlongs"}, codes.InvalidArgument, nil},
+		{"unknown field", &munchpb.ForecastRequest{Coordinates: bern, Fields: []string{"hourly.nope"}}, codes.InvalidArgument, nil},
+		{"days beyond the limit", &munchpb.ForecastRequest{Coordinates: bern, Options: &munchpb.FetchOptions{ForecastDays: 17}}, codes.InvalidArgument, nil},
+		{"unknown provider", &munchpb.ForecastRequest{Coordinates: bern, Provider: "nope"}, codes.NotFound, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := len(u.queries)
+			resp, err := s.GetForecast(context.Background(), tt.req)
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("code %s, want %s: %v", code, tt.code, err)
+			}
+			if tt.code != codes.OK {
+				if len(u.queries) != before {
+					t.Error("bad request reached upstream")
+				}
+				return
+			}
+			tt.check(t, resp)
+		})
+	}
+}
+
+func TestGetForecastUpstreamError(t *testing.T) {
+	f, _ := newTestForecaster(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad request", http.StatusBadRequest)
+	})
+	s := &forecastService{forecaster: f, logger: discard}
+	if _, err := s.GetForecast(context.Background(), &munchpb.ForecastRequest{Coordinates: bern}); status.Code(err) != codes.Unavailable {
+		t.Errorf("err = %v, want Unavailable", err)
+	}
+}
+
+// recordingStream collects the responses sent on a StreamForecasts stream
+type recordingStream struct {
+	grpc.ServerStream
+
+	mu   sync.Mutex
+	sent []*munchpb.ForecastResponse
+}
+
+func (s *recordingStream) Context() context.Context { return context.Background() }
+
+func (s *recordingStream) Send(resp *munchpb.ForecastResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, resp)
+	return nil
+}
+
+func TestStreamForecasts(t *testing.T) {
+	f, _ := newTestForecaster(t, serveForecast)
+	s := &forecastService{forecaster: f, logger: discard}
+
+	stream := &recordingStream{}
+	req := &munchpb.StreamForecastsRequest{
+		Coordinates: []*munchpb.Coordinates{bern, {Latitude: -33.9, Longitude: 18.4}},
+		Units:       "imperial",
+		Fields:      []string{"current"},
+	}
+	if err := s.StreamForecasts(req, stream); err != nil {
+		t.Fatal(err)
+	}
+	if len(stream.sent) != 2 {
+		t.Fatalf("sent %d responses, want 2", len(stream.sent))
+	}
+	for _, resp := range stream.sent {
+		if resp.Data.Current.Temperature_2M != 68 || len(resp.Data.Hourly.Time) != 0 {
+			t.Errorf("current = %v, hourly = %v, want the current block in °F alone", resp.Data.Current, resp.Data.Hourly)
+		}
+	}
+
+	for _, req := range []*munchpb.StreamForecastsRequest{
+		{},
+		{Coordinates: []*munchpb.Coordinates{bern}, Units: "furlongs"},
+		{Coordinates: []*munchpb.Coordinates{bern}, Fields: []string{"nope"}},
+		{Coordinates: []*munchpb.Coordinates{bern}, Options: &munchpb.FetchOptions{PastDays: -1}},
+	} {
+		if err := s.StreamForecasts(req, &recordingStream{}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%v: err = %v, want InvalidArgument", req, err)
+		}
+	}
+}
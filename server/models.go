@@ -0,0 +1,170 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+
+	e "github.com/tinkershack/meteomunch/errors"
+	"github.com/tinkershack/meteomunch/plumber"
+	"github.com/tinkershack/meteomunch/providers"
+)
+
+// errNoModels marks providers that can't fetch several models in one call
+var errNoModels = errors.New("provider can't compare models")
+
+// modelsResponse is the body of GET /v1/forecast/models
+type modelsResponse struct {
+	Latitude  float64           `json:"latitude"`
+	Longitude float64           `json:"longitude"`
+	Provider  string            `json:"provider"`
+	Models    map[string]any    `json:"models"` // *plumber.BaseData by model, or their projections onto the selected fields
+	Spread    any               `json:"spread"` // plumber.Spread between the models, as in a consensus
+	Units     map[string]string `json:"units"`
+}
+
+// models fetches several models of the named provider for coords in a single upstream call
+//
+// Model comparisons go straight to upstream, bypassing the cache and archive, which hold single model forecasts.
+// The error wraps errNoModels if the provider isn't a providers.ModelFetcher, or what fetch's errors wrap.
+func (f *forecaster) models(ctx context.Context, name string, coords *plumber.Coordinates, models []string, req providers.Request) (map[string]*plumber.BaseData, error) {
+	p, err := f.providers.Upstream(name)
+	if err != nil {
+		return nil, err
+	}
+	mf, ok := p.(providers.ModelFetcher)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errNoModels, name)
+	}
+
+	if timeout := f.timeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	data, err := mf.FetchModels(ctx, coords, models, req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", errUpstream, name, err)
+	}
+	return data, nil
+}
+
+// modelsHandler serves GET /v1/forecast/models?lat=&lon=&models=icon_seamless,gfs_seamless&provider=&units=&fields=,
+// the forecasts of several models side by side along with their spread
+//
+// days, hours, past_days, cell_selection and timezone override the provider's configured options as for /v1/forecast.
+type modelsHandler struct {
+	forecaster *forecaster
+	logger     *slog.Logger
+}
+
+func (h *modelsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	coords, err := parseCoordinates(r)
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	q := r.URL.Query()
+	if q.Get("model") != "" {
+		writeError(w, h.logger, http.StatusBadRequest, "use models to name the models to compare")
+		return
+	}
+	var models []string
+	for _, m := range strings.Split(q.Get("models"), ",") {
+		if m = strings.TrimSpace(m); m != "" && !slices.Contains(models, m) {
+			models = append(models, m)
+		}
+	}
+	if len(models) < 2 {
+		writeError(w, h.logger, http.StatusBadRequest, "models must name at least two models")
+		return
+	}
+
+	name := providerParam(q)
+	opts, err := optionsParam(q, name)
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+	if limits, err := providers.LimitsOf(name); err == nil {
+		for _, m := range models {
+			o := opts
+			o.Model = m
+			if err := o.Validate(limits); err != nil {
+				writeError(w, h.logger, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+	}
+	system, err := unitsParam(q)
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+	fields, err := plumber.ParseFields(q.Get("fields"))
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	data, err := h.forecaster.models(r.Context(), name, coords, models, providers.Request{Options: opts, Fields: fields})
+	if errors.Is(err, errNoModels) {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		writeFetchError(w, h.logger, name, err)
+		return
+	}
+
+	results := make([]plumber.Result, len(models))
+	for i, m := range models {
+		results[i] = plumber.Result{Name: m, Data: data[m]}
+	}
+	c, err := plumber.Combine(plumber.MergeMean, results)
+	if err != nil {
+		h.logger.Error(e.FAIL, "err", err, "description", "Couldn't compare models", "provider", name)
+		writeError(w, h.logger, http.StatusInternalServerError, "couldn't compare models")
+		return
+	}
+	system.ConvertConsensus(c)
+	for _, bd := range data {
+		system.Convert(bd)
+	}
+
+	resp := modelsResponse{
+		Latitude:  coords.Latitude,
+		Longitude: coords.Longitude,
+		Provider:  name,
+		Models:    make(map[string]any, len(data)),
+		Spread:    c.Spread,
+		Units:     system.Units(),
+	}
+	for m, bd := range data {
+		resp.Models[m] = bd
+		if fields == nil {
+			continue
+		}
+		if resp.Models[m], err = fields.Project(bd); err != nil {
+			h.logger.Error(e.FAIL, "err", err, "description", "Couldn't project forecast onto fields")
+			writeError(w, h.logger, http.StatusInternalServerError, "couldn't select fields")
+			return
+		}
+	}
+	if fields != nil {
+		projected, err := fields.Project(c)
+		if err != nil {
+			h.logger.Error(e.FAIL, "err", err, "description", "Couldn't project forecast onto fields")
+			writeError(w, h.logger, http.StatusInternalServerError, "couldn't select fields")
+			return
+		}
+		resp.Spread = projected["spread"]
+	}
+	writeJSON(w, h.logger, http.StatusOK, resp)
+}
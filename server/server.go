@@ -4,22 +4,21 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/tinkershack/meteomunch/config"
 	e "github.com/tinkershack/meteomunch/errors"
 	"github.com/tinkershack/meteomunch/logger"
-	"github.com/tinkershack/meteomunch/plumber"
-	"github.com/tinkershack/meteomunch/providers"
+	"github.com/tinkershack/meteomunch/pws"
 )
 
 func Serve(ctx context.Context, args []string) {
 	logger := logger.NewTag("server")
 
-	// The following are transient test routes for an early stage development convenience.
-	// These will be cleanedup in the future.
-
 	cfg, err := config.Get()
 	logger.Debug("Config fetched", "config", cfg, "err", err)
 	if err != nil {
@@ -28,61 +27,94 @@ func Serve(ctx context.Context, args []string) {
 	}
 	logger.Debug("Config parsed successfully", "config", cfg)
 
-	mux := http.NewServeMux()
+	setupCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	f, err := newForecaster(setupCtx, cfg)
+	cancel()
+	if err != nil {
+		logger.Error(e.FATAL, "err", err, "description", "Couldn't set up providers")
+		os.Exit(1)
+	}
 
-	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		fmt.Fprintln(w, "OK")
-		logger.Debug(r.URL.String())
-	})
+	var stations *pws.Stations
+	if len(cfg.WeatherStations) > 0 {
+		stations = pws.NewStations(cfg.WeatherStations, pws.DefaultRetention)
+	}
 
-	mux.HandleFunc("GET /open-meteo", func(w http.ResponseWriter, r *http.Request) {
-		// p, err := providers.NewOpenMeteoProvider(cfg)
-		p, err := providers.New("open-meteo", cfg)
-		if err != nil {
-			logger.Error(e.FAIL, "err", err, "description", "Couldn't create OpenMeteoProvider")
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-		bd, err := p.FetchData(plumber.NewCoordinates(10.9018379, 76.8998445))
-		if err != nil {
-			logger.Error(e.FAIL, "err", err, "description", "Couldn't fetch data from OpenMeteoProvider")
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(bd); err != nil {
-			logger.Error(e.FAIL, "err", err, "description", "Couldn't encode response data to JSON")
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-		logger.Debug("API Data fetched", "data", bd, "provider", "open-meteo")
-	})
+	// Both transports are served side by side; whichever dies first takes the process down with it
+	errc := make(chan error, 2)
 
-	mux.HandleFunc("GET /meteo", func(w http.ResponseWriter, r *http.Request) {
-		// p, err := providers.NewMeteoBlueProvider(cfg)
-		p, err := providers.New("meteoblue", cfg)
-		if err != nil {
-			logger.Error(e.FAIL, "err", err, "description", "Couldn't create MeteoBlueProvider")
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-		// TO-DO : After defining the MB response, write it to the response
-		bd, err := p.FetchData(plumber.NewCoordinates(11.0056, 76.9661))
+	go func() {
+		addr := net.JoinHostPort(cfg.Munch.Server.Hostname, cfg.Munch.Server.GRPCPort)
+		lis, err := net.Listen("tcp", addr)
 		if err != nil {
-			logger.Error(e.FAIL, "err", err, "description", "Couldn't fetch data from MeteoBlueProvider")
-			w.WriteHeader(http.StatusInternalServerError)
+			errc <- fmt.Errorf("grpc: %w", err)
 			return
 		}
+		logger.Info("Ready, Plank? Serving Meteo Munch gRPC on " + addr)
+		errc <- fmt.Errorf("grpc: %w", newGRPCServer(f, logger).Serve(lis))
+	}()
+
+	go func() {
+		addr := net.JoinHostPort(cfg.Munch.Server.Hostname, cfg.Munch.Server.Port)
+		logger.Info("Ready, Plank? Serving Meteo Munch on " + addr)
+		errc <- fmt.Errorf("http: %w", http.ListenAndServe(addr, newMux(f, stations, logger)))
+	}()
+
+	err = <-errc
+	logger.Error(e.FATAL, "err", err, "description", "Server killed!")
+	os.Exit(-1)
+}
+
+// newMux registers all the HTTP routes served by munch; stations is nil unless WeatherStations are configured
+func newMux(f *forecaster, stations *pws.Stations, logger *slog.Logger) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		fmt.Fprintln(w, "OK")
-		// fmt.Printf("Data: %+v\n", bd)
-		logger.Debug("API Data fetched", "data", bd, "provider", "meteo-blue")
+		logger.Debug(r.URL.String())
 	})
 
-	logger.Info("Ready, Plank? Serving Meteo Munch on " + cfg.Munch.Server.Hostname + ":" + cfg.Munch.Server.Port)
-	err = http.ListenAndServe(fmt.Sprintf("%s:%s", cfg.Munch.Server.Hostname, cfg.Munch.Server.Port), mux)
-	logger.Error(e.FATAL, "err", err, "description", "Server killed!")
-	os.Exit(-1)
+	mux.Handle("GET /v1/forecast", &forecastHandler{forecaster: f, logger: logger})
+	mux.Handle("GET /v1/forecast/consensus", &consensusHandler{forecaster: f, logger: logger})
+	mux.Handle("GET /v1/forecast/models", &modelsHandler{forecaster: f, logger: logger})
+	mux.Handle("GET /v1/forecast/ensemble", &ensembleHandler{forecaster: f, logger: logger})
+	mux.Handle("GET /v1/soaring", &soaringHandler{forecaster: f, logger: logger})
+	mux.Handle("GET /v1/climatology", &climatologyHandler{forecaster: f, logger: logger})
+
+	if f.archive != nil {
+		a := &archiveHandler{archive: f.archive, logger: logger}
+		mux.HandleFunc("GET /v1/archive/forecast", a.forecast)
+		mux.HandleFunc("GET /v1/archive/issues", a.issues)
+		mux.HandleFunc("GET /v1/archive/diff", a.diff)
+	}
+
+	if stations != nil {
+		st := &stationHandler{stations: stations, logger: logger}
+		mux.HandleFunc("GET /weatherstation/updateweatherstation.php", st.wu)
+		mux.HandleFunc("POST /v1/stations/ecowitt", st.ecowitt)
+		mux.HandleFunc("GET /v1/stations", st.list)
+		mux.HandleFunc("GET /v1/stations/{id}", st.current)
+		mux.HandleFunc("GET /v1/stations/{id}/readings", st.readings)
+	}
+
+	return mux
+}
+
+// writeJSON encodes v as the JSON response body with the given status code
+func writeJSON(w http.ResponseWriter, logger *slog.Logger, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Error(e.FAIL, "err", err, "description", "Couldn't encode response data to JSON")
+	}
+}
+
+// writeError responds with a JSON error body of the form {"error": {"code": ..., "message": ...}}
+func writeError(w http.ResponseWriter, logger *slog.Logger, status int, message string) {
+	writeJSON(w, logger, status, struct {
+		Error e.Munch `json:"error"`
+	}{
+		Error: e.Munch{Code: status, Message: message},
+	})
 }
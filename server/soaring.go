@@ -0,0 +1,67 @@
+package server
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	e "github.com/tinkershack/meteomunch/errors"
+	"github.com/tinkershack/meteomunch/plumber"
+	"github.com/tinkershack/meteomunch/providers"
+)
+
+// soaringResponse is the body of GET /v1/soaring
+type soaringResponse struct {
+	Latitude  float64           `json:"latitude"`
+	Longitude float64           `json:"longitude"`
+	Elevation float64           `json:"elevation"`
+	Provider  string            `json:"provider"`
+	Hourly    []plumber.Soaring `json:"hourly"`
+}
+
+// soaringHandler serves GET /v1/soaring?lat=&lon=&provider=
+//
+// The forecast is scored against plumber.ParaglidingLimits.
+type soaringHandler struct {
+	forecaster *forecaster
+	logger     *slog.Logger
+}
+
+func (h *soaringHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	coords, err := parseCoordinates(r)
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	name := r.URL.Query().Get("provider")
+	if name == "" {
+		name = defaultProvider
+	}
+	bd, err := h.forecaster.fetch(r.Context(), name, coords, providers.Request{})
+	if err != nil {
+		writeFetchError(w, h.logger, name, err)
+		return
+	}
+
+	hours, err := bd.Soaring(plumber.ParaglidingLimits)
+	switch {
+	case err == nil:
+	case errors.Is(err, plumber.ErrNoBoundaryLayer):
+		// The provider doesn't forecast what thermals are estimated from, try another one
+		writeError(w, h.logger, http.StatusUnprocessableEntity, name+": "+err.Error())
+		return
+	default:
+		h.logger.Error(e.FAIL, "err", err, "description", "Couldn't compute soaring forecast", "provider", name)
+		writeError(w, h.logger, http.StatusInternalServerError, "couldn't compute soaring forecast")
+		return
+	}
+
+	writeJSON(w, h.logger, http.StatusOK, soaringResponse{
+		Latitude:  bd.Latitude,
+		Longitude: bd.Longitude,
+		Elevation: bd.Elevation,
+		Provider:  name,
+		Hourly:    hours,
+	})
+}
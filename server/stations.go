@@ -0,0 +1,133 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	e "github.com/tinkershack/meteomunch/errors"
+	"github.com/tinkershack/meteomunch/plumber"
+	"github.com/tinkershack/meteomunch/pws"
+)
+
+// stationCurrent is the body of GET /v1/stations/{id} and an entry of GET /v1/stations
+type stationCurrent struct {
+	Station  string               `json:"station"`
+	Location plumber.Location     `json:"location"`
+	Current  *plumber.CurrentData `json:"current"` // nil until the station uploads
+}
+
+// stationReadings is the body of GET /v1/stations/{id}/readings
+type stationReadings struct {
+	Station  string        `json:"station"`
+	Readings []pws.Reading `json:"readings"`
+}
+
+// stationHandler ingests uploads of the personal weather stations in config.WeatherStations and serves their readings
+type stationHandler struct {
+	stations *pws.Stations
+	logger   *slog.Logger
+}
+
+// wu serves GET /weatherstation/updateweatherstation.php, uploads in the Weather Underground protocol
+//
+// WU clients only look for "success" in the body, so the response is plain text.
+func (h *stationHandler) wu(w http.ResponseWriter, r *http.Request) {
+	creds, reading, err := pws.ParseWU(r.URL.Query(), time.Now())
+	h.ingest(w, creds, reading, err)
+}
+
+// ecowitt serves POST /v1/stations/ecowitt, uploads in Ecowitt's custom server protocol
+func (h *stationHandler) ecowitt(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	creds, reading, err := pws.ParseEcowitt(r.PostForm, time.Now())
+	h.ingest(w, creds, reading, err)
+}
+
+// ingest records a parsed upload, err being the parser's
+func (h *stationHandler) ingest(w http.ResponseWriter, creds pws.Credentials, reading *pws.Reading, err error) {
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	id, err := h.stations.Authenticate(creds)
+	if err != nil {
+		h.logger.Debug("Rejected station upload", "station", creds.ID)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	reading.Station = id
+	if err := h.stations.Record(*reading, time.Now()); err != nil {
+		h.logger.Error(e.FAIL, "err", err, "description", "Couldn't record station reading", "station", id)
+		http.Error(w, "couldn't record reading", http.StatusInternalServerError)
+		return
+	}
+	h.logger.Debug("Station reading recorded", "station", id, "time", reading.Time)
+	fmt.Fprintln(w, "success")
+}
+
+// list serves GET /v1/stations, every station with its current conditions
+func (h *stationHandler) list(w http.ResponseWriter, r *http.Request) {
+	out := make([]stationCurrent, 0, len(h.stations.IDs()))
+	for _, id := range h.stations.IDs() {
+		loc, current, err := h.stations.Current(id)
+		if err != nil && !errors.Is(err, pws.ErrNoReading) {
+			h.writeStationError(w, err)
+			return
+		}
+		out = append(out, stationCurrent{Station: id, Location: loc, Current: current})
+	}
+	writeJSON(w, h.logger, http.StatusOK, out)
+}
+
+// current serves GET /v1/stations/{id}, the station's latest reading as current conditions
+func (h *stationHandler) current(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	loc, current, err := h.stations.Current(id)
+	if err != nil {
+		h.writeStationError(w, err)
+		return
+	}
+	writeJSON(w, h.logger, http.StatusOK, stationCurrent{Station: id, Location: loc, Current: current})
+}
+
+// readings serves GET /v1/stations/{id}/readings?from=&to=, the station's recent readings
+func (h *stationHandler) readings(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	from, err := parseTime(q, "from", time.Time{})
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+	to, err := parseTime(q, "to", time.Time{})
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id := r.PathValue("id")
+	readings, err := h.stations.Readings(id, from, to)
+	if err != nil {
+		h.writeStationError(w, err)
+		return
+	}
+	if readings == nil {
+		readings = []pws.Reading{}
+	}
+	writeJSON(w, h.logger, http.StatusOK, stationReadings{Station: id, Readings: readings})
+}
+
+func (h *stationHandler) writeStationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, pws.ErrUnknownStation), errors.Is(err, pws.ErrNoReading):
+		writeError(w, h.logger, http.StatusNotFound, err.Error())
+	default:
+		h.logger.Error(e.FAIL, "err", err, "description", "Couldn't read station readings")
+		writeError(w, h.logger, http.StatusInternalServerError, "couldn't read station readings")
+	}
+}
@@ -0,0 +1,138 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+// boltBucket holds every forecast, keyed by Key.String()
+var boltBucket = []byte("forecasts")
+
+// Bolt is a ForecastStore in a single embedded bbolt file
+//
+// Queries scan the whole file, which is fine for the local and offline use it's meant for.
+// Only one process can open the file at a time.
+type Bolt struct {
+	db *bolt.DB
+}
+
+// boltRecord is the JSON value a Record is stored as
+type boltRecord struct {
+	Provider  string            `json:"provider"`
+	Model     string            `json:"model"`
+	Latitude  float64           `json:"latitude"`
+	Longitude float64           `json:"longitude"`
+	FetchedAt time.Time         `json:"fetched_at"`
+	Data      *plumber.BaseData `json:"data"`
+}
+
+// OpenBolt opens the store in the file at path, creating it if needed
+func OpenBolt(path string) (*Bolt, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open %s: %w", path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(boltBucket)
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("couldn't create bucket: %w", err)
+	}
+	return &Bolt{db: db}, nil
+}
+
+// Put stores the record
+func (b *Bolt) Put(_ context.Context, r *Record) error {
+	if r.Data == nil {
+		return errors.New("record has no data")
+	}
+	key := r.Key.normalize()
+	value, err := json.Marshal(boltRecord{
+		Provider:  key.Provider,
+		Model:     key.Model,
+		Latitude:  key.Latitude,
+		Longitude: key.Longitude,
+		FetchedAt: key.FetchedAt,
+		Data:      r.Data,
+	})
+	if err != nil {
+		return err
+	}
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltBucket).Put([]byte(key.String()), value)
+	})
+}
+
+// Get returns the record with the given key
+func (b *Bolt) Get(_ context.Context, key Key) (*Record, error) {
+	var r *Record
+	err := b.db.View(func(tx *bolt.Tx) error {
+		value := tx.Bucket(boltBucket).Get([]byte(key.normalize().String()))
+		if value == nil {
+			return fmt.Errorf("%w: %s", ErrNotFound, key)
+		}
+		var err error
+		r, err = decodeBolt(value)
+		return err
+	})
+	return r, err
+}
+
+// Query returns the matching records
+func (b *Bolt) Query(ctx context.Context, q Query) ([]*Record, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+
+	var out []*Record
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltBucket).ForEach(func(_, value []byte) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			r, err := decodeBolt(value)
+			if err != nil {
+				return err
+			}
+			if q.Matches(r) {
+				out = append(out, r)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sortAndLimit(out, q.Limit), nil
+}
+
+// Close closes the file
+func (b *Bolt) Close() error {
+	return b.db.Close()
+}
+
+func decodeBolt(value []byte) (*Record, error) {
+	var br boltRecord
+	if err := json.Unmarshal(value, &br); err != nil {
+		return nil, fmt.Errorf("corrupt record: %w", err)
+	}
+	return &Record{
+		Key: Key{
+			Provider:  br.Provider,
+			Model:     br.Model,
+			Latitude:  br.Latitude,
+			Longitude: br.Longitude,
+			FetchedAt: br.FetchedAt.UTC(),
+		},
+		Data: br.Data,
+	}, nil
+}
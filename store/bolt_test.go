@@ -0,0 +1,19 @@
+package store_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/tinkershack/meteomunch/store"
+	"github.com/tinkershack/meteomunch/store/storetest"
+)
+
+func TestBolt(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) store.ForecastStore {
+		s, err := store.OpenBolt(filepath.Join(t.TempDir(), "forecasts.db"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	})
+}
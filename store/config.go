@@ -0,0 +1,24 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tinkershack/meteomunch/config"
+)
+
+// FromConfig opens the store named by cfg.Munch.ForecastStore
+//
+// The error wraps ErrNotConfigured if none is set.
+func FromConfig(ctx context.Context, cfg *config.Config) (ForecastStore, error) {
+	switch cfg.Munch.ForecastStore {
+	case "":
+		return nil, ErrNotConfigured
+	case "bolt":
+		return OpenBolt(cfg.Munch.ForecastStorePath)
+	case "mongo":
+		return NewMongo(ctx, cfg.Mongo)
+	default:
+		return nil, fmt.Errorf("unsupported forecast store: %s", cfg.Munch.ForecastStore)
+	}
+}
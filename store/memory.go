@@ -0,0 +1,81 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Memory is a ForecastStore that keeps forecasts in process memory
+//
+// Records are copied in and out, so callers may modify them freely.
+type Memory struct {
+	mu      sync.RWMutex
+	records map[Key]*Record
+	closed  bool
+}
+
+// NewMemory returns an empty Memory store
+func NewMemory() *Memory {
+	return &Memory{records: make(map[Key]*Record)}
+}
+
+// Put stores a copy of the record
+func (m *Memory) Put(_ context.Context, r *Record) error {
+	if r.Data == nil {
+		return errors.New("record has no data")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return errClosed
+	}
+
+	key := r.Key.normalize()
+	m.records[key] = &Record{Key: key, Data: r.Data.Clone()}
+	return nil
+}
+
+// Get returns a copy of the record with the given key
+func (m *Memory) Get(_ context.Context, key Key) (*Record, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.closed {
+		return nil, errClosed
+	}
+
+	r, ok := m.records[key.normalize()]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	return &Record{Key: r.Key, Data: r.Data.Clone()}, nil
+}
+
+// Query returns copies of the matching records
+func (m *Memory) Query(_ context.Context, q Query) ([]*Record, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.closed {
+		return nil, errClosed
+	}
+
+	var out []*Record
+	for _, r := range m.records {
+		if q.Matches(r) {
+			out = append(out, &Record{Key: r.Key, Data: r.Data.Clone()})
+		}
+	}
+	return sortAndLimit(out, q.Limit), nil
+}
+
+// Close drops every record
+func (m *Memory) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.records, m.closed = nil, true
+	return nil
+}
@@ -0,0 +1,14 @@
+package store_test
+
+import (
+	"testing"
+
+	"github.com/tinkershack/meteomunch/store"
+	"github.com/tinkershack/meteomunch/store/storetest"
+)
+
+func TestMemory(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) store.ForecastStore {
+		return store.NewMemory()
+	})
+}
@@ -0,0 +1,185 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/tinkershack/meteomunch/config"
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+// mongoCollection holds the stored forecasts, one document per Key
+const mongoCollection = "forecasts"
+
+// Mongo is a ForecastStore backed by a MongoDB collection
+type Mongo struct {
+	client     *mongo.Client
+	collection *mongo.Collection
+}
+
+// mongoRecord is the document a Record is stored as
+//
+// The valid range is denormalised out of the data so that time range queries can use an index.
+type mongoRecord struct {
+	Key       string            `bson:"_id"`
+	Provider  string            `bson:"provider"`
+	Model     string            `bson:"model"`
+	Latitude  float64           `bson:"latitude"`
+	Longitude float64           `bson:"longitude"`
+	FetchedAt time.Time         `bson:"fetched_at"`
+	ValidFrom *time.Time        `bson:"valid_from,omitempty"`
+	ValidTo   *time.Time        `bson:"valid_to,omitempty"`
+	Data      *plumber.BaseData `bson:"data"`
+}
+
+// NewMongo connects to the database described by ds, usually config.Config.Mongo, and prepares the forecasts collection
+func NewMongo(ctx context.Context, ds config.DataStore) (*Mongo, error) {
+	if ds.URI == "" || ds.DBName == "" {
+		return nil, errors.New("mongo URI and DBName are required")
+	}
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(ds.URI))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't connect to mongo: %w", err)
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		_ = client.Disconnect(context.Background())
+		return nil, fmt.Errorf("couldn't reach mongo: %w", err)
+	}
+
+	collection := client.Database(ds.DBName).Collection(mongoCollection)
+	_, err = collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "latitude", Value: 1}, {Key: "longitude", Value: 1}, {Key: "fetched_at", Value: -1}}},
+		{Keys: bson.D{{Key: "provider", Value: 1}, {Key: "model", Value: 1}, {Key: "fetched_at", Value: -1}}},
+		{Keys: bson.D{{Key: "valid_from", Value: 1}, {Key: "valid_to", Value: 1}}},
+	})
+	if err != nil {
+		_ = client.Disconnect(context.Background())
+		return nil, fmt.Errorf("couldn't create indexes: %w", err)
+	}
+
+	return &Mongo{client: client, collection: collection}, nil
+}
+
+// Put upserts the record
+func (m *Mongo) Put(ctx context.Context, r *Record) error {
+	if r.Data == nil {
+		return errors.New("record has no data")
+	}
+	key := r.Key.normalize()
+	doc := mongoRecord{
+		Key:       key.String(),
+		Provider:  key.Provider,
+		Model:     key.Model,
+		Latitude:  key.Latitude,
+		Longitude: key.Longitude,
+		FetchedAt: key.FetchedAt,
+		Data:      r.Data,
+	}
+	if from, to, ok := r.ValidRange(); ok {
+		doc.ValidFrom, doc.ValidTo = &from, &to
+	}
+
+	_, err := m.collection.ReplaceOne(ctx, bson.D{{Key: "_id", Value: doc.Key}}, doc, options.Replace().SetUpsert(true))
+	return err
+}
+
+// Get returns the record with the given key
+func (m *Mongo) Get(ctx context.Context, key Key) (*Record, error) {
+	var doc mongoRecord
+	err := m.collection.FindOne(ctx, bson.D{{Key: "_id", Value: key.normalize().String()}}).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return doc.record(), nil
+}
+
+// Query returns the matching records
+func (m *Mongo) Query(ctx context.Context, q Query) ([]*Record, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+
+	filter := bson.D{}
+	if q.Provider != "" {
+		filter = append(filter, bson.E{Key: "provider", Value: q.Provider})
+	}
+	if q.Model != "" {
+		filter = append(filter, bson.E{Key: "model", Value: q.Model})
+	}
+	if q.Location != nil {
+		tol := q.tolerance()
+		filter = append(filter,
+			bson.E{Key: "latitude", Value: bson.D{{Key: "$gte", Value: q.Location.Latitude - tol}, {Key: "$lte", Value: q.Location.Latitude + tol}}},
+			bson.E{Key: "longitude", Value: bson.D{{Key: "$gte", Value: q.Location.Longitude - tol}, {Key: "$lte", Value: q.Location.Longitude + tol}}},
+		)
+	}
+	fetched := bson.D{}
+	if !q.FetchedAfter.IsZero() {
+		fetched = append(fetched, bson.E{Key: "$gte", Value: q.FetchedAfter})
+	}
+	if !q.FetchedBefore.IsZero() {
+		fetched = append(fetched, bson.E{Key: "$lt", Value: q.FetchedBefore})
+	}
+	if len(fetched) > 0 {
+		filter = append(filter, bson.E{Key: "fetched_at", Value: fetched})
+	}
+	// Records without a valid range have neither field and match neither condition, like Query.Matches
+	if !q.From.IsZero() || !q.To.IsZero() {
+		validFrom := bson.D{{Key: "$exists", Value: true}}
+		if !q.To.IsZero() {
+			validFrom = append(validFrom, bson.E{Key: "$lte", Value: q.To})
+		}
+		filter = append(filter, bson.E{Key: "valid_from", Value: validFrom})
+		if !q.From.IsZero() {
+			filter = append(filter, bson.E{Key: "valid_to", Value: bson.D{{Key: "$gte", Value: q.From}}})
+		}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "fetched_at", Value: -1}, {Key: "_id", Value: 1}})
+	if q.Limit > 0 {
+		opts.SetLimit(int64(q.Limit))
+	}
+	cursor, err := m.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var out []*Record
+	for cursor.Next(ctx) {
+		var doc mongoRecord
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		out = append(out, doc.record())
+	}
+	return out, cursor.Err()
+}
+
+// Close disconnects from mongo
+func (m *Mongo) Close() error {
+	return m.client.Disconnect(context.Background())
+}
+
+func (doc *mongoRecord) record() *Record {
+	return &Record{
+		Key: Key{
+			Provider:  doc.Provider,
+			Model:     doc.Model,
+			Latitude:  doc.Latitude,
+			Longitude: doc.Longitude,
+			FetchedAt: doc.FetchedAt.UTC(),
+		},
+		Data: doc.Data,
+	}
+}
@@ -0,0 +1,55 @@
+package store_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/tinkershack/meteomunch/config"
+	"github.com/tinkershack/meteomunch/store"
+	"github.com/tinkershack/meteomunch/store/storetest"
+)
+
+// testMongoURI is the server the Mongo tests run against, MUNCH_TEST_MONGO_URI if set
+func testMongoURI() string {
+	if uri := os.Getenv("MUNCH_TEST_MONGO_URI"); uri != "" {
+		return uri
+	}
+	return "mongodb://localhost:27017"
+}
+
+func TestMongo(t *testing.T) {
+	uri := testMongoURI()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	admin, err := mongo.Connect(ctx, options.Client().ApplyURI(uri).SetServerSelectionTimeout(2*time.Second))
+	if err == nil {
+		err = admin.Ping(ctx, nil)
+	}
+	if err != nil {
+		t.Skipf("mongo isn't reachable at %s: %v", uri, err)
+	}
+	defer admin.Disconnect(context.Background())
+
+	n := 0
+	storetest.Run(t, func(t *testing.T) store.ForecastStore {
+		// A database per subtest, dropped once it's done
+		n++
+		db := fmt.Sprintf("munch_test_%d_%d", os.Getpid(), n)
+		t.Cleanup(func() {
+			if err := admin.Database(db).Drop(context.Background()); err != nil {
+				t.Errorf("dropping %s: %v", db, err)
+			}
+		})
+		s, err := store.NewMongo(context.Background(), config.DataStore{URI: uri, DBName: db})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	})
+}
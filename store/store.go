@@ -0,0 +1,209 @@
+// Package store persists fetched forecasts for later querying
+//
+// A ForecastStore keeps every forecast it's given, as opposed to the cache package which only keeps the
+// latest one per grid cell until it expires. Forecasts are identified by provider, model, location and the
+// time they were fetched, and can be queried by any of those as well as by the time range they cover.
+//
+// Three backends are provided:
+// - Memory: in process, for tests and short lived tools.
+// - Bolt: a single embedded file, for local and offline use.
+// - Mongo: the config.Mongo database, shared between munch replicas.
+//
+// Every backend must pass the conformance suite in the storetest package.
+package store
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+// ErrNotFound is returned by ForecastStore.Get when there's no forecast for the key
+var ErrNotFound = errors.New("forecast not found")
+
+// ErrNotConfigured is returned by FromConfig when munch isn't configured with a ForecastStore
+var ErrNotConfigured = errors.New("no forecast store configured")
+
+// errClosed is returned by the stores that can tell they've been closed
+var errClosed = errors.New("store is closed")
+
+// DefaultTolerance is how far apart, in degrees, a forecast's location and a queried location may be to match
+const DefaultTolerance = 1e-4
+
+// ForecastStore persists forecasts
+//
+// Implementations must be safe for concurrent use. Locations are kept to 4 decimal places, about 10 m,
+// and fetch times to the millisecond, in UTC.
+type ForecastStore interface {
+	// Put stores the record, replacing any record with the same key
+	Put(ctx context.Context, r *Record) error
+	// Get returns the record with the given key, or an error wrapping ErrNotFound
+	Get(ctx context.Context, key Key) (*Record, error)
+	// Query returns the records matching q, most recently fetched first
+	Query(ctx context.Context, q Query) ([]*Record, error)
+	// Close releases the resources held by the store
+	Close() error
+}
+
+// Key identifies a stored forecast
+type Key struct {
+	Provider  string
+	Model     string
+	Latitude  float64
+	Longitude float64
+	FetchedAt time.Time
+}
+
+// String returns the key in the form provider/model/latitude,longitude/fetched-at
+func (k Key) String() string {
+	return fmt.Sprintf("%s/%s/%.4f,%.4f/%s", k.Provider, k.Model, k.Latitude, k.Longitude, k.FetchedAt.UTC().Format(time.RFC3339Nano))
+}
+
+// Record is a forecast as stored
+type Record struct {
+	Key
+	Data *plumber.BaseData
+}
+
+// NewRecord returns a record of the forecast fetched from provider at the given time, located at the forecast's coordinates
+func NewRecord(provider, model string, fetchedAt time.Time, data *plumber.BaseData) *Record {
+	return &Record{
+		Key: Key{
+			Provider:  provider,
+			Model:     model,
+			Latitude:  data.Latitude,
+			Longitude: data.Longitude,
+			FetchedAt: fetchedAt,
+		},
+		Data: data,
+	}
+}
+
+// normalize rounds the key to the precision every backend keeps
+func (k Key) normalize() Key {
+	round := func(v float64) float64 { return math.Round(v*1e4) / 1e4 }
+	k.Latitude, k.Longitude = round(k.Latitude), round(k.Longitude)
+	k.FetchedAt = k.FetchedAt.UTC().Truncate(time.Millisecond)
+	return k
+}
+
+// ValidRange returns the first and last time the forecast has data for, across its current, hourly and daily data
+//
+// ok is false if the forecast has no time axis at all.
+func (r *Record) ValidRange() (from, to time.Time, ok bool) {
+	if r.Data == nil {
+		return time.Time{}, time.Time{}, false
+	}
+
+	var times []int64
+	if r.Data.Current.Time != 0 {
+		times = append(times, r.Data.Current.Time)
+	}
+	times = append(times, r.Data.Hourly.Time...)
+	times = append(times, r.Data.Daily.Time...)
+	if len(times) == 0 {
+		return time.Time{}, time.Time{}, false
+	}
+	return time.Unix(slices.Min(times), 0).UTC(), time.Unix(slices.Max(times), 0).UTC(), true
+}
+
+// Query selects stored forecasts; zero fields don't filter
+type Query struct {
+	Provider string
+	Model    string
+
+	Location  *plumber.Coordinates
+	Tolerance float64 // Degrees of latitude and longitude a forecast may be off Location, DefaultTolerance if zero
+
+	// Forecasts that have data for at least part of [From, To]
+	From time.Time
+	To   time.Time
+
+	// Forecasts fetched within [FetchedAfter, FetchedBefore)
+	FetchedAfter  time.Time
+	FetchedBefore time.Time
+
+	Limit int // Maximum number of records returned, unlimited if zero
+}
+
+// Validate checks the query for contradictions
+func (q Query) Validate() error {
+	if q.Location != nil {
+		if err := q.Location.Validate(); err != nil {
+			return err
+		}
+	}
+	if q.Tolerance < 0 || q.Limit < 0 {
+		return errors.New("tolerance and limit can't be negative")
+	}
+	if !q.From.IsZero() && !q.To.IsZero() && q.To.Before(q.From) {
+		return errors.New("query range ends before it starts")
+	}
+	if !q.FetchedAfter.IsZero() && !q.FetchedBefore.IsZero() && q.FetchedBefore.Before(q.FetchedAfter) {
+		return errors.New("query fetch range ends before it starts")
+	}
+	return nil
+}
+
+// tolerance returns the effective location tolerance
+func (q Query) tolerance() float64 {
+	if q.Tolerance == 0 {
+		return DefaultTolerance
+	}
+	return q.Tolerance
+}
+
+// Matches reports whether the record is selected by the query, ignoring Limit
+func (q Query) Matches(r *Record) bool {
+	if q.Provider != "" && r.Provider != q.Provider {
+		return false
+	}
+	if q.Model != "" && r.Model != q.Model {
+		return false
+	}
+	if q.Location != nil {
+		tol := q.tolerance()
+		if math.Abs(r.Latitude-q.Location.Latitude) > tol || math.Abs(r.Longitude-q.Location.Longitude) > tol {
+			return false
+		}
+	}
+	if !q.FetchedAfter.IsZero() && r.FetchedAt.Before(q.FetchedAfter) {
+		return false
+	}
+	if !q.FetchedBefore.IsZero() && !r.FetchedAt.Before(q.FetchedBefore) {
+		return false
+	}
+	if !q.From.IsZero() || !q.To.IsZero() {
+		from, to, ok := r.ValidRange()
+		if !ok {
+			return false
+		}
+		if !q.From.IsZero() && to.Before(q.From) {
+			return false
+		}
+		if !q.To.IsZero() && from.After(q.To) {
+			return false
+		}
+	}
+	return true
+}
+
+// sortAndLimit orders records most recently fetched first, breaking ties by key, and applies the query's limit
+func sortAndLimit(records []*Record, limit int) []*Record {
+	slices.SortFunc(records, func(a, b *Record) int {
+		if c := b.FetchedAt.Compare(a.FetchedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Key.String(), b.Key.String())
+	})
+	if limit > 0 && len(records) > limit {
+		records = records[:limit]
+	}
+	return records
+}
@@ -0,0 +1,338 @@
+// Package storetest is the conformance suite every store.ForecastStore implementation must pass
+//
+// Run it from a test of the backend, giving it a function that opens an empty store:
+//
+//	func TestBolt(t *testing.T) {
+//	    storetest.Run(t, func(t *testing.T) store.ForecastStore {
+//	        s, err := store.OpenBolt(filepath.Join(t.TempDir(), "forecasts.db"))
+//	        if err != nil {
+//	            t.Fatal(err)
+//	        }
+//	        return s
+//	    })
+//	}
+//
+// Backends that need a server, like store.Mongo, should skip when it isn't reachable and drop their data between runs.
+package storetest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tinkershack/meteomunch/plumber"
+	"github.com/tinkershack/meteomunch/store"
+)
+
+// Opener returns a new, empty store; Run closes it when the subtest is done
+type Opener func(t *testing.T) store.ForecastStore
+
+// Run runs every conformance check against stores returned by open, each in its own subtest
+func Run(t *testing.T, open Opener) {
+	checks := []struct {
+		name  string
+		check func(t *testing.T, s store.ForecastStore)
+	}{
+		{"PutGet", testPutGet},
+		{"GetMissing", testGetMissing},
+		{"PutReplaces", testPutReplaces},
+		{"Precision", testPrecision},
+		{"QueryByProviderAndModel", testQueryByProviderAndModel},
+		{"QueryByLocation", testQueryByLocation},
+		{"QueryByFetchTime", testQueryByFetchTime},
+		{"QueryByValidRange", testQueryByValidRange},
+		{"QueryOrderAndLimit", testQueryOrderAndLimit},
+		{"QueryInvalid", testQueryInvalid},
+		{"Isolation", testIsolation},
+		{"Concurrency", testConcurrency},
+	}
+	for _, c := range checks {
+		t.Run(c.name, func(t *testing.T) {
+			s := open(t)
+			t.Cleanup(func() {
+				if err := s.Close(); err != nil {
+					t.Errorf("Close: %v", err)
+				}
+			})
+			c.check(t, s)
+		})
+	}
+}
+
+// base is the fetch time of the records the checks store
+var base = time.Date(2024, 10, 1, 6, 0, 0, 0, time.UTC)
+
+// forecast returns a forecast at the given location with 24 hours of hourly data starting at start
+func forecast(lat, lon float64, start time.Time) *plumber.BaseData {
+	bd := &plumber.BaseData{Latitude: lat, Longitude: lon, Elevation: 411, Timezone: "GMT"}
+	for i := range 24 {
+		bd.Hourly.Time = append(bd.Hourly.Time, start.Add(time.Duration(i)*time.Hour).Unix())
+		bd.Hourly.Temperature2M = append(bd.Hourly.Temperature2M, 20+float64(i)/2)
+	}
+	bd.Current.Time = start.Unix()
+	bd.Current.Temperature2M = 20
+	bd.Daily.Time = []int64{start.Truncate(24 * time.Hour).Unix()}
+	bd.Daily.Temperature2MMax = []float64{31.5}
+	return bd
+}
+
+// record returns a record of a forecast starting at its fetch time
+func record(provider, model string, lat, lon float64, fetchedAt time.Time) *store.Record {
+	return store.NewRecord(provider, model, fetchedAt, forecast(lat, lon, fetchedAt.Truncate(time.Hour)))
+}
+
+func put(t *testing.T, s store.ForecastStore, records ...*store.Record) {
+	t.Helper()
+	for _, r := range records {
+		if err := s.Put(context.Background(), r); err != nil {
+			t.Fatalf("Put %s: %v", r.Key, err)
+		}
+	}
+}
+
+func query(t *testing.T, s store.ForecastStore, q store.Query) []*store.Record {
+	t.Helper()
+	records, err := s.Query(context.Background(), q)
+	if err != nil {
+		t.Fatalf("Query %+v: %v", q, err)
+	}
+	return records
+}
+
+// sameData compares forecasts by their JSON encoding, which is what clients get to see
+func sameData(t *testing.T, got, want *plumber.BaseData) {
+	t.Helper()
+	g, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(g) != string(w) {
+		t.Errorf("data differs\n got: %s\nwant: %s", g, w)
+	}
+}
+
+// keys lists the keys of the records for error messages and comparisons
+func keys(records []*store.Record) []string {
+	out := make([]string, len(records))
+	for i, r := range records {
+		out[i] = r.Key.String()
+	}
+	return out
+}
+
+func expectKeys(t *testing.T, got []*store.Record, want ...*store.Record) {
+	t.Helper()
+	g, w := keys(got), keys(want)
+	if fmt.Sprint(g) != fmt.Sprint(w) {
+		t.Errorf("got records %v, want %v", g, w)
+	}
+}
+
+func testPutGet(t *testing.T, s store.ForecastStore) {
+	r := record("open-meteo", "best_match", 11, 77, base)
+	put(t, s, r)
+
+	got, err := s.Get(context.Background(), r.Key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Key != r.Key {
+		t.Errorf("got key %+v, want %+v", got.Key, r.Key)
+	}
+	sameData(t, got.Data, r.Data)
+}
+
+func testGetMissing(t *testing.T, s store.ForecastStore) {
+	put(t, s, record("open-meteo", "best_match", 11, 77, base))
+
+	for _, key := range []store.Key{
+		{Provider: "meteoblue", Model: "best_match", Latitude: 11, Longitude: 77, FetchedAt: base},
+		{Provider: "open-meteo", Model: "gfs_seamless", Latitude: 11, Longitude: 77, FetchedAt: base},
+		{Provider: "open-meteo", Model: "best_match", Latitude: 12, Longitude: 77, FetchedAt: base},
+		{Provider: "open-meteo", Model: "best_match", Latitude: 11, Longitude: 77, FetchedAt: base.Add(time.Second)},
+	} {
+		if _, err := s.Get(context.Background(), key); !errors.Is(err, store.ErrNotFound) {
+			t.Errorf("Get %s: got error %v, want ErrNotFound", key, err)
+		}
+	}
+}
+
+func testPutReplaces(t *testing.T, s store.ForecastStore) {
+	r := record("open-meteo", "best_match", 11, 77, base)
+	put(t, s, r)
+
+	replacement := record("open-meteo", "best_match", 11, 77, base)
+	replacement.Data.Elevation = 1234
+	put(t, s, replacement)
+
+	got, err := s.Get(context.Background(), r.Key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	sameData(t, got.Data, replacement.Data)
+	if n := len(query(t, s, store.Query{})); n != 1 {
+		t.Errorf("got %d records, want 1", n)
+	}
+}
+
+func testPrecision(t *testing.T, s store.ForecastStore) {
+	fetched := base.Add(123456789 * time.Nanosecond).In(time.FixedZone("IST", 19800))
+	r := record("open-meteo", "best_match", 11.00001, 76.99999, fetched)
+	put(t, s, r)
+
+	// The exact key the record was put with finds it, and what comes back is normalised
+	got, err := s.Get(context.Background(), r.Key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	want := store.Key{
+		Provider:  "open-meteo",
+		Model:     "best_match",
+		Latitude:  11,
+		Longitude: 77,
+		FetchedAt: base.Add(123 * time.Millisecond),
+	}
+	if got.Key != want {
+		t.Errorf("got key %+v, want %+v", got.Key, want)
+	}
+	if got.FetchedAt.Location() != time.UTC {
+		t.Errorf("got fetch time in %s, want UTC", got.FetchedAt.Location())
+	}
+}
+
+func testQueryByProviderAndModel(t *testing.T, s store.ForecastStore) {
+	om := record("open-meteo", "best_match", 11, 77, base)
+	gfs := record("open-meteo", "gfs_seamless", 11, 77, base)
+	mb := record("meteoblue", "basic", 11, 77, base)
+	put(t, s, om, gfs, mb)
+
+	expectKeys(t, query(t, s, store.Query{Provider: "open-meteo"}), om, gfs)
+	expectKeys(t, query(t, s, store.Query{Provider: "open-meteo", Model: "gfs_seamless"}), gfs)
+	expectKeys(t, query(t, s, store.Query{Model: "basic"}), mb)
+	expectKeys(t, query(t, s, store.Query{Provider: "nope"}))
+}
+
+func testQueryByLocation(t *testing.T, s store.ForecastStore) {
+	here := record("open-meteo", "best_match", 11, 77, base)
+	near := record("open-meteo", "best_match", 11.2, 76.9, base)
+	far := record("open-meteo", "best_match", -33.9, 18.4, base)
+	put(t, s, here, near, far)
+
+	expectKeys(t, query(t, s, store.Query{Location: plumber.NewCoordinates(11, 77)}), here)
+	expectKeys(t, query(t, s, store.Query{Location: plumber.NewCoordinates(11.00001, 77)}), here)
+	expectKeys(t, query(t, s, store.Query{Location: plumber.NewCoordinates(11, 77), Tolerance: 0.25}), here, near)
+	expectKeys(t, query(t, s, store.Query{Location: plumber.NewCoordinates(-33.9, 18.4)}), far)
+}
+
+func testQueryByFetchTime(t *testing.T, s store.ForecastStore) {
+	r0 := record("open-meteo", "best_match", 11, 77, base)
+	r1 := record("open-meteo", "best_match", 11, 77, base.Add(time.Hour))
+	r2 := record("open-meteo", "best_match", 11, 77, base.Add(2*time.Hour))
+	put(t, s, r0, r1, r2)
+
+	// FetchedAfter is inclusive, FetchedBefore exclusive
+	expectKeys(t, query(t, s, store.Query{FetchedAfter: base.Add(time.Hour)}), r2, r1)
+	expectKeys(t, query(t, s, store.Query{FetchedBefore: base.Add(time.Hour)}), r0)
+	expectKeys(t, query(t, s, store.Query{FetchedAfter: base, FetchedBefore: base.Add(2 * time.Hour)}), r1, r0)
+}
+
+func testQueryByValidRange(t *testing.T, s store.ForecastStore) {
+	// Forecasts covering a day each, fetched on consecutive days
+	day0 := record("open-meteo", "best_match", 11, 77, base)
+	day1 := record("open-meteo", "best_match", 11, 77, base.Add(24*time.Hour))
+	empty := store.NewRecord("open-meteo", "best_match", base.Add(time.Minute), &plumber.BaseData{Latitude: 11, Longitude: 77})
+	put(t, s, day0, day1, empty)
+
+	expectKeys(t, query(t, s, store.Query{From: base.Add(30 * time.Hour)}), day1)
+	expectKeys(t, query(t, s, store.Query{To: base.Add(time.Hour)}), day0)
+	expectKeys(t, query(t, s, store.Query{From: base.Add(20 * time.Hour), To: base.Add(25 * time.Hour)}), day1, day0)
+	expectKeys(t, query(t, s, store.Query{From: base.Add(72 * time.Hour)}))
+	// Without a time range, forecasts without a time axis are returned too
+	expectKeys(t, query(t, s, store.Query{FetchedBefore: base.Add(time.Hour)}), empty, day0)
+}
+
+func testQueryOrderAndLimit(t *testing.T, s store.ForecastStore) {
+	var records []*store.Record
+	for i := range 5 {
+		records = append(records, record("open-meteo", "best_match", 11, 77, base.Add(time.Duration(i)*time.Hour)))
+	}
+	// Stored out of order, returned most recently fetched first
+	put(t, s, records[3], records[0], records[4], records[1], records[2])
+
+	expectKeys(t, query(t, s, store.Query{}), records[4], records[3], records[2], records[1], records[0])
+	expectKeys(t, query(t, s, store.Query{Limit: 2}), records[4], records[3])
+}
+
+func testQueryInvalid(t *testing.T, s store.ForecastStore) {
+	for _, q := range []store.Query{
+		{Location: plumber.NewCoordinates(91, 0)},
+		{Tolerance: -1},
+		{Limit: -1},
+		{From: base, To: base.Add(-time.Hour)},
+		{FetchedAfter: base, FetchedBefore: base.Add(-time.Hour)},
+	} {
+		if _, err := s.Query(context.Background(), q); err == nil {
+			t.Errorf("Query %+v: got no error", q)
+		}
+	}
+}
+
+func testIsolation(t *testing.T, s store.ForecastStore) {
+	r := record("open-meteo", "best_match", 11, 77, base)
+	want := r.Data.Clone()
+	put(t, s, r)
+
+	// Neither the record put nor the one returned may share memory with what's stored
+	r.Data.Hourly.Temperature2M[0] = -99
+	got, err := s.Get(context.Background(), r.Key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got.Data.Hourly.Temperature2M[1] = -99
+
+	again, err := s.Get(context.Background(), r.Key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	sameData(t, again.Data, want)
+}
+
+func testConcurrency(t *testing.T, s store.ForecastStore) {
+	var wg sync.WaitGroup
+	errs := make(chan error, 64)
+	for i := range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := record("open-meteo", "best_match", 11, 77, base.Add(time.Duration(i)*time.Minute))
+			if err := s.Put(context.Background(), r); err != nil {
+				errs <- err
+				return
+			}
+			if _, err := s.Get(context.Background(), r.Key); err != nil {
+				errs <- err
+				return
+			}
+			if _, err := s.Query(context.Background(), store.Query{Provider: "open-meteo"}); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if n := len(query(t, s, store.Query{})); n != 16 {
+		t.Errorf("got %d records, want 16", n)
+	}
+}
@@ -0,0 +1,187 @@
+package units
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+// ErrUnknownSystem is returned by Lookup for names that aren't in Systems
+var ErrUnknownSystem = errors.New("unknown unit system")
+
+// System is a consistent choice of units for every quantity in meteo data
+type System struct {
+	Name          string
+	Temperature   TemperatureUnit
+	Speed         SpeedUnit
+	Pressure      PressureUnit
+	Height        LengthUnit        // Elevation, freezing level, boundary layer and geopotential heights
+	Visibility    LengthUnit        // Horizontal visibility
+	Precipitation PrecipitationUnit // Rain, showers, precipitation and evapotranspiration
+	Snowfall      PrecipitationUnit
+}
+
+var (
+	// Metric is plumber.CommonUnits, the units munch keeps its data in
+	Metric = System{"metric", Celsius, KilometresPerHour, Hectopascal, Metre, Metre, Millimetre, Centimetre}
+	// Imperial is US customary units
+	Imperial = System{"imperial", Fahrenheit, MilesPerHour, InchesOfMercury, Foot, StatuteMile, Inch, Inch}
+	// SI is the International System of Units, with precipitation in mm, i.e. kg/m²
+	SI = System{"si", Kelvin, MetresPerSecond, Pascal, Metre, Metre, Millimetre, Millimetre}
+	// Aviation is the ICAO mix of units used in METARs, TAFs and flight planning
+	Aviation = System{"aviation", Celsius, Knots, Hectopascal, Foot, Metre, Millimetre, Centimetre}
+)
+
+// Systems lists the unit systems by name
+var Systems = map[string]System{
+	Metric.Name:   Metric,
+	Imperial.Name: Imperial,
+	SI.Name:       SI,
+	Aviation.Name: Aviation,
+}
+
+// Lookup returns the named unit system, Metric if name is empty
+func Lookup(name string) (System, error) {
+	if name == "" {
+		return Metric, nil
+	}
+	s, ok := Systems[strings.ToLower(name)]
+	if !ok {
+		return System{}, fmt.Errorf("%w: %s", ErrUnknownSystem, name)
+	}
+	return s, nil
+}
+
+// Units returns the symbol of the system's unit for every quantity, e.g. "temperature": "°F"
+func (s System) Units() map[string]string {
+	return map[string]string{
+		"temperature":   s.Temperature.String(),
+		"speed":         s.Speed.String(),
+		"pressure":      s.Pressure.String(),
+		"height":        s.Height.String(),
+		"visibility":    s.Visibility.String(),
+		"precipitation": s.Precipitation.String(),
+		"snowfall":      s.Snowfall.String(),
+	}
+}
+
+// Convert converts data in plumber.CommonUnits, as providers return it, into the system's units in place
+// and records them in data.Units
+//
+// Only quantities with a unit in System are converted; humidity, cloud cover, directions, energies
+// and the like are the same in every system.
+func (s System) Convert(data *plumber.BaseData) {
+	data.Elevation = height.convert(data.Elevation, s)
+	convertBlock(reflect.ValueOf(&data.Current).Elem(), s)
+	convertBlock(reflect.ValueOf(&data.Hourly).Elem(), s)
+	convertBlock(reflect.ValueOf(&data.Daily).Elem(), s)
+	if data.Marine != nil {
+		convertBlock(reflect.ValueOf(data.Marine).Elem(), s)
+	}
+	data.Units = s.Units()
+}
+
+// ConvertConsensus converts a consensus like Convert, along with its spread
+func (s System) ConvertConsensus(c *plumber.Consensus) {
+	s.Convert(&c.BaseData)
+	// Spreads are differences between values, which only scale: a spread of 1 °C is one of 1.8 °F
+	for name, v := range c.Spread.Current {
+		if q, ok := quantityOf(name); ok {
+			c.Spread.Current[name] = q.convertDifference(v, s)
+		}
+	}
+	for _, spreads := range []map[string][]float64{c.Spread.Hourly, c.Spread.Daily} {
+		for name, values := range spreads {
+			q, ok := quantityOf(name)
+			if !ok {
+				continue
+			}
+			for i, v := range values {
+				values[i] = q.convertDifference(v, s)
+			}
+		}
+	}
+}
+
+// quantity is what a field measures: the unit plumber keeps it in and the unit a System wants it in
+type quantity struct {
+	common unit
+	target func(System) unit
+}
+
+var (
+	temperature   = quantity{Celsius.unit, func(s System) unit { return s.Temperature.unit }}
+	speed         = quantity{KilometresPerHour.unit, func(s System) unit { return s.Speed.unit }}
+	pressure      = quantity{Hectopascal.unit, func(s System) unit { return s.Pressure.unit }}
+	height        = quantity{Metre.unit, func(s System) unit { return s.Height.unit }}
+	visibility    = quantity{Metre.unit, func(s System) unit { return s.Visibility.unit }}
+	precipitation = quantity{Millimetre.unit, func(s System) unit { return s.Precipitation.unit }}
+	snowfall      = quantity{Centimetre.unit, func(s System) unit { return s.Snowfall.unit }} // plumber keeps snowfall in cm
+)
+
+// quantityOf returns the quantity of the field with the given JSON name; ok is false for fields that aren't converted
+func quantityOf(field string) (quantity, bool) {
+	switch {
+	case strings.HasPrefix(field, "temperature"), strings.HasPrefix(field, "apparent_temperature"),
+		strings.HasPrefix(field, "dew_point"), strings.HasPrefix(field, "soil_temperature"),
+		field == "sea_surface_temperature":
+		return temperature, true
+	case strings.HasPrefix(field, "wind_speed"), strings.HasPrefix(field, "wind_gusts"):
+		return speed, true
+	case field == "pressure_msl", field == "surface_pressure":
+		return pressure, true
+	case field == "elevation", field == "freezing_level_height", field == "boundary_layer_height",
+		strings.HasPrefix(field, "geopotential_height"), strings.HasSuffix(field, "wave_height"):
+		return height, true
+	case field == "visibility":
+		return visibility, true
+	case field == "precipitation", field == "rain", field == "showers", field == "precipitation_sum",
+		field == "rain_sum", field == "showers_sum", field == "evapotranspiration", field == "et0_fao_evapotranspiration":
+		return precipitation, true
+	case field == "snowfall", field == "snowfall_sum":
+		return snowfall, true
+	}
+	return quantity{}, false
+}
+
+// convert converts a value of the quantity from its common unit into the system's
+func (q quantity) convert(v float64, s System) float64 {
+	target := q.target(s)
+	if target == q.common {
+		return v
+	}
+	return target.round(target.fromCommon(q.common.toCommon(v)))
+}
+
+// convertDifference converts a difference between two values of the quantity, ignoring the offset of the units
+func (q quantity) convertDifference(v float64, s System) float64 {
+	target := q.target(s)
+	if target == q.common {
+		return v
+	}
+	return target.round(v * q.common.scale / target.scale)
+}
+
+// convertBlock converts every float field of a CurrentData, HourlyData, DailyData or MarineData, scalar or series,
+// whose JSON name has a quantity
+func convertBlock(v reflect.Value, s System) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		q, ok := quantityOf(name)
+		if !ok {
+			continue
+		}
+		switch f := v.Field(i); {
+		case f.Kind() == reflect.Float64:
+			f.SetFloat(q.convert(f.Float(), s))
+		case f.Kind() == reflect.Slice && f.Type().Elem().Kind() == reflect.Float64:
+			for j := 0; j < f.Len(); j++ {
+				f.Index(j).SetFloat(q.convert(f.Index(j).Float(), s))
+			}
+		}
+	}
+}
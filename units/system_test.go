@@ -0,0 +1,62 @@
+package units
+
+import (
+	"testing"
+
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+func TestConvertImperial(t *testing.T) {
+	bd := &plumber.BaseData{Elevation: 1000}
+	bd.Hourly.Time = []int64{0, 3600, 7200}
+	bd.Hourly.Temperature2M = []float64{20, -40, 0.1}
+	bd.Hourly.Precipitation = []float64{0.1, 0.2, 12.7}
+	bd.Hourly.WindSpeed10M = []float64{16.09344, 0, 100}
+	bd.Hourly.PressureMSL = []float64{1013.25, 1000, 980}
+	bd.Hourly.Visibility = []float64{1609.344, 24140, 50}
+
+	Imperial.Convert(bd)
+
+	tests := []struct {
+		field string
+		got   []float64
+		want  []float64
+	}{
+		{"temperature_2m", bd.Hourly.Temperature2M, []float64{68, -40, 32.18}},
+		// Light rain must not round away: 0.1 mm is the smallest amount reported
+		{"precipitation", bd.Hourly.Precipitation, []float64{0.004, 0.008, 0.5}},
+		{"wind_speed_10m", bd.Hourly.WindSpeed10M, []float64{10, 0, 62.14}},
+		{"pressure_msl", bd.Hourly.PressureMSL, []float64{29.92, 29.53, 28.94}},
+		{"visibility", bd.Hourly.Visibility, []float64{1, 15, 0.03}},
+	}
+	for _, tt := range tests {
+		for i := range tt.want {
+			if tt.got[i] != tt.want[i] {
+				t.Errorf("%s[%d] = %v, want %v", tt.field, i, tt.got[i], tt.want[i])
+			}
+		}
+	}
+	if bd.Elevation != 3280.84 {
+		t.Errorf("elevation = %v, want 3280.84", bd.Elevation)
+	}
+	if bd.Units["precipitation"] != "in" || bd.Units["temperature"] != "°F" {
+		t.Errorf("units = %v", bd.Units)
+	}
+}
+
+func TestConvertSpreadScalesOnly(t *testing.T) {
+	c := &plumber.Consensus{Spread: plumber.Spread{
+		Current: map[string]float64{"temperature_2m": 1},
+		Hourly:  map[string][]float64{"precipitation": {0.1}, "wind_direction_10m": {30}},
+	}}
+	Imperial.ConvertConsensus(c)
+	if got := c.Spread.Current["temperature_2m"]; got != 1.8 {
+		t.Errorf("temperature spread = %v, want 1.8", got)
+	}
+	if got := c.Spread.Hourly["precipitation"][0]; got != 0.004 {
+		t.Errorf("precipitation spread = %v, want 0.004", got)
+	}
+	if got := c.Spread.Hourly["wind_direction_10m"][0]; got != 30 {
+		t.Errorf("direction spread = %v, want 30", got)
+	}
+}
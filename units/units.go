@@ -0,0 +1,154 @@
+// Package units gives the quantities in meteo data a type and converts them between units.
+//
+// Every quantity type holds its value in the unit plumber.CommonUnits lists for it: Temperature in °C,
+// Speed in km/h, Pressure in hPa, Length in m and Precipitation in mm. New* constructors take a value in
+// any unit of the quantity, and In returns it in another:
+//
+//	units.NewSpeed(10, units.Knots).In(units.KilometresPerHour) // 18.52
+//
+// A System picks a unit for every quantity; its Convert methods convert plumber data into it.
+package units
+
+import "math"
+
+// unit converts values to the common unit of its quantity: common = value*scale + offset
+type unit struct {
+	symbol string
+	scale  float64
+	offset float64
+	// Decimals converted values are rounded to, enough to keep the smallest step of the data in the common unit,
+	// e.g. 0.1 mm of rain as 0.004 in
+	decimals int
+}
+
+// String returns the unit's symbol, e.g. km/h
+func (u unit) String() string {
+	return u.symbol
+}
+
+func (u unit) toCommon(v float64) float64 {
+	return v*u.scale + u.offset
+}
+
+func (u unit) fromCommon(v float64) float64 {
+	return (v - u.offset) / u.scale
+}
+
+// TemperatureUnit is a unit of temperature
+type TemperatureUnit struct{ unit }
+
+var (
+	Celsius    = TemperatureUnit{unit{"°C", 1, 0, 2}}
+	Fahrenheit = TemperatureUnit{unit{"°F", 5.0 / 9, -32 * 5.0 / 9, 2}}
+	Kelvin     = TemperatureUnit{unit{"K", 1, -273.15, 2}}
+)
+
+// Temperature in °C
+type Temperature float64
+
+// NewTemperature returns the temperature of v in unit u
+func NewTemperature(v float64, u TemperatureUnit) Temperature {
+	return Temperature(u.toCommon(v))
+}
+
+// In returns the temperature in unit u
+func (t Temperature) In(u TemperatureUnit) float64 {
+	return u.fromCommon(float64(t))
+}
+
+// SpeedUnit is a unit of speed
+type SpeedUnit struct{ unit }
+
+var (
+	KilometresPerHour = SpeedUnit{unit{"km/h", 1, 0, 2}}
+	MetresPerSecond   = SpeedUnit{unit{"m/s", 3.6, 0, 2}}
+	MilesPerHour      = SpeedUnit{unit{"mph", 1.609344, 0, 2}}
+	Knots             = SpeedUnit{unit{"kn", 1.852, 0, 2}}
+)
+
+// Speed in km/h
+type Speed float64
+
+// NewSpeed returns the speed of v in unit u
+func NewSpeed(v float64, u SpeedUnit) Speed {
+	return Speed(u.toCommon(v))
+}
+
+// In returns the speed in unit u
+func (s Speed) In(u SpeedUnit) float64 {
+	return u.fromCommon(float64(s))
+}
+
+// PressureUnit is a unit of pressure
+type PressureUnit struct{ unit }
+
+var (
+	Hectopascal     = PressureUnit{unit{"hPa", 1, 0, 2}}
+	Pascal          = PressureUnit{unit{"Pa", 0.01, 0, 2}}
+	Kilopascal      = PressureUnit{unit{"kPa", 10, 0, 2}}
+	InchesOfMercury = PressureUnit{unit{"inHg", 33.8639, 0, 2}}
+)
+
+// Pressure in hPa
+type Pressure float64
+
+// NewPressure returns the pressure of v in unit u
+func NewPressure(v float64, u PressureUnit) Pressure {
+	return Pressure(u.toCommon(v))
+}
+
+// In returns the pressure in unit u
+func (p Pressure) In(u PressureUnit) float64 {
+	return u.fromCommon(float64(p))
+}
+
+// LengthUnit is a unit of length, used for heights and distances
+type LengthUnit struct{ unit }
+
+var (
+	Metre       = LengthUnit{unit{"m", 1, 0, 2}}
+	Kilometre   = LengthUnit{unit{"km", 1000, 0, 2}}
+	Foot        = LengthUnit{unit{"ft", 0.3048, 0, 2}}
+	StatuteMile = LengthUnit{unit{"mi", 1609.344, 0, 2}}
+)
+
+// Length in m
+type Length float64
+
+// NewLength returns the length of v in unit u
+func NewLength(v float64, u LengthUnit) Length {
+	return Length(u.toCommon(v))
+}
+
+// In returns the length in unit u
+func (l Length) In(u LengthUnit) float64 {
+	return u.fromCommon(float64(l))
+}
+
+// PrecipitationUnit is a unit of precipitation depth
+type PrecipitationUnit struct{ unit }
+
+var (
+	Millimetre = PrecipitationUnit{unit{"mm", 1, 0, 2}}
+	Centimetre = PrecipitationUnit{unit{"cm", 10, 0, 2}}
+	Inch       = PrecipitationUnit{unit{"in", 25.4, 0, 3}}
+)
+
+// Precipitation depth in mm
+type Precipitation float64
+
+// NewPrecipitation returns the precipitation depth of v in unit u
+func NewPrecipitation(v float64, u PrecipitationUnit) Precipitation {
+	return Precipitation(u.toCommon(v))
+}
+
+// In returns the precipitation depth in unit u
+func (p Precipitation) In(u PrecipitationUnit) float64 {
+	return u.fromCommon(float64(p))
+}
+
+// round keeps a converted value to a precision that doesn't pretend more than the data has, the unit's decimals
+func (u unit) round(v float64) float64 {
+	p := math.Pow10(u.decimals)
+	return math.Round(v*p) / p
+}
@@ -0,0 +1,263 @@
+package verify
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+// Observation is what was measured at a station at a given time
+//
+// Values are keyed by the JSON name of the matching HourlyData field, e.g. temperature_2m, and must be
+// in plumber.CommonUnits. Like HourlyData, sums such as precipitation cover the hour preceding Time.
+type Observation struct {
+	Station   string             `json:"station"`
+	Latitude  float64            `json:"latitude"`
+	Longitude float64            `json:"longitude"`
+	Time      time.Time          `json:"time"`
+	Values    map[string]float64 `json:"values"`
+}
+
+// Validate checks the observation's location and that its values name hourly fields
+func (o *Observation) Validate() error {
+	if err := plumber.NewCoordinates(o.Latitude, o.Longitude).Validate(); err != nil {
+		return fmt.Errorf("station %s: %w", o.Station, err)
+	}
+	if o.Time.IsZero() {
+		return fmt.Errorf("station %s: observation has no time", o.Station)
+	}
+	fields := plumber.HourlyFields()
+	for name, v := range o.Values {
+		if !slices.Contains(fields, name) {
+			return fmt.Errorf("station %s: unknown hourly field %q", o.Station, name)
+		}
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("station %s: %s isn't a number", o.Station, name)
+		}
+	}
+	return nil
+}
+
+// ObservationQuery selects observations; zero fields don't filter
+type ObservationQuery struct {
+	Location  *plumber.Coordinates
+	Tolerance float64 // Degrees of latitude and longitude a station may be off Location, DefaultTolerance if zero
+
+	// Observations made within [From, To)
+	From time.Time
+	To   time.Time
+}
+
+// matches reports whether the observation is selected by the query
+func (q ObservationQuery) matches(o *Observation) bool {
+	if q.Location != nil {
+		tol := q.Tolerance
+		if tol == 0 {
+			tol = DefaultTolerance
+		}
+		if math.Abs(o.Latitude-q.Location.Latitude) > tol || math.Abs(o.Longitude-q.Location.Longitude) > tol {
+			return false
+		}
+	}
+	if !q.From.IsZero() && o.Time.Before(q.From) {
+		return false
+	}
+	if !q.To.IsZero() && !o.Time.Before(q.To) {
+		return false
+	}
+	return true
+}
+
+// Source provides observations to verify forecasts against
+//
+// Implementations must be safe for concurrent use.
+type Source interface {
+	Observations(ctx context.Context, q ObservationQuery) ([]Observation, error)
+}
+
+// Observations is an in-memory Source, filled by Add or by importing files
+type Observations struct {
+	mu  sync.RWMutex
+	obs []Observation
+}
+
+// NewObservations returns a Source holding the given observations
+func NewObservations(obs ...Observation) (*Observations, error) {
+	o := &Observations{}
+	if err := o.Add(obs...); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// Add validates and adds observations; nothing is added if any of them is invalid
+func (o *Observations) Add(obs ...Observation) error {
+	for i := range obs {
+		if err := obs[i].Validate(); err != nil {
+			return err
+		}
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.obs = append(o.obs, obs...)
+	return nil
+}
+
+// Observations returns the observations matching q, in the order they were added
+func (o *Observations) Observations(_ context.Context, q ObservationQuery) ([]Observation, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	var out []Observation
+	for i := range o.obs {
+		if q.matches(&o.obs[i]) {
+			out = append(out, o.obs[i])
+		}
+	}
+	return out, nil
+}
+
+// Len returns the number of observations held
+func (o *Observations) Len() int {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return len(o.obs)
+}
+
+// ImportFile adds the observations in a .csv or .json file, see ReadCSV and ReadJSON for the formats
+func (o *Observations) ImportFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var obs []Observation
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		obs, err = ReadCSV(f)
+	case ".json":
+		obs, err = ReadJSON(f)
+	default:
+		return fmt.Errorf("%s: unsupported observations format, want .csv or .json", path)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return o.Add(obs...)
+}
+
+// ReadJSON reads a JSON array of observations
+func ReadJSON(r io.Reader) ([]Observation, error) {
+	var obs []Observation
+	if err := json.NewDecoder(r).Decode(&obs); err != nil {
+		return nil, fmt.Errorf("couldn't decode observations: %w", err)
+	}
+	for i := range obs {
+		if err := obs[i].Validate(); err != nil {
+			return nil, fmt.Errorf("observation %d: %w", i, err)
+		}
+	}
+	return obs, nil
+}
+
+// csvColumns are the leading columns of an observations CSV file, the others name hourly fields
+var csvColumns = []string{"station", "latitude", "longitude", "time"}
+
+// ReadCSV reads observations from CSV with a header row
+//
+// The first columns must be station, latitude, longitude and time (RFC 3339 or a Unix timestamp),
+// followed by one column per hourly field, named by its JSON name:
+//
+//	station,latitude,longitude,time,temperature_2m,wind_speed_10m,precipitation
+//	VOCB,11.03,77.04,2024-10-01T06:00:00Z,27.5,11.1,0
+//
+// Empty cells are missing values.
+func ReadCSV(r io.Reader) ([]Observation, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read header: %w", err)
+	}
+	if len(header) < len(csvColumns) || !slices.Equal(header[:len(csvColumns)], csvColumns) {
+		return nil, fmt.Errorf("header must start with %s", strings.Join(csvColumns, ","))
+	}
+	fields := header[len(csvColumns):]
+	known := plumber.HourlyFields()
+	for _, f := range fields {
+		if !slices.Contains(known, f) {
+			return nil, fmt.Errorf("unknown hourly field %q in header", f)
+		}
+	}
+
+	var obs []Observation
+	for line := 2; ; line++ {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return obs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		o, err := parseCSVRecord(rec, fields)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		obs = append(obs, o)
+	}
+}
+
+func parseCSVRecord(rec []string, fields []string) (Observation, error) {
+	o := Observation{Station: rec[0], Values: make(map[string]float64)}
+
+	var err error
+	if o.Latitude, err = strconv.ParseFloat(rec[1], 64); err != nil {
+		return o, errors.New("latitude must be a decimal number")
+	}
+	if o.Longitude, err = strconv.ParseFloat(rec[2], 64); err != nil {
+		return o, errors.New("longitude must be a decimal number")
+	}
+	if o.Time, err = parseTime(rec[3]); err != nil {
+		return o, err
+	}
+
+	for i, f := range fields {
+		cell := rec[len(csvColumns)+i]
+		if cell == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(cell, 64)
+		if err != nil {
+			return o, fmt.Errorf("%s must be a decimal number", f)
+		}
+		o.Values[f] = v
+	}
+	return o, o.Validate()
+}
+
+// parseTime parses an RFC 3339 time or a Unix timestamp
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.UTC(), nil
+	}
+	if unix, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(unix, 0).UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("time %q must be RFC 3339 or a Unix timestamp", s)
+}
@@ -0,0 +1,138 @@
+package verify
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tinkershack/meteomunch/plumber"
+)
+
+func TestReadCSV(t *testing.T) {
+	tests := []struct {
+		name string
+		csv  string
+		want []Observation // nil for an error
+	}{
+		{"observations", `station,latitude,longitude,time,temperature_2m,wind_speed_10m,precipitation
+VOCB,11.03,77.04,2024-10-01T06:00:00Z,27.5,11.1,0
+VOCB, 11.03, 77.04, 1727766000, 28, , 0.4
+`, []Observation{
+			{Station: "VOCB", Latitude: 11.03, Longitude: 77.04, Time: time.Date(2024, 10, 1, 6, 0, 0, 0, time.UTC),
+				Values: map[string]float64{"temperature_2m": 27.5, "wind_speed_10m": 11.1, "precipitation": 0}},
+			{Station: "VOCB", Latitude: 11.03, Longitude: 77.04, Time: time.Date(2024, 10, 1, 7, 0, 0, 0, time.UTC),
+				Values: map[string]float64{"temperature_2m": 28, "precipitation": 0.4}},
+		}},
+		{"header only", "station,latitude,longitude,time\n", []Observation{}},
+		{"empty", "", nil},
+		{"header out of order", "latitude,station,longitude,time\n", nil},
+		{"unknown field", "station,latitude,longitude,time,temperature\n", nil},
+		{"latitude not a number", "station,latitude,longitude,time\nVOCB,north,77.04,2024-10-01T06:00:00Z\n", nil},
+		{"latitude out of range", "station,latitude,longitude,time\nVOCB,91,77.04,2024-10-01T06:00:00Z\n", nil},
+		{"longitude out of range", "station,latitude,longitude,time\nVOCB,11.03,-180.5,2024-10-01T06:00:00Z\n", nil},
+		{"bad time", "station,latitude,longitude,time\nVOCB,11.03,77.04,yesterday\n", nil},
+		{"value not a number", "station,latitude,longitude,time,temperature_2m\nVOCB,11.03,77.04,2024-10-01T06:00:00Z,warm\n", nil},
+		{"short row", "station,latitude,longitude,time,temperature_2m\nVOCB,11.03,77.04,2024-10-01T06:00:00Z\n", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadCSV(strings.NewReader(tt.csv))
+			if tt.want == nil {
+				if err == nil {
+					t.Errorf("read %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			compareObservations(t, got, tt.want)
+		})
+	}
+}
+
+func TestReadJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want []Observation // nil for an error
+	}{
+		{"observations", `[{"station": "VOCB", "latitude": 11.03, "longitude": 77.04, "time": "2024-10-01T06:00:00Z", "values": {"temperature_2m": 27.5}}]`, []Observation{
+			{Station: "VOCB", Latitude: 11.03, Longitude: 77.04, Time: time.Date(2024, 10, 1, 6, 0, 0, 0, time.UTC), Values: map[string]float64{"temperature_2m": 27.5}},
+		}},
+		{"not an array", `{"station": "VOCB"}`, nil},
+		{"no time", `[{"station": "VOCB", "latitude": 11.03, "longitude": 77.04}]`, nil},
+		{"latitude out of range", `[{"station": "VOCB", "latitude": -95, "longitude": 77.04, "time": "2024-10-01T06:00:00Z"}]`, nil},
+		{"unknown field", `[{"station": "VOCB", "latitude": 11.03, "longitude": 77.04, "time": "2024-10-01T06:00:00Z", "values": {"temp": 27.5}}]`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadJSON(strings.NewReader(tt.json))
+			if tt.want == nil {
+				if err == nil {
+					t.Errorf("read %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			compareObservations(t, got, tt.want)
+		})
+	}
+}
+
+func compareObservations(t *testing.T, got, want []Observation) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("read %d observations, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.Station != w.Station || g.Latitude != w.Latitude || g.Longitude != w.Longitude || !g.Time.Equal(w.Time) || len(g.Values) != len(w.Values) {
+			t.Errorf("observation %d = %+v, want %+v", i, g, w)
+			continue
+		}
+		for name, v := range w.Values {
+			if gv, ok := g.Values[name]; !ok || gv != v {
+				t.Errorf("observation %d %s = %v, want %v", i, name, gv, v)
+			}
+		}
+	}
+}
+
+func TestObservations(t *testing.T) {
+	_, obs := verifyFixture()
+	src, err := NewObservations(obs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bad := Observation{Station: "bad", Latitude: 100, Time: issued}
+	if err := src.Add(obs[0], bad); err == nil || src.Len() != len(obs) {
+		t.Errorf("added an invalid observation, err = %v, %d held", err, src.Len())
+	}
+
+	tests := []struct {
+		name string
+		q    ObservationQuery
+		want int
+	}{
+		{"all", ObservationQuery{}, 5},
+		{"near", ObservationQuery{Location: plumber.NewCoordinates(46.68, 7.86)}, 4},
+		{"wider", ObservationQuery{Location: plumber.NewCoordinates(46.68, 7.86), Tolerance: 7}, 5},
+		{"first day", ObservationQuery{From: issued, To: issued.Add(24 * time.Hour)}, 3},
+		{"to excluded", ObservationQuery{To: issued.Add(time.Hour)}, 1}, // Not the far station at 1:00
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := src.Observations(context.Background(), tt.q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != tt.want {
+				t.Errorf("got %d observations, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,301 @@
+// Package verify scores archived forecasts against observations
+//
+// Observations come from a Source: files imported into Observations, or any other implementation such as a
+// feed of station reports. Every archived forecast is matched with the observations made near its location,
+// and the forecast errors are aggregated per provider, model, field and lead time, i.e. how far ahead of the
+// observation the forecast was issued.
+//
+// Scores are continuous, MAE, RMSE and bias, and, for fields with an event threshold like "it rains", categorical:
+// how many events were forecast and observed (hits), observed only (misses) or forecast only (false alarms).
+// Report.Weights turns the scores into relative provider weights for a weighted consensus.
+package verify
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/tinkershack/meteomunch/store"
+)
+
+const (
+	// DefaultTolerance is how far, in degrees, a station may be from a forecast's location to verify it;
+	// half a cell of the 0.25 degree grids most models use
+	DefaultTolerance = 0.125
+	// DefaultLeadBucket is the width of the lead time ranges scores are aggregated over
+	DefaultLeadBucket = 24 * time.Hour
+)
+
+// DefaultFields are the hourly fields verified when Options.Fields is empty
+var DefaultFields = []string{"temperature_2m", "wind_speed_10m", "precipitation"}
+
+// DefaultThresholds define the events scored by hits and misses when Options.Thresholds is nil:
+// a field's event happens when its value reaches the threshold
+var DefaultThresholds = map[string]float64{
+	"precipitation":  0.1, // mm in an hour, it rains
+	"wind_speed_10m": 25,  // km/h, too windy for many outdoor activities
+}
+
+// Options tune the verification; the zero value uses the defaults
+type Options struct {
+	Fields     []string           // JSON names of the hourly fields to verify
+	Thresholds map[string]float64 // Event thresholds by field
+	LeadBucket time.Duration      // Width of the lead time ranges
+	Tolerance  float64            // Degrees a station may be off a forecast's location
+}
+
+func (o Options) withDefaults() Options {
+	if len(o.Fields) == 0 {
+		o.Fields = DefaultFields
+	}
+	if o.Thresholds == nil {
+		o.Thresholds = DefaultThresholds
+	}
+	if o.LeadBucket <= 0 {
+		o.LeadBucket = DefaultLeadBucket
+	}
+	if o.Tolerance <= 0 {
+		o.Tolerance = DefaultTolerance
+	}
+	return o
+}
+
+// Score aggregates the forecast errors of a provider's model for a field
+type Score struct {
+	Provider string `json:"provider"`
+	Model    string `json:"model"`
+	Field    string `json:"field"`
+	// Lead time range in hours, [LeadFrom, LeadTo); both are zero for the scores across all lead times
+	LeadFrom float64 `json:"lead_from"`
+	LeadTo   float64 `json:"lead_to"`
+
+	Count int     `json:"count"` // Number of forecast-observation pairs
+	MAE   float64 `json:"mae"`   // Mean absolute error
+	RMSE  float64 `json:"rmse"`  // Root mean square error
+	Bias  float64 `json:"bias"`  // Mean error, forecast minus observation; positive when the forecast runs high
+
+	Events *Contingency `json:"events,omitempty"` // Only for fields with an event threshold
+}
+
+// Contingency counts how well a provider forecast whether an event happens
+//
+// Rates are nil when they're undefined, e.g. the hit rate when the event was never observed.
+type Contingency struct {
+	Threshold        float64  `json:"threshold"`
+	Hits             int      `json:"hits"`              // Forecast and observed
+	Misses           int      `json:"misses"`            // Observed but not forecast
+	FalseAlarms      int      `json:"false_alarms"`      // Forecast but not observed
+	CorrectNegatives int      `json:"correct_negatives"` // Neither forecast nor observed
+	HitRate          *float64 `json:"hit_rate"`          // Share of observed events that were forecast
+	MissRate         *float64 `json:"miss_rate"`         // Share of observed events that weren't forecast
+	FalseAlarmRatio  *float64 `json:"false_alarm_ratio"` // Share of forecast events that didn't happen
+	ThreatScore      *float64 `json:"threat_score"`      // Hits over hits, misses and false alarms
+}
+
+// Report is the outcome of a verification
+type Report struct {
+	Scores  []Score `json:"scores"`  // Per provider, model, field and lead time range
+	Overall []Score `json:"overall"` // Per provider, model and field across all lead times
+}
+
+// group identifies the pairs a Score aggregates
+type group struct {
+	provider, model, field string
+	bucket                 int // Lead time bucket, -1 for all lead times
+}
+
+// accumulator sums up the pairs of a group
+type accumulator struct {
+	n                  int
+	sumAbs, sumSq, sum float64
+	events             *Contingency
+}
+
+func (a *accumulator) add(forecast, observed float64, threshold *float64) {
+	e := forecast - observed
+	a.n++
+	a.sumAbs += math.Abs(e)
+	a.sumSq += e * e
+	a.sum += e
+
+	if threshold == nil {
+		return
+	}
+	if a.events == nil {
+		a.events = &Contingency{Threshold: *threshold}
+	}
+	f, o := forecast >= *threshold, observed >= *threshold
+	switch {
+	case f && o:
+		a.events.Hits++
+	case o:
+		a.events.Misses++
+	case f:
+		a.events.FalseAlarms++
+	default:
+		a.events.CorrectNegatives++
+	}
+}
+
+// Verify scores the forecasts against the observations
+//
+// Observations are matched to the nearest forecast hour. Only hours after a forecast was issued count;
+// hours it had already observed or analysed when it was fetched are skipped.
+func Verify(forecasts []*store.Record, obs []Observation, opts Options) *Report {
+	opts = opts.withDefaults()
+
+	// Observations by the hour they're matched to
+	byHour := make(map[int64][]*Observation)
+	for i := range obs {
+		h := obs[i].Time.Round(time.Hour).Unix()
+		byHour[h] = append(byHour[h], &obs[i])
+	}
+
+	acc := make(map[group]*accumulator)
+	for _, r := range forecasts {
+		if r.Data == nil {
+			continue
+		}
+		h := &r.Data.Hourly
+		for i, t := range h.Time {
+			lead := time.Unix(t, 0).Sub(r.FetchedAt)
+			if lead < 0 {
+				continue
+			}
+			for _, o := range byHour[t] {
+				if math.Abs(o.Latitude-r.Latitude) > opts.Tolerance || math.Abs(o.Longitude-r.Longitude) > opts.Tolerance {
+					continue
+				}
+				for _, field := range opts.Fields {
+					observed, ok := o.Values[field]
+					if !ok {
+						continue
+					}
+					forecast, ok := h.Value(field, i)
+					if !ok {
+						continue
+					}
+					var threshold *float64
+					if thr, ok := opts.Thresholds[field]; ok {
+						threshold = &thr
+					}
+					for _, bucket := range []int{int(lead / opts.LeadBucket), -1} {
+						g := group{provider: r.Provider, model: r.Model, field: field, bucket: bucket}
+						if acc[g] == nil {
+							acc[g] = &accumulator{}
+						}
+						acc[g].add(forecast, observed, threshold)
+					}
+				}
+			}
+		}
+	}
+
+	report := &Report{Scores: []Score{}, Overall: []Score{}}
+	for g, a := range acc {
+		s := Score{
+			Provider: g.provider,
+			Model:    g.model,
+			Field:    g.field,
+			Count:    a.n,
+			MAE:      a.sumAbs / float64(a.n),
+			RMSE:     math.Sqrt(a.sumSq / float64(a.n)),
+			Bias:     a.sum / float64(a.n),
+			Events:   a.events.withRates(),
+		}
+		if g.bucket < 0 {
+			report.Overall = append(report.Overall, s)
+			continue
+		}
+		s.LeadFrom = (time.Duration(g.bucket) * opts.LeadBucket).Hours()
+		s.LeadTo = (time.Duration(g.bucket+1) * opts.LeadBucket).Hours()
+		report.Scores = append(report.Scores, s)
+	}
+
+	order := func(a, b Score) int {
+		return cmp.Or(
+			cmp.Compare(a.Provider, b.Provider),
+			cmp.Compare(a.Model, b.Model),
+			cmp.Compare(a.Field, b.Field),
+			cmp.Compare(a.LeadFrom, b.LeadFrom),
+		)
+	}
+	slices.SortFunc(report.Scores, order)
+	slices.SortFunc(report.Overall, order)
+	return report
+}
+
+func (c *Contingency) withRates() *Contingency {
+	if c == nil {
+		return nil
+	}
+	ratio := func(num, den int) *float64 {
+		if den == 0 {
+			return nil
+		}
+		r := float64(num) / float64(den)
+		return &r
+	}
+	c.HitRate = ratio(c.Hits, c.Hits+c.Misses)
+	c.MissRate = ratio(c.Misses, c.Hits+c.Misses)
+	c.FalseAlarmRatio = ratio(c.FalseAlarms, c.Hits+c.FalseAlarms)
+	c.ThreatScore = ratio(c.Hits, c.Hits+c.Misses+c.FalseAlarms)
+	return c
+}
+
+// Run verifies the forecasts in fs matching q against the observations src has for the same time range
+func Run(ctx context.Context, fs store.ForecastStore, src Source, q store.Query, opts Options) (*Report, error) {
+	forecasts, err := fs.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	if len(forecasts) == 0 {
+		return nil, errors.New("no forecasts to verify")
+	}
+
+	obs, err := src.Observations(ctx, ObservationQuery{From: q.From, To: q.To})
+	if err != nil {
+		return nil, err
+	}
+	return Verify(forecasts, obs, opts), nil
+}
+
+// Weights suggests relative weights of the providers for a weighted consensus of the field
+//
+// Each provider is weighted by the inverse of its mean square error across all lead times and models,
+// scaled so that the weights average 1 like config.MeteoProvider.Weight does.
+func (r *Report) Weights(field string) map[string]float64 {
+	type pooled struct {
+		n     int
+		sumSq float64
+	}
+	byProvider := make(map[string]*pooled)
+	for _, s := range r.Overall {
+		if s.Field != field || s.Count == 0 {
+			continue
+		}
+		p := byProvider[s.Provider]
+		if p == nil {
+			p = &pooled{}
+			byProvider[s.Provider] = p
+		}
+		p.n += s.Count
+		p.sumSq += s.RMSE * s.RMSE * float64(s.Count)
+	}
+
+	weights := make(map[string]float64, len(byProvider))
+	var total float64
+	for name, p := range byProvider {
+		// A perfect score would get an infinite weight; a floor on the error keeps it finite
+		mse := math.Max(p.sumSq/float64(p.n), 1e-6)
+		weights[name] = 1 / mse
+		total += weights[name]
+	}
+	for name := range weights {
+		weights[name] *= float64(len(weights)) / total
+	}
+	return weights
+}
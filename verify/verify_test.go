@@ -0,0 +1,189 @@
+package verify
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/tinkershack/meteomunch/plumber"
+	"github.com/tinkershack/meteomunch/store"
+)
+
+var issued = time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+
+// hours returns the Unix timestamps of the given hours after issued
+func hours(h ...int) []int64 {
+	out := make([]int64, len(h))
+	for i, v := range h {
+		out[i] = issued.Add(time.Duration(v) * time.Hour).Unix()
+	}
+	return out
+}
+
+// verifyFixture has forecasts of providers a and b issued at midnight, and observations of a station nearby
+// as well as one outside the tolerance
+func verifyFixture() ([]*store.Record, []Observation) {
+	forecasts := []*store.Record{
+		store.NewRecord("a", "m", issued, &plumber.BaseData{Latitude: 46.68, Longitude: 7.86, Hourly: plumber.HourlyData{
+			Time:          hours(-1, 1, 2, 25),
+			Temperature2M: []float64{99, 10, 12, 20}, // The hour before the issue isn't a forecast
+			Precipitation: []float64{5, 0, 0.5, 0},
+		}}),
+		store.NewRecord("b", "m", issued, &plumber.BaseData{Latitude: 46.68, Longitude: 7.86, Hourly: plumber.HourlyData{
+			Time:          hours(1, 2),
+			Temperature2M: []float64{11, 11},
+			Precipitation: []float64{0.2, 0},
+		}}),
+		store.NewRecord("a", "m", issued, &plumber.BaseData{Latitude: 48, Longitude: 7.86, Hourly: plumber.HourlyData{
+			Time:          hours(1),
+			Temperature2M: []float64{-40},
+		}}),
+	}
+	at := func(h int, m int) time.Time {
+		return issued.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute)
+	}
+	obs := []Observation{
+		{Station: "near", Latitude: 46.7, Longitude: 7.85, Time: at(-1, 0), Values: map[string]float64{"temperature_2m": 0, "precipitation": 0}},
+		{Station: "near", Latitude: 46.7, Longitude: 7.85, Time: at(1, 10), Values: map[string]float64{"temperature_2m": 11, "precipitation": 0}},
+		{Station: "near", Latitude: 46.7, Longitude: 7.85, Time: at(2, -20), Values: map[string]float64{"temperature_2m": 11, "precipitation": 1}},
+		{Station: "near", Latitude: 46.7, Longitude: 7.85, Time: at(25, 0), Values: map[string]float64{"temperature_2m": 18}},
+		{Station: "far", Latitude: 40, Longitude: 7, Time: at(1, 0), Values: map[string]float64{"temperature_2m": 50}},
+	}
+	return forecasts, obs
+}
+
+func TestVerify(t *testing.T) {
+	forecasts, obs := verifyFixture()
+	report := Verify(forecasts, obs, Options{})
+
+	ptr := func(v float64) *float64 { return &v }
+	scores := []Score{
+		{Provider: "a", Model: "m", Field: "precipitation", LeadFrom: 0, LeadTo: 24, Count: 2, MAE: 0.25, RMSE: math.Sqrt(0.125), Bias: -0.25,
+			Events: &Contingency{Threshold: 0.1, Hits: 1, CorrectNegatives: 1, HitRate: ptr(1), MissRate: ptr(0), FalseAlarmRatio: ptr(0), ThreatScore: ptr(1)}},
+		{Provider: "a", Model: "m", Field: "temperature_2m", LeadFrom: 0, LeadTo: 24, Count: 2, MAE: 1, RMSE: 1, Bias: 0},
+		{Provider: "a", Model: "m", Field: "temperature_2m", LeadFrom: 24, LeadTo: 48, Count: 1, MAE: 2, RMSE: 2, Bias: 2},
+		{Provider: "b", Model: "m", Field: "precipitation", LeadFrom: 0, LeadTo: 24, Count: 2, MAE: 0.6, RMSE: math.Sqrt(0.52), Bias: -0.4,
+			Events: &Contingency{Threshold: 0.1, Misses: 1, FalseAlarms: 1, HitRate: ptr(0), MissRate: ptr(1), FalseAlarmRatio: ptr(1), ThreatScore: ptr(0)}},
+		{Provider: "b", Model: "m", Field: "temperature_2m", LeadFrom: 0, LeadTo: 24, Count: 2},
+	}
+	overall := []Score{
+		{Provider: "a", Model: "m", Field: "precipitation", Count: 2, MAE: 0.25, RMSE: math.Sqrt(0.125), Bias: -0.25},
+		{Provider: "a", Model: "m", Field: "temperature_2m", Count: 3, MAE: 4.0 / 3, RMSE: math.Sqrt2, Bias: 2.0 / 3},
+		{Provider: "b", Model: "m", Field: "precipitation", Count: 2, MAE: 0.6, RMSE: math.Sqrt(0.52), Bias: -0.4},
+		{Provider: "b", Model: "m", Field: "temperature_2m", Count: 2},
+	}
+	compareScores(t, "scores", report.Scores, scores)
+	compareScores(t, "overall", report.Overall, overall)
+}
+
+// compareScores compares scores to those expected, to within rounding, and their events if expected
+func compareScores(t *testing.T, name string, got, want []Score) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: got %d scores, want %d: %+v", name, len(got), len(want), got)
+	}
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+	for i, w := range want {
+		g := got[i]
+		if g.Provider != w.Provider || g.Model != w.Model || g.Field != w.Field || g.LeadFrom != w.LeadFrom || g.LeadTo != w.LeadTo || g.Count != w.Count ||
+			!near(g.MAE, w.MAE) || !near(g.RMSE, w.RMSE) || !near(g.Bias, w.Bias) {
+			t.Errorf("%s[%d] = %+v, want %+v", name, i, g, w)
+		}
+		if w.Events == nil {
+			continue
+		}
+		ge, we := g.Events, w.Events
+		rate := func(a, b *float64) bool { return (a == nil) == (b == nil) && (a == nil || near(*a, *b)) }
+		if ge == nil || ge.Threshold != we.Threshold || ge.Hits != we.Hits || ge.Misses != we.Misses || ge.FalseAlarms != we.FalseAlarms ||
+			ge.CorrectNegatives != we.CorrectNegatives || !rate(ge.HitRate, we.HitRate) || !rate(ge.MissRate, we.MissRate) ||
+			!rate(ge.FalseAlarmRatio, we.FalseAlarmRatio) || !rate(ge.ThreatScore, we.ThreatScore) {
+			t.Errorf("%s[%d] events = %+v, want %+v", name, i, ge, we)
+		}
+	}
+}
+
+func TestVerifyOptions(t *testing.T) {
+	forecasts, obs := verifyFixture()
+	report := Verify(forecasts, obs, Options{
+		Fields:     []string{"temperature_2m"},
+		Thresholds: map[string]float64{"temperature_2m": 12},
+		LeadBucket: 48 * time.Hour,
+		Tolerance:  10, // The far station and forecast count too
+	})
+	// At 1:00 both stations verify all three forecasts; a has two more hours near, b one
+	for _, s := range report.Scores {
+		if s.Field != "temperature_2m" || s.LeadTo != 48 || s.Events == nil || s.Events.Threshold != 12 {
+			t.Errorf("score = %+v, want temperatures over 48 h with an event at 12 °C", s)
+		}
+	}
+	if len(report.Overall) != 2 || report.Overall[0].Count != 6 || report.Overall[1].Count != 3 {
+		t.Errorf("overall = %+v, want 6 pairs for a and 3 for b", report.Overall)
+	}
+}
+
+func TestWeights(t *testing.T) {
+	tests := []struct {
+		name    string
+		overall []Score
+		field   string
+		want    map[string]float64
+	}{
+		// Mean square errors of 0.125 and 0.52 weigh 8 to 1.923, scaled to average 1
+		{"inverse mean square error", []Score{
+			{Provider: "a", Field: "precipitation", Count: 2, RMSE: math.Sqrt(0.125)},
+			{Provider: "b", Field: "precipitation", Count: 2, RMSE: math.Sqrt(0.52)},
+			{Provider: "b", Field: "temperature_2m", Count: 2, RMSE: 5},
+		}, "precipitation", map[string]float64{"a": 1.612403, "b": 0.387597}},
+		// The models of a provider are pooled by their counts: (1 * 1 + 3 * 9) / 4 = 7
+		{"pooled models", []Score{
+			{Provider: "a", Model: "m1", Field: "temperature_2m", Count: 1, RMSE: 1},
+			{Provider: "a", Model: "m2", Field: "temperature_2m", Count: 3, RMSE: 3},
+			{Provider: "b", Field: "temperature_2m", Count: 4, RMSE: math.Sqrt(7)},
+		}, "temperature_2m", map[string]float64{"a": 1, "b": 1}},
+		{"perfect", []Score{
+			{Provider: "a", Field: "temperature_2m", Count: 2, RMSE: 0},
+			{Provider: "b", Field: "temperature_2m", Count: 2, RMSE: 1},
+		}, "temperature_2m", map[string]float64{"a": 2, "b": 0}},
+		{"unverified field", []Score{{Provider: "a", Field: "temperature_2m", Count: 2, RMSE: 1}}, "precipitation", map[string]float64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := (&Report{Overall: tt.overall}).Weights(tt.field)
+			if len(got) != len(tt.want) {
+				t.Fatalf("weights = %v, want %v", got, tt.want)
+			}
+			for name, w := range tt.want {
+				if math.Abs(got[name]-w) > 1e-5 {
+					t.Errorf("weights = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	forecasts, obs := verifyFixture()
+	fs := store.NewMemory()
+	src, err := NewObservations(obs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Run(context.Background(), fs, src, store.Query{}, Options{}); err == nil {
+		t.Error("verified an empty archive")
+	}
+	for _, r := range forecasts {
+		if err := fs.Put(context.Background(), r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Only b, and only the observations of the first forecast hour
+	report, err := Run(context.Background(), fs, src, store.Query{Provider: "b", From: issued, To: issued.Add(90 * time.Minute)}, Options{Fields: []string{"temperature_2m"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Overall) != 1 || report.Overall[0].Provider != "b" || report.Overall[0].Count != 1 {
+		t.Errorf("overall = %+v, want one pair for b", report.Overall)
+	}
+}